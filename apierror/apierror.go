@@ -0,0 +1,166 @@
+// Package apierror define o envelope de erro da API: cada erro tem um código estável
+// (ex.: REPORT_ALREADY_EXISTS), o status HTTP correspondente e, nos erros de validação,
+// os detalhes de cada campo
+package apierror
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
+)
+
+// Error é um erro de API. Os valores do catálogo (codes.go) são compartilhados; WithArgs,
+// WithDetails e Wrap retornam cópias. A mensagem vem do catálogo de i18n do idioma da
+// requisição ("errors.<Code>").
+type Error struct {
+	Status int
+	Code   string
+	// Args são os argumentos do formato da mensagem
+	Args    []any
+	Details []FieldError
+
+	cause error
+}
+
+// FieldError descreve o problema de um campo da requisição. Message é preenchida na resposta,
+// no idioma da requisição ("rules.<Rule>").
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Response é o corpo de todas as respostas de erro
+type Response struct {
+	Error     bool         `json:"error"`
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Details   []FieldError `json:"details,omitempty"`
+	RequestID string       `json:"requestId,omitempty"`
+}
+
+// codes registra os códigos do catálogo, para conferir que todos têm mensagem nos idiomas
+var codes []string
+
+func define(status int, code string) *Error {
+	codes = append(codes, code)
+	return &Error{Status: status, Code: code}
+}
+
+// Codes retorna os códigos definidos pelo pacote
+func Codes() []string {
+	return append([]string(nil), codes...)
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Code + ": " + e.cause.Error()
+	}
+	return e.Code + ": " + e.Message(i18n.Default)
+}
+
+// Unwrap retorna a causa registrada por Wrap
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is compara pelo código, para que errors.Is reconheça as cópias dos erros do catálogo
+func (e *Error) Is(target error) bool {
+	other, ok := target.(*Error)
+	return ok && other.Code == e.Code
+}
+
+// Message retorna a mensagem no idioma informado, com os argumentos aplicados
+func (e *Error) Message(locale string) string {
+	return i18n.Message(locale, "errors."+e.Code, e.Args...)
+}
+
+// WithArgs retorna uma cópia com os argumentos da mensagem
+func (e *Error) WithArgs(args ...any) *Error {
+	copied := *e
+	copied.Args = args
+	return &copied
+}
+
+// WithDetails retorna uma cópia com os detalhes dos campos
+func (e *Error) WithDetails(details ...FieldError) *Error {
+	copied := *e
+	copied.Details = append(append([]FieldError(nil), e.Details...), details...)
+	return &copied
+}
+
+// Wrap retorna uma cópia que guarda a causa; ela é registrada no log, nunca enviada ao cliente
+func (e *Error) Wrap(cause error) *Error {
+	copied := *e
+	copied.cause = cause
+	return &copied
+}
+
+// Status retorna o status HTTP com que err será respondido
+func Status(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
+// From converte err no erro de API correspondente. Erros do Fiber (rota inexistente,
+// corpo grande demais) viram o código do status; os demais, INTERNAL_ERROR.
+func From(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var fiberErr *fiber.Error
+	if !errors.As(err, &fiberErr) {
+		return ErrInternal.Wrap(err)
+	}
+	switch fiberErr.Code {
+	case fiber.StatusNotFound:
+		return ErrRouteNotFound
+	case fiber.StatusMethodNotAllowed:
+		return ErrMethodNotAllowed
+	case fiber.StatusRequestEntityTooLarge:
+		return ErrRequestTooLarge
+	case fiber.StatusTooManyRequests:
+		return ErrRateLimited
+	case fiber.StatusUnsupportedMediaType, fiber.StatusUnprocessableEntity:
+		return ErrInvalidBody
+	}
+	if fiberErr.Code >= fiber.StatusInternalServerError {
+		return ErrInternal.Wrap(err)
+	}
+	badRequest := *ErrBadRequest
+	badRequest.Status = fiberErr.Code
+	return &badRequest
+}
+
+// Handler é o ErrorHandler do Fiber: responde qualquer erro retornado por handlers e
+// middlewares no envelope padrão, no idioma da requisição. Erros 5xx com causa são
+// registrados no log da requisição.
+func Handler(c *fiber.Ctx, err error) error {
+	apiErr := From(err)
+
+	if apiErr.Status >= fiber.StatusInternalServerError && apiErr.cause != nil {
+		logging.From(c).Error("Unhandled error", "status", apiErr.Status, "code", apiErr.Code, "error", apiErr.cause)
+	}
+
+	locale := i18n.Locale(c)
+	return c.Status(apiErr.Status).JSON(Response{
+		Error:     true,
+		Code:      apiErr.Code,
+		Message:   apiErr.Message(locale),
+		Details:   localizeDetails(locale, apiErr.Details),
+		RequestID: logging.RequestID(c),
+	})
+}
@@ -0,0 +1,130 @@
+package apierror_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/utils"
+)
+
+func respond(t *testing.T, err error, acceptLanguage string) (int, apierror.Response) {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Use(i18n.Middleware())
+	app.Get("/", func(c *fiber.Ctx) error { return err })
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", acceptLanguage)
+	resp, testErr := app.Test(req)
+	if testErr != nil {
+		t.Fatal(testErr)
+	}
+	var body apierror.Response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestHandlerEnvelope(t *testing.T) {
+	cases := []struct {
+		name           string
+		err            error
+		acceptLanguage string
+		status         int
+		code           string
+		message        string
+	}{
+		{"catálogo", apierror.ErrReportAlreadyExists, "", 400, "REPORT_ALREADY_EXISTS", "Já existe um relatório para este desenvolvedor neste mês"},
+		{"com argumentos", apierror.ErrScoreOutOfRange.WithArgs("0.0", "10.0"), "", 400, "SCORE_OUT_OF_RANGE", "Pontuação deve estar entre 0.0 e 10.0"},
+		{"erro do Fiber", fiber.ErrMethodNotAllowed, "", 405, "METHOD_NOT_ALLOWED", "Método não permitido"},
+		{"status do Fiber sem código", fiber.ErrConflict, "", 409, "BAD_REQUEST", "Requisição inválida"},
+		{"erro inesperado", errors.New("pq: connection refused"), "", 500, "INTERNAL_ERROR", "Erro interno do servidor"},
+		{"em inglês", apierror.ErrReportAlreadyExists, "en-US", 400, "REPORT_ALREADY_EXISTS", "A report already exists for this developer in this month"},
+		{"em espanhol com duração", apierror.ErrLoginRateLimited.WithArgs(15 * time.Minute), "es", 429, "LOGIN_RATE_LIMITED", "Demasiados intentos de inicio de sesión. Inténtelo de nuevo en 15 minutos."},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := respond(t, tc.err, tc.acceptLanguage)
+			if status != tc.status || !body.Error || body.Code != tc.code || body.Message != tc.message {
+				t.Errorf("obtido %d %+v; esperado %d %s %q", status, body, tc.status, tc.code, tc.message)
+			}
+		})
+	}
+}
+
+func TestValidationDetails(t *testing.T) {
+	type goal struct {
+		Title string `json:"title" validate:"required"`
+	}
+	type request struct {
+		Email string `json:"email" validate:"required,email"`
+		Level int    `json:"level" validate:"gte=1,lte=5"`
+		Goals []goal `json:"goals" validate:"dive"`
+	}
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(apierror.JSONFieldName)
+
+	err := apierror.Validation(validate.Struct(request{Email: "ana", Level: 7, Goals: []goal{{}}}))
+	if !errors.Is(err, apierror.ErrValidation) {
+		t.Fatalf("esperava VALIDATION_FAILED, obteve %v", err)
+	}
+
+	expected := map[string][]apierror.FieldError{
+		"pt-BR": {
+			{Field: "email", Rule: "email", Message: "deve ser um email válido"},
+			{Field: "level", Rule: "lte", Param: "5", Message: "deve ser menor ou igual a 5"},
+			{Field: "goals[0].title", Rule: "required", Message: "é obrigatório"},
+		},
+		"en": {
+			{Field: "email", Rule: "email", Message: "must be a valid email"},
+			{Field: "level", Rule: "lte", Param: "5", Message: "must be less than or equal to 5"},
+			{Field: "goals[0].title", Rule: "required", Message: "is required"},
+		},
+	}
+	for locale, details := range expected {
+		_, body := respond(t, err, locale)
+		if len(body.Details) != len(details) {
+			t.Fatalf("%s: detalhes %+v", locale, body.Details)
+		}
+		for i, detail := range body.Details {
+			if detail != details[i] {
+				t.Errorf("%s: detalhe %d: obtido %+v, esperado %+v", locale, i, detail, details[i])
+			}
+		}
+	}
+
+	if apierror.ErrValidation.Details != nil {
+		t.Error("WithDetails não pode alterar o erro do catálogo")
+	}
+
+	if got := apierror.RuleMessage("es", "regra_desconhecida", ""); got != "no es válido" {
+		t.Errorf("regra sem mensagem: %q", got)
+	}
+
+	weak := apierror.WeakPassword("newPassword", utils.ValidatePassword("curta"))
+	if len(weak.Details) != 1 || weak.Details[0].Rule != "password_min_length" {
+		t.Errorf("senha fraca: %+v", weak.Details)
+	}
+}
+
+func TestCodesHaveMessages(t *testing.T) {
+	for _, code := range apierror.Codes() {
+		for _, locale := range i18n.Supported {
+			if !i18n.Has(locale, "errors."+code) {
+				t.Errorf("%s: código %s sem mensagem", locale, code)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package apierror
+
+import "github.com/gofiber/fiber/v2"
+
+// Códigos estáveis da API. Os clientes devem decidir pelo código; a mensagem pode mudar.
+// As mensagens de cada código estão nos catálogos de i18n/locales.
+
+// Requisição
+var (
+	ErrBadRequest       = define(fiber.StatusBadRequest, "BAD_REQUEST")
+	ErrInvalidBody      = define(fiber.StatusBadRequest, "INVALID_BODY")
+	ErrValidation       = define(fiber.StatusBadRequest, "VALIDATION_FAILED")
+	ErrInvalidID        = define(fiber.StatusBadRequest, "INVALID_ID")
+	ErrNothingToUpdate  = define(fiber.StatusBadRequest, "NOTHING_TO_UPDATE")
+	ErrRouteNotFound    = define(fiber.StatusNotFound, "ROUTE_NOT_FOUND")
+	ErrMethodNotAllowed = define(fiber.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED")
+	ErrRequestTooLarge  = define(fiber.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE")
+	ErrRateLimited      = define(fiber.StatusTooManyRequests, "RATE_LIMITED")
+	ErrLoginRateLimited = define(fiber.StatusTooManyRequests, "LOGIN_RATE_LIMITED")
+	ErrRequestTimeout   = define(fiber.StatusGatewayTimeout, "REQUEST_TIMEOUT")
+	ErrInternal         = define(fiber.StatusInternalServerError, "INTERNAL_ERROR")
+)
+
+// Autenticação e permissões
+var (
+	ErrMissingToken              = define(fiber.StatusUnauthorized, "MISSING_TOKEN")
+	ErrInvalidAuthHeader         = define(fiber.StatusUnauthorized, "INVALID_AUTH_HEADER")
+	ErrInvalidToken              = define(fiber.StatusUnauthorized, "INVALID_TOKEN")
+	ErrInvalidCredentials        = define(fiber.StatusUnauthorized, "INVALID_CREDENTIALS")
+	ErrWrongPassword             = define(fiber.StatusUnauthorized, "WRONG_PASSWORD")
+	ErrInvalidInstallKey         = define(fiber.StatusUnauthorized, "INVALID_INSTALL_KEY")
+	ErrUserInactive              = define(fiber.StatusForbidden, "USER_INACTIVE")
+	ErrPasswordChangeRequired    = define(fiber.StatusForbidden, "PASSWORD_CHANGE_REQUIRED")
+	ErrPasswordChangeNotRequired = define(fiber.StatusBadRequest, "PASSWORD_CHANGE_NOT_REQUIRED")
+	ErrWeakPassword              = define(fiber.StatusBadRequest, "WEAK_PASSWORD")
+	ErrAlreadyInstalled          = define(fiber.StatusForbidden, "ALREADY_INSTALLED")
+	ErrAdminRequired             = define(fiber.StatusForbidden, "ADMIN_REQUIRED")
+	ErrManagerOrAdminRequired    = define(fiber.StatusForbidden, "MANAGER_OR_ADMIN_REQUIRED")
+	ErrCompanyMembershipRequired = define(fiber.StatusForbidden, "COMPANY_MEMBERSHIP_REQUIRED")
+)
+
+// Usuários e empresas
+var (
+	ErrUserNotFound            = define(fiber.StatusNotFound, "USER_NOT_FOUND")
+	ErrEmailInUse              = define(fiber.StatusConflict, "EMAIL_IN_USE")
+	ErrCannotDeleteSelf        = define(fiber.StatusBadRequest, "CANNOT_DELETE_SELF")
+	ErrUserEditForbidden       = define(fiber.StatusForbidden, "USER_EDIT_FORBIDDEN")
+	ErrUserDeleteForbidden     = define(fiber.StatusForbidden, "USER_DELETE_FORBIDDEN")
+	ErrAdminEditForbidden      = define(fiber.StatusForbidden, "ADMIN_EDIT_FORBIDDEN")
+	ErrAdminPromotionForbidden = define(fiber.StatusForbidden, "ADMIN_PROMOTION_FORBIDDEN")
+	ErrUserCompanyChange       = define(fiber.StatusForbidden, "USER_COMPANY_CHANGE_FORBIDDEN")
+	ErrUserStatusChange        = define(fiber.StatusForbidden, "USER_STATUS_CHANGE_FORBIDDEN")
+	ErrCompanyNotFound         = define(fiber.StatusNotFound, "COMPANY_NOT_FOUND")
+	ErrCompanyUnavailable      = define(fiber.StatusBadRequest, "COMPANY_UNAVAILABLE")
+	ErrCompanyAlreadyExists    = define(fiber.StatusConflict, "COMPANY_ALREADY_EXISTS")
+	ErrCompanyHasUsers         = define(fiber.StatusConflict, "COMPANY_HAS_USERS")
+)
+
+// Times
+var (
+	ErrTeamNotFound           = define(fiber.StatusNotFound, "TEAM_NOT_FOUND")
+	ErrTeamAccessDenied       = define(fiber.StatusForbidden, "TEAM_ACCESS_DENIED")
+	ErrTeamNotInCompany       = define(fiber.StatusBadRequest, "TEAM_NOT_IN_COMPANY")
+	ErrParentTeamNotInCompany = define(fiber.StatusBadRequest, "PARENT_TEAM_NOT_IN_COMPANY")
+	ErrTeamHierarchyCycle     = define(fiber.StatusBadRequest, "TEAM_HIERARCHY_CYCLE")
+)
+
+// Desenvolvedores e carreira
+var (
+	ErrDeveloperNotFound        = define(fiber.StatusNotFound, "DEVELOPER_NOT_FOUND")
+	ErrDeveloperNotInCompany    = define(fiber.StatusBadRequest, "DEVELOPER_NOT_IN_COMPANY")
+	ErrDeveloperAccessDenied    = define(fiber.StatusForbidden, "DEVELOPER_ACCESS_DENIED")
+	ErrDeveloperDeleteForbidden = define(fiber.StatusForbidden, "DEVELOPER_DELETE_FORBIDDEN")
+	ErrLinkedUserNotInCompany   = define(fiber.StatusBadRequest, "LINKED_USER_NOT_IN_COMPANY")
+	ErrCareerTrackNotFound      = define(fiber.StatusNotFound, "CAREER_TRACK_NOT_FOUND")
+	ErrCareerTrackExists        = define(fiber.StatusConflict, "CAREER_TRACK_ALREADY_EXISTS")
+	ErrCareerLevelNotFound      = define(fiber.StatusNotFound, "CAREER_LEVEL_NOT_FOUND")
+	ErrCareerLevelExists        = define(fiber.StatusConflict, "CAREER_LEVEL_ALREADY_EXISTS")
+	ErrCareerLevelInUse         = define(fiber.StatusBadRequest, "CAREER_LEVEL_IN_USE")
+	ErrCareerLevelNotInCompany  = define(fiber.StatusBadRequest, "CAREER_LEVEL_NOT_IN_COMPANY")
+	ErrAlreadyAtLevel           = define(fiber.StatusBadRequest, "DEVELOPER_ALREADY_AT_LEVEL")
+	ErrLevelChangeInFuture      = define(fiber.StatusBadRequest, "LEVEL_CHANGE_IN_FUTURE")
+	ErrSkillNotFound            = define(fiber.StatusNotFound, "SKILL_NOT_FOUND")
+	ErrSkillExists              = define(fiber.StatusConflict, "SKILL_ALREADY_EXISTS")
+	ErrSkillNotInCompany        = define(fiber.StatusBadRequest, "SKILL_NOT_IN_COMPANY")
+	ErrSelfAssessmentForbidden  = define(fiber.StatusForbidden, "SELF_ASSESSMENT_FORBIDDEN")
+)
+
+// Relatórios, metas, 1:1s e comentários
+var (
+	ErrReportNotFound            = define(fiber.StatusNotFound, "REPORT_NOT_FOUND")
+	ErrReportAlreadyExists       = define(fiber.StatusBadRequest, "REPORT_ALREADY_EXISTS")
+	ErrReportNotForDeveloper     = define(fiber.StatusBadRequest, "REPORT_NOT_FOR_DEVELOPER")
+	ErrScoreOutOfRange           = define(fiber.StatusBadRequest, "SCORE_OUT_OF_RANGE")
+	ErrScoreStepMismatch         = define(fiber.StatusBadRequest, "SCORE_STEP_MISMATCH")
+	ErrScoreScaleInverted        = define(fiber.StatusBadRequest, "SCORE_SCALE_INVERTED")
+	ErrScoreStepTooLarge         = define(fiber.StatusBadRequest, "SCORE_STEP_TOO_LARGE")
+	ErrScoreScaleOutOfRange      = define(fiber.StatusBadRequest, "SCORE_SCALE_OUT_OF_RANGE")
+	ErrGoalNotFound              = define(fiber.StatusNotFound, "GOAL_NOT_FOUND")
+	ErrOwnerNotInCompany         = define(fiber.StatusBadRequest, "OWNER_NOT_IN_COMPANY")
+	ErrMeetingNotFound           = define(fiber.StatusNotFound, "MEETING_NOT_FOUND")
+	ErrActionItemNotFound        = define(fiber.StatusNotFound, "ACTION_ITEM_NOT_FOUND")
+	ErrActionItemOwnerRequired   = define(fiber.StatusForbidden, "ACTION_ITEM_OWNER_REQUIRED")
+	ErrCommentNotFound           = define(fiber.StatusNotFound, "COMMENT_NOT_FOUND")
+	ErrCommentAuthorRequired     = define(fiber.StatusForbidden, "COMMENT_AUTHOR_REQUIRED")
+	ErrInvalidParentComment      = define(fiber.StatusBadRequest, "INVALID_PARENT_COMMENT")
+	ErrMentionedUserNotInCompany = define(fiber.StatusBadRequest, "MENTIONED_USER_NOT_IN_COMPANY")
+)
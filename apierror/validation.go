@@ -0,0 +1,105 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"tivix-performance-tracker-backend/i18n"
+)
+
+// JSONFieldName é o TagNameFunc dos validadores: os erros usam o nome do campo no JSON
+func JSONFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// Field cria o detalhe de um campo que não atende à regra rule
+func Field(field, rule, param string) FieldError {
+	return FieldError{Field: field, Rule: rule, Param: param}
+}
+
+// RuleMessage descreve a regra no idioma informado; %s na mensagem recebe o parâmetro da
+// regra. Regras sem mensagem no catálogo são descritas como "invalid".
+func RuleMessage(locale, rule, param string) string {
+	key := "rules." + rule
+	if !i18n.Has(i18n.Default, key) {
+		key = "rules.invalid"
+	}
+	message := i18n.Message(locale, key)
+	if strings.Contains(message, "%s") {
+		message = fmt.Sprintf(message, param)
+	}
+	return message
+}
+
+// localizeDetails retorna uma cópia dos detalhes com as mensagens no idioma informado
+func localizeDetails(locale string, details []FieldError) []FieldError {
+	if len(details) == 0 {
+		return nil
+	}
+	localized := make([]FieldError, len(details))
+	for i, detail := range details {
+		detail.Message = RuleMessage(locale, detail.Rule, detail.Param)
+		localized[i] = detail
+	}
+	return localized
+}
+
+// Validation converte os erros do go-playground/validator em VALIDATION_FAILED, com um
+// detalhe por campo. Outros erros viram INVALID_BODY.
+func Validation(err error) *Error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return ErrInvalidBody.Wrap(err)
+	}
+
+	details := make([]FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		details = append(details, Field(fieldPath(fieldErr), fieldErr.Tag(), fieldErr.Param()))
+	}
+	return ErrValidation.WithDetails(details...)
+}
+
+// fieldPath é o caminho do campo sem o nome do struct raiz (goals[0].title)
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fieldErr.Field()
+}
+
+// Required é o erro de um campo obrigatório ausente
+func Required(field string) *Error {
+	return ErrValidation.WithDetails(Field(field, "required", ""))
+}
+
+// InvalidField é o erro de um campo (do corpo ou da query) fora da regra rule
+func InvalidField(field, rule, param string) *Error {
+	return ErrValidation.WithDetails(Field(field, rule, param))
+}
+
+// InvalidID é o erro de um identificador que não é um UUID
+func InvalidID(field string) *Error {
+	return ErrInvalidID.WithDetails(Field(field, "uuid", ""))
+}
+
+// WeakPassword é o erro de uma senha recusada pela política de senhas, com a regra violada
+// nos detalhes do campo
+func WeakPassword(field string, err error) *Error {
+	var rule interface{ PasswordRule() string }
+	if !errors.As(err, &rule) {
+		return ErrWeakPassword
+	}
+	return ErrWeakPassword.WithDetails(Field(field, rule.PasswordRule(), ""))
+}
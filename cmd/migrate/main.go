@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"tivix-performance-tracker-backend/config"
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/migrations"
+)
+
+const usage = `Uso: go run ./cmd/migrate <comando> [opções]
+
+Comandos:
+  status [-json]             Lista as migrações e sua situação (padrão)
+  check                      Falha se houver migrações pendentes ou alteradas
+  up [-to VERSÃO]            Aplica as migrações pendentes, opcionalmente até uma versão
+  down [-steps N | -to VERSÃO]
+                             Desfaz as últimas N migrações (padrão 1) ou todas acima de uma versão
+  redo                       Desfaz e reaplica a última migração
+  force VERSÃO               Marca a migração como aplicada sem executá-la
+  create [-dir DIR] NOME     Cria VERSÃO_nome.up.sql e .down.sql com versão em timestamp
+
+As conexões usam as mesmas variáveis de ambiente da API (DB_DRIVER, DB_HOST, SQLITE_PATH...).
+`
+
+func main() {
+	log.SetFlags(0)
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	command, args := "status", []string{}
+	if flag.NArg() > 0 {
+		command, args = flag.Arg(0), flag.Args()[1:]
+	}
+
+	var err error
+	switch command {
+	case "status":
+		err = runStatus(args)
+	case "check":
+		err = runCheck(args)
+	case "up":
+		err = runUp(args)
+	case "down":
+		err = runDown(args)
+	case "redo":
+		err = runRedo(args)
+	case "force":
+		err = runForce(args)
+	case "create":
+		err = runCreate(args)
+	case "help", "-h", "--help":
+		flag.Usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "comando desconhecido: %s\n\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+}
+
+// newManager conecta ao banco configurado no ambiente, como a API faz ao iniciar
+func newManager() *migrations.MigrationManager {
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Aviso: arquivo .env não encontrado: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	database.Connect(cfg)
+
+	manager := migrations.NewMigrationManager(database.DB.DB)
+	manager.Dialect = database.DB.DriverName()
+	return manager
+}
+
+// parseFlags interpreta as opções de um subcomando e exige a quantidade esperada de argumentos posicionais
+func parseFlags(set *flag.FlagSet, args []string, positional int) ([]string, error) {
+	if err := set.Parse(args); err != nil {
+		return nil, err
+	}
+	if set.NArg() != positional {
+		return nil, fmt.Errorf("%s: esperava %d argumento(s), recebeu %d", set.Name(), positional, set.NArg())
+	}
+	return set.Args(), nil
+}
+
+func runStatus(args []string) error {
+	set := flag.NewFlagSet("status", flag.ExitOnError)
+	asJSON := set.Bool("json", false, "emite a situação em JSON")
+	if _, err := parseFlags(set, args, 0); err != nil {
+		return err
+	}
+
+	statuses, err := newManager().Status()
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statuses)
+	}
+
+	printStatus(statuses)
+	return nil
+}
+
+func printStatus(statuses []migrations.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tDescrição\tStatus\tData")
+	fmt.Fprintln(w, "---\t----------\t------\t----")
+
+	pendingCount := 0
+	appliedCount := 0
+	changedCount := 0
+	missingCount := 0
+
+	for _, migration := range statuses {
+		status := "⏳ Pendente"
+		date := "-"
+
+		if migration.Applied {
+			status = "✅ Aplicada"
+			appliedCount++
+
+			switch {
+			case migration.Missing:
+				status = "❓ Sem arquivo"
+				missingCount++
+			case migration.Changed:
+				status = "⚠️  Alterada"
+				changedCount++
+			}
+
+			if migration.AppliedAt != nil {
+				date = migration.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+		} else {
+			pendingCount++
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			migration.ID,
+			migration.Description,
+			status,
+			date)
+	}
+
+	w.Flush()
+
+	fmt.Println()
+	fmt.Printf("📈 Resumo das Migrações:\n")
+	fmt.Printf("   • Total: %d\n", len(statuses))
+	fmt.Printf("   • Aplicadas: %d\n", appliedCount)
+	fmt.Printf("   • Pendentes: %d\n", pendingCount)
+	if changedCount > 0 {
+		fmt.Printf("   • Alteradas após aplicadas: %d\n", changedCount)
+	}
+	if missingCount > 0 {
+		fmt.Printf("   • Aplicadas sem arquivo: %d\n", missingCount)
+	}
+
+	if changedCount > 0 {
+		fmt.Println()
+		fmt.Println("❌ Há migrações aplicadas cujo arquivo foi editado; a aplicação não vai iniciar as migrações.")
+		fmt.Println("   Restaure o arquivo original e crie uma nova migração com a mudança.")
+	} else if pendingCount > 0 {
+		fmt.Println()
+		fmt.Println("⚠️  Existem migrações pendentes.")
+		fmt.Println("   Aplique-as com o comando up ou iniciando a aplicação:")
+		fmt.Println("   go run ./cmd/migrate up")
+	} else {
+		fmt.Println()
+		fmt.Println("🎉 Todas as migrações estão atualizadas!")
+	}
+}
+
+func runCheck(args []string) error {
+	set := flag.NewFlagSet("check", flag.ExitOnError)
+	if _, err := parseFlags(set, args, 0); err != nil {
+		return err
+	}
+
+	if err := newManager().Check(); err != nil {
+		return err
+	}
+
+	log.Println("✅ Schema do banco atualizado")
+	return nil
+}
+
+func runUp(args []string) error {
+	set := flag.NewFlagSet("up", flag.ExitOnError)
+	to := set.String("to", "", "aplica apenas até esta versão (inclusive)")
+	if _, err := parseFlags(set, args, 0); err != nil {
+		return err
+	}
+
+	return newManager().MigrateTo(*to)
+}
+
+func runDown(args []string) error {
+	set := flag.NewFlagSet("down", flag.ExitOnError)
+	steps := set.Int("steps", 0, "número de migrações a desfazer (padrão 1)")
+	to := set.String("to", "", "desfaz todas as migrações acima desta versão (000 desfaz todas)")
+	if _, err := parseFlags(set, args, 0); err != nil {
+		return err
+	}
+
+	if *to != "" && *steps != 0 {
+		return fmt.Errorf("down: use -steps ou -to, não ambos")
+	}
+	if *to != "" {
+		return newManager().Rollback(*to)
+	}
+	if *steps == 0 {
+		*steps = 1
+	}
+	return newManager().RollbackSteps(*steps)
+}
+
+func runRedo(args []string) error {
+	set := flag.NewFlagSet("redo", flag.ExitOnError)
+	if _, err := parseFlags(set, args, 0); err != nil {
+		return err
+	}
+
+	return newManager().Redo()
+}
+
+func runForce(args []string) error {
+	set := flag.NewFlagSet("force", flag.ExitOnError)
+	positional, err := parseFlags(set, args, 1)
+	if err != nil {
+		return err
+	}
+	return newManager().Force(positional[0])
+}
+
+func runCreate(args []string) error {
+	set := flag.NewFlagSet("create", flag.ExitOnError)
+	dir := set.String("dir", "migrations", "diretório dos arquivos (migrations/sqlite para o modo SQLite)")
+	positional, err := parseFlags(set, args, 1)
+	if err != nil {
+		return err
+	}
+
+	upPath, downPath, err := migrations.CreateMigration(*dir, positional[0], time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Criados:\n   %s\n   %s\n", upPath, downPath)
+	fmt.Println("   Recompile a aplicação para embutir os novos arquivos.")
+	return nil
+}
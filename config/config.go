@@ -1,54 +1,472 @@
 package config
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
 )
 
+// Config é a configuração da aplicação, carregada uma vez na inicialização por Load e
+// repassada a quem precisa. Cada campo vem, em ordem crescente de prioridade, do valor
+// padrão (envDefault), do arquivo CONFIG_FILE (chave = nome da variável em minúsculas),
+// da variável de ambiente e do arquivo apontado por <VARIÁVEL>_FILE.
 type Config struct {
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBSSLMode  string
-	Port       string
-	Host       string
-	Environment string
-	JWTSecret  string
-	CORSOrigin string
-}
-
-func LoadConfig() *Config {
-	return &Config{
-		DBHost:      getEnv("DB_HOST", "localhost"),
-		DBPort:      getEnv("DB_PORT", "5432"),
-		DBUser:      getEnv("DB_USER", "postgres"),
-		DBPassword:  getEnv("DB_PASSWORD", "postgres"),
-		DBName:      getEnv("DB_NAME", "tivix_performance_tracker"),
-		DBSSLMode:   getEnv("DB_SSLMODE", "disable"),
-		Port:        getEnv("PORT", "8080"),
-		Host:        getEnv("HOST", "localhost"),
-		Environment: getEnv("ENVIRONMENT", "development"),
-		JWTSecret:   getRequiredEnv("JWT_SECRET"),
-		CORSOrigin:  getEnv("CORS_ORIGIN", "http://localhost:5173"),
-	}
-}
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
-func getRequiredEnv(key string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		log.Fatalf("Required environment variable %s is not set", key)
-	}
-	if len(value) < 32 {
-		log.Fatalf("Environment variable %s must be at least 32 characters long for security", key)
-	}
-	return value
+	Environment string `env:"ENVIRONMENT" envDefault:"development"`
+
+	// Log estruturado: nível (debug, info, warn, error) e formato (json ou text)
+	LogLevel  string `env:"LOG_LEVEL" envDefault:"info"`
+	LogFormat string `env:"LOG_FORMAT" envDefault:"json"`
+
+	// Servidor (Host vazio escuta em todas as interfaces, como exigem os containers)
+	Host         string        `env:"HOST"`
+	Port         int           `env:"PORT" envDefault:"8080"`
+	ReadTimeout  time.Duration `env:"READ_TIMEOUT" envDefault:"15s"`
+	WriteTimeout time.Duration `env:"WRITE_TIMEOUT" envDefault:"15s"`
+	IdleTimeout  time.Duration `env:"IDLE_TIMEOUT" envDefault:"60s"`
+	BodyLimit    int           `env:"BODY_LIMIT" envDefault:"10485760"`
+	// ShutdownTimeout é quanto o encerramento espera as requisições em andamento
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"30s"`
+	// HealthCheckTimeout limita as verificações de /readyz
+	HealthCheckTimeout time.Duration `env:"HEALTH_CHECK_TIMEOUT" envDefault:"2s"`
+	// RequestTimeout é o prazo de cada requisição; ao expirar, as queries em andamento são
+	// canceladas e as falhas da requisição respondem 504
+	RequestTimeout time.Duration `env:"REQUEST_TIMEOUT" envDefault:"10s"`
+
+	// Banco de dados
+	DBDriver          string        `env:"DB_DRIVER" envDefault:"postgres"`
+	SQLitePath        string        `env:"SQLITE_PATH" envDefault:"tivix_performance_tracker.db"`
+	DBHost            string        `env:"DB_HOST" envDefault:"localhost"`
+	DBPort            int           `env:"DB_PORT" envDefault:"5432"`
+	DBUser            string        `env:"DB_USER" envDefault:"postgres"`
+	DBPassword        string        `env:"DB_PASSWORD" envDefault:"postgres"`
+	DBName            string        `env:"DB_NAME" envDefault:"tivix_performance_tracker"`
+	DBSSLMode         string        `env:"DB_SSLMODE" envDefault:"disable"`
+	DBMaxOpenConns    int           `env:"DB_MAX_OPEN_CONNS" envDefault:"25"`
+	DBMaxIdleConns    int           `env:"DB_MAX_IDLE_CONNS" envDefault:"5"`
+	DBConnMaxLifetime time.Duration `env:"DB_CONN_MAX_LIFETIME" envDefault:"30m"`
+	DBConnMaxIdleTime time.Duration `env:"DB_CONN_MAX_IDLE_TIME" envDefault:"5m"`
+	// MigrationMode define o que fazer com as migrações ao iniciar: migrate, check ou skip
+	MigrationMode string `env:"MIGRATION_MODE" envDefault:"migrate"`
+
+	// Autenticação
+	JWTSecret      string        `env:"JWT_SECRET"`
+	AccessTokenTTL time.Duration `env:"ACCESS_TOKEN_TTL" envDefault:"15m"`
+	InstallKey     string        `env:"INSTALL_KEY" envDefault:"TIVIX_INSTALL_2024"`
+
+	// CORS: sem CORS_ORIGINS, usa as origens padrão do ambiente (ou CORS_ORIGIN)
+	CORSOrigins []string `env:"CORS_ORIGINS"`
+	CORSOrigin  string   `env:"CORS_ORIGIN"`
+
+	// Métricas do Prometheus: em um listener separado (METRICS_ADDR, ex.: ":9090") ou em
+	// /metrics no listener principal, protegido por METRICS_TOKEN
+	MetricsAddr  string `env:"METRICS_ADDR"`
+	MetricsToken string `env:"METRICS_TOKEN"`
+
+	// Tracing (OpenTelemetry): none, otlp (coletor via OTLP/HTTP) ou stdout
+	TracingExporter    string  `env:"TRACING_EXPORTER" envDefault:"none"`
+	TracingEndpoint    string  `env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	TracingServiceName string  `env:"OTEL_SERVICE_NAME" envDefault:"tivix-performance-tracker-api"`
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+
+	// Rate limiting por IP
+	RateLimitMax         int           `env:"RATE_LIMIT_MAX" envDefault:"100"`
+	RateLimitWindow      time.Duration `env:"RATE_LIMIT_WINDOW" envDefault:"1m"`
+	LoginRateLimitMax    int           `env:"LOGIN_RATE_LIMIT_MAX" envDefault:"5"`
+	LoginRateLimitWindow time.Duration `env:"LOGIN_RATE_LIMIT_WINDOW" envDefault:"15m"`
+}
+
+// ConfigFileEnv aponta para um arquivo YAML (.yaml/.yml) ou TOML (.toml) opcional
+const ConfigFileEnv = "CONFIG_FILE"
+
+var environmentOrigins = map[string][]string{
+	"development": {
+		"http://localhost:3000",
+		"http://localhost:5173",
+		"http://127.0.0.1:5173",
+	},
+	"production": {
+		"https://performancetracker.tivix.com.br",
+		"https://performance.valiantgroup.com.br",
+	},
+}
+
+// Load monta a configuração a partir dos valores padrão, do arquivo CONFIG_FILE, do
+// ambiente e dos arquivos *_FILE, e a valida. Os erros de todos os campos são
+// reportados juntos.
+func Load() (*Config, error) {
+	cfg := &Config{}
+
+	values := defaults()
+
+	if path := os.Getenv(ConfigFileEnv); path != "" {
+		fileValues, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileValues {
+			values[key] = value
+		}
+	}
+
+	var problems []string
+	for _, field := range fields() {
+		value, fromEnv, err := lookupEnv(field.env)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if fromEnv {
+			values[field.env] = value
+		}
+	}
+
+	for _, field := range fields() {
+		value, ok := values[field.env]
+		if !ok {
+			continue
+		}
+		if err := field.set(cfg, value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", field.env, err))
+		}
+	}
+
+	if len(cfg.CORSOrigins) == 0 {
+		cfg.CORSOrigins = cfg.defaultOrigins()
+	}
+
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("configuração inválida:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return cfg, nil
+}
+
+// Defaults retorna a configuração apenas com os valores padrão, sem ler ambiente nem
+// arquivos e sem segredo JWT
+func Defaults() *Config {
+	cfg := &Config{}
+	for _, field := range fields() {
+		if field.fallback != "" {
+			field.set(cfg, field.fallback)
+		}
+	}
+	cfg.CORSOrigins = cfg.defaultOrigins()
+	return cfg
+}
+
+// IsProduction indica se a aplicação roda em produção
+func (c *Config) IsProduction() bool {
+	return c.Environment == "production"
+}
+
+// IsDevelopment indica se a aplicação roda em desenvolvimento
+func (c *Config) IsDevelopment() bool {
+	return c.Environment == "development"
+}
+
+// Address é o endereço de escuta do servidor HTTP
+func (c *Config) Address() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+// LocalAddress é o endereço para falar com o próprio servidor, usado pelo healthcheck: o Host
+// configurado ou o loopback quando o servidor escuta em todas as interfaces
+func (c *Config) LocalAddress() string {
+	host := c.Host
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, strconv.Itoa(c.Port))
+}
+
+func (c *Config) defaultOrigins() []string {
+	if origins, ok := environmentOrigins[c.Environment]; ok {
+		return append([]string(nil), origins...)
+	}
+	if c.CORSOrigin != "" {
+		return []string{c.CORSOrigin}
+	}
+	return []string{"http://localhost:5173"}
+}
+
+func (c *Config) validate() []string {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	switch {
+	case c.JWTSecret == "":
+		add("JWT_SECRET: obrigatório (defina JWT_SECRET ou JWT_SECRET_FILE)")
+	case len(c.JWTSecret) < 32:
+		add("JWT_SECRET: deve ter ao menos 32 caracteres")
+	}
+
+	if c.Environment == "" {
+		add("ENVIRONMENT: não pode ser vazio")
+	}
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		add("LOG_LEVEL: %q inválido (use debug, info, warn ou error)", c.LogLevel)
+	}
+	switch strings.ToLower(c.LogFormat) {
+	case "json", "text":
+	default:
+		add("LOG_FORMAT: %q inválido (use json ou text)", c.LogFormat)
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		add("PORT: %d fora do intervalo 1-65535", c.Port)
+	}
+	if c.BodyLimit < 1 {
+		add("BODY_LIMIT: deve ser positivo")
+	}
+
+	switch c.DBDriver {
+	case "postgres":
+		if c.DBHost == "" || c.DBName == "" || c.DBUser == "" {
+			add("DB_HOST, DB_NAME e DB_USER: obrigatórios com DB_DRIVER=postgres")
+		}
+		if c.DBPort < 1 || c.DBPort > 65535 {
+			add("DB_PORT: %d fora do intervalo 1-65535", c.DBPort)
+		}
+	case "sqlite":
+		if c.SQLitePath == "" {
+			add("SQLITE_PATH: obrigatório com DB_DRIVER=sqlite")
+		}
+	default:
+		add("DB_DRIVER: %q não suportado (use postgres ou sqlite)", c.DBDriver)
+	}
+
+	if c.DBMaxOpenConns < 0 || c.DBMaxIdleConns < 0 {
+		add("DB_MAX_OPEN_CONNS e DB_MAX_IDLE_CONNS: não podem ser negativos")
+	} else if c.DBMaxOpenConns > 0 && c.DBMaxIdleConns > c.DBMaxOpenConns {
+		add("DB_MAX_IDLE_CONNS: %d maior que DB_MAX_OPEN_CONNS (%d)", c.DBMaxIdleConns, c.DBMaxOpenConns)
+	}
+
+	switch c.MigrationMode {
+	case "migrate", "check", "skip":
+	default:
+		add("MIGRATION_MODE: %q inválido (use migrate, check ou skip)", c.MigrationMode)
+	}
+
+	positive := map[string]time.Duration{
+		"READ_TIMEOUT":            c.ReadTimeout,
+		"WRITE_TIMEOUT":           c.WriteTimeout,
+		"IDLE_TIMEOUT":            c.IdleTimeout,
+		"SHUTDOWN_TIMEOUT":        c.ShutdownTimeout,
+		"HEALTH_CHECK_TIMEOUT":    c.HealthCheckTimeout,
+		"REQUEST_TIMEOUT":         c.RequestTimeout,
+		"ACCESS_TOKEN_TTL":        c.AccessTokenTTL,
+		"RATE_LIMIT_WINDOW":       c.RateLimitWindow,
+		"LOGIN_RATE_LIMIT_WINDOW": c.LoginRateLimitWindow,
+	}
+	names := make([]string, 0, len(positive))
+	for name := range positive {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if positive[name] <= 0 {
+			add("%s: deve ser uma duração positiva", name)
+		}
+	}
+	if c.DBConnMaxLifetime < 0 || c.DBConnMaxIdleTime < 0 {
+		add("DB_CONN_MAX_LIFETIME e DB_CONN_MAX_IDLE_TIME: não podem ser negativos")
+	}
+
+	switch c.TracingExporter {
+	case "none", "otlp", "stdout":
+	default:
+		add("TRACING_EXPORTER: %q inválido (use none, otlp ou stdout)", c.TracingExporter)
+	}
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		add("TRACING_SAMPLE_RATIO: %v fora do intervalo 0-1", c.TracingSampleRatio)
+	}
+	if c.TracingEndpoint != "" {
+		if parsed, err := url.Parse(c.TracingEndpoint); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			add("OTEL_EXPORTER_OTLP_ENDPOINT: %q não é uma URL válida (ex.: http://localhost:4318)", c.TracingEndpoint)
+		}
+	}
+
+	if c.RateLimitMax < 1 {
+		add("RATE_LIMIT_MAX: deve ser positivo")
+	}
+	if c.LoginRateLimitMax < 1 {
+		add("LOGIN_RATE_LIMIT_MAX: deve ser positivo")
+	}
+
+	if c.InstallKey == "" {
+		add("INSTALL_KEY: não pode ser vazio")
+	}
+
+	for _, origin := range c.CORSOrigins {
+		parsed, err := url.Parse(origin)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || parsed.Path != "" {
+			add("CORS_ORIGINS: %q não é uma origem válida (ex.: https://app.exemplo.com)", origin)
+		}
+	}
+
+	return problems
+}
+
+// field associa um campo de Config à sua variável de ambiente
+type field struct {
+	index    int
+	env      string
+	fallback string
+}
+
+func fields() []field {
+	t := reflect.TypeOf(Config{})
+	result := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		result = append(result, field{index: i, env: f.Tag.Get("env"), fallback: f.Tag.Get("envDefault")})
+	}
+	return result
+}
+
+func defaults() map[string]string {
+	values := make(map[string]string)
+	for _, field := range fields() {
+		if field.fallback != "" {
+			values[field.env] = field.fallback
+		}
+	}
+	return values
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set converte value para o tipo do campo
+func (f field) set(cfg *Config, value string) error {
+	target := reflect.ValueOf(cfg).Elem().Field(f.index)
+
+	switch {
+	case target.Type() == durationType:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("duração inválida %q (ex.: 30s, 15m, 1h)", value)
+		}
+		target.SetInt(int64(duration))
+	case target.Kind() == reflect.Int:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("número inteiro inválido %q", value)
+		}
+		target.SetInt(int64(number))
+	case target.Kind() == reflect.Float64:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("número inválido %q", value)
+		}
+		target.SetFloat(number)
+	case target.Kind() == reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		target.Set(reflect.ValueOf(items))
+	default:
+		target.SetString(value)
+	}
+	return nil
+}
+
+// lookupEnv lê a variável ou o arquivo indicado por <VARIÁVEL>_FILE (para segredos
+// montados, como em Docker/Kubernetes); definir as duas é um erro
+func lookupEnv(key string) (string, bool, error) {
+	value, hasValue := os.LookupEnv(key)
+	path, hasFile := os.LookupEnv(key + "_FILE")
+
+	if hasValue && hasFile {
+		return "", false, fmt.Errorf("%s: defina apenas %s ou %s_FILE", key, key, key)
+	}
+	if hasFile {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("%s_FILE: %v", key, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), true, nil
+	}
+	if hasValue && value != "" {
+		return value, true, nil
+	}
+	return "", false, nil
+}
+
+// readFile lê o arquivo de configuração e devolve os valores indexados pelo nome da
+// variável de ambiente correspondente
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler %s: %w", ConfigFileEnv, err)
+	}
+
+	raw := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("%s: formato de %s não suportado (use .yaml, .yml ou .toml)", ConfigFileEnv, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("falha ao interpretar %s: %w", path, err)
+	}
+
+	known := make(map[string]bool)
+	for _, field := range fields() {
+		known[field.env] = true
+	}
+
+	values := make(map[string]string, len(raw))
+	var unknown []string
+	for key, value := range raw {
+		env := strings.ToUpper(key)
+		if !known[env] {
+			unknown = append(unknown, key)
+			continue
+		}
+		values[env] = stringify(value)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, errors.New("chaves desconhecidas em " + path + ": " + strings.Join(unknown, ", "))
+	}
+
+	return values, nil
+}
+
+// stringify converte um valor do arquivo para a mesma forma textual das variáveis de
+// ambiente (listas viram valores separados por vírgula)
+func stringify(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = stringify(item)
+		}
+		return strings.Join(items, ",")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
 }
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "config-test-secret-with-32-characters"
+
+// clearEnv isola o teste das variáveis do ambiente de quem roda a suíte
+func clearEnv(t *testing.T) {
+	t.Helper()
+
+	for _, field := range fields() {
+		for _, key := range []string{field.env, field.env + "_FILE"} {
+			if value, ok := os.LookupEnv(key); ok {
+				os.Unsetenv(key)
+				t.Cleanup(func() { os.Setenv(key, value) })
+			}
+		}
+	}
+	t.Setenv(ConfigFileEnv, "")
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("JWT_SECRET", testSecret)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Port != 8080 || cfg.AccessTokenTTL != 15*time.Minute || cfg.DBMaxOpenConns != 25 || cfg.MigrationMode != "migrate" {
+		t.Errorf("valores padrão inesperados: %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.CORSOrigins, environmentOrigins["development"]) {
+		t.Errorf("origens padrão de desenvolvimento inesperadas: %v", cfg.CORSOrigins)
+	}
+}
+
+func TestAddress(t *testing.T) {
+	cases := []struct {
+		host string
+		port int
+		want string
+	}{
+		{"", 8080, ":8080"},
+		{"127.0.0.1", 9000, "127.0.0.1:9000"},
+		{"::1", 8080, "[::1]:8080"},
+	}
+
+	for _, tc := range cases {
+		cfg := &Config{Host: tc.host, Port: tc.port}
+		if got := cfg.Address(); got != tc.want {
+			t.Errorf("Host %q, Port %d: %q; esperado %q", tc.host, tc.port, got, tc.want)
+		}
+	}
+}
+
+func TestLocalAddress(t *testing.T) {
+	cases := []struct {
+		host string
+		want string
+	}{
+		{"", "127.0.0.1:8080"},
+		{"0.0.0.0", "127.0.0.1:8080"},
+		{"::", "127.0.0.1:8080"},
+		{"10.0.0.5", "10.0.0.5:8080"},
+		{"::1", "[::1]:8080"},
+	}
+
+	for _, tc := range cases {
+		cfg := &Config{Host: tc.host, Port: 8080}
+		if got := cfg.LocalAddress(); got != tc.want {
+			t.Errorf("Host %q: %q; esperado %q", tc.host, got, tc.want)
+		}
+	}
+}
+
+func TestLoadFilePrecedence(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": `
+port: 9000
+access_token_ttl: 30m
+tracing_sample_ratio: 0.25
+db_max_open_conns: 10
+cors_origins:
+  - https://a.example.com
+  - https://b.example.com
+`,
+		"config.toml": `
+port = 9000
+access_token_ttl = "30m"
+tracing_sample_ratio = 0.25
+db_max_open_conns = 10
+cors_origins = ["https://a.example.com", "https://b.example.com"]
+`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("JWT_SECRET", testSecret)
+			t.Setenv(ConfigFileEnv, writeFile(t, name, content))
+			t.Setenv("DB_MAX_OPEN_CONNS", "40")
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cfg.Port != 9000 || cfg.AccessTokenTTL != 30*time.Minute {
+				t.Errorf("valores do arquivo não aplicados: porta %d, ttl %s", cfg.Port, cfg.AccessTokenTTL)
+			}
+			if cfg.TracingSampleRatio != 0.25 {
+				t.Errorf("fração de amostragem do arquivo não aplicada: %v", cfg.TracingSampleRatio)
+			}
+			if cfg.DBMaxOpenConns != 40 {
+				t.Errorf("variável de ambiente deveria prevalecer sobre o arquivo: %d", cfg.DBMaxOpenConns)
+			}
+			if !reflect.DeepEqual(cfg.CORSOrigins, []string{"https://a.example.com", "https://b.example.com"}) {
+				t.Errorf("origens do arquivo inesperadas: %v", cfg.CORSOrigins)
+			}
+		})
+	}
+}
+
+func TestLoadSecretFiles(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("JWT_SECRET_FILE", writeFile(t, "jwt", testSecret+"\n"))
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "db", "s3cr3t\n"))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.JWTSecret != testSecret || cfg.DBPassword != "s3cr3t" {
+		t.Errorf("segredos dos arquivos não aplicados: %q, %q", cfg.JWTSecret, cfg.DBPassword)
+	}
+
+	t.Setenv("JWT_SECRET", testSecret)
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "JWT_SECRET_FILE") {
+		t.Fatalf("definir JWT_SECRET e JWT_SECRET_FILE deveria falhar, obteve %v", err)
+	}
+}
+
+func TestLoadReportsAllProblems(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("JWT_SECRET", "curto")
+	t.Setenv("PORT", "abc")
+	t.Setenv("DB_DRIVER", "mysql")
+	t.Setenv("DB_MAX_OPEN_CONNS", "2")
+	t.Setenv("DB_MAX_IDLE_CONNS", "5")
+	t.Setenv("ACCESS_TOKEN_TTL", "0s")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("CORS_ORIGINS", "https://ok.example.com,localhost:5173")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("configuração inválida foi aceita")
+	}
+	for _, expected := range []string{"JWT_SECRET", "PORT", "DB_DRIVER", "DB_MAX_IDLE_CONNS", "ACCESS_TOKEN_TTL", "LOG_LEVEL", `"localhost:5173"`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("erro não menciona %s:\n%v", expected, err)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownFileKeys(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("JWT_SECRET", testSecret)
+	t.Setenv(ConfigFileEnv, writeFile(t, "config.yaml", "prot: 9000\n"))
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "prot") {
+		t.Fatalf("chave desconhecida deveria ser rejeitada, obteve %v", err)
+	}
+}
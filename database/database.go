@@ -2,7 +2,8 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
 
 	"tivix-performance-tracker-backend/config"
 	"tivix-performance-tracker-backend/migrations"
@@ -13,34 +14,96 @@ import (
 
 var DB *sqlx.DB
 
-func Connect() {
-	cfg := config.LoadConfig()
+// Connect abre a conexão global DB com o banco configurado e aplica os limites do pool
+func Connect(cfg *config.Config) {
+	switch cfg.DBDriver {
+	case DriverPostgres:
+	case DriverSQLite:
+		var err error
+		DB, err = OpenSQLite(cfg.SQLitePath)
+		if err != nil {
+			fatal("Failed to connect to database", "driver", DriverSQLite, "error", err)
+		}
+		configurePool(cfg)
+		slog.Info("Connected to database", "driver", DriverSQLite, "path", cfg.SQLitePath)
+		return
+	default:
+		fatal("Unsupported DB_DRIVER", "driver", cfg.DBDriver)
+	}
 
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+	// As conexões do pool não ignoram as políticas de RLS: sem o contexto definido por
+	// BeginTenant ou BeginSystem nenhuma linha das tabelas de empresa fica visível
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBSSLMode)
 
 	var err error
 	DB, err = sqlx.Open("postgres", dsn)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		fatal("Failed to connect to database", "driver", DriverPostgres, "error", err)
 	}
 
+	configurePool(cfg)
+
 	if err = DB.Ping(); err != nil {
-		log.Fatal("Failed to ping database:", err)
+		fatal("Failed to ping database", "driver", DriverPostgres, "host", cfg.DBHost, "error", err)
 	}
 
-	log.Println("✅ Connected to PostgreSQL database")
+	slog.Info("Connected to database", "driver", DriverPostgres, "host", cfg.DBHost, "database", cfg.DBName)
 }
 
-func Migrate() {
-	migrationManager := migrations.NewMigrationManager(DB.DB)
+const (
+	// MigrationModeMigrate aplica as migrações pendentes ao iniciar (padrão)
+	MigrationModeMigrate = "migrate"
+	// MigrationModeCheck não aplica nada e impede a inicialização se o schema estiver desatualizado
+	MigrationModeCheck = "check"
+	// MigrationModeSkip ignora as migrações, para quando elas são aplicadas por um job separado
+	MigrationModeSkip = "skip"
+)
 
-	log.Println("🔄 Iniciando sistema de migrações centralizado...")
+// configurePool aplica os limites de conexões configurados ao pool global
+func configurePool(cfg *config.Config) {
+	DB.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	DB.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	DB.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	DB.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
+}
 
-	if err := migrationManager.RunMigrations(); err != nil {
-		log.Printf("❌ Erro nas migrações: %v", err)
-		return
+// Migrate trata as migrações conforme MIGRATION_MODE e encerra o processo em caso de falha,
+// para que a API nunca suba sobre um schema incompleto
+func Migrate(cfg *config.Config) {
+	mode := cfg.MigrationMode
+
+	migrationManager := Migrations()
+
+	switch mode {
+	case MigrationModeMigrate:
+		slog.Info("Aplicando migrações pendentes", "mode", mode)
+
+		if err := migrationManager.RunMigrations(); err != nil {
+			fatal("Erro nas migrações", "error", err)
+		}
+	case MigrationModeCheck:
+		if err := migrationManager.Check(); err != nil {
+			fatal("Schema desatualizado; aplique as migrações antes de iniciar", "mode", mode, "error", err)
+		}
+
+		slog.Info("Schema do banco atualizado", "mode", mode)
+	case MigrationModeSkip:
+		slog.Info("Migrações ignoradas", "mode", mode)
+	default:
+		fatal("MIGRATION_MODE inválido", "mode", mode)
 	}
+}
+
+// Migrations retorna o gerenciador de migrações do banco conectado, no dialeto do driver
+func Migrations() *migrations.MigrationManager {
+	manager := migrations.NewMigrationManager(DB.DB)
+	manager.Dialect = DB.DriverName()
+	return manager
+}
 
-	log.Println("✅ Sistema de migrações concluído com sucesso")
+// fatal registra o erro e encerra o processo, como log.Fatal
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
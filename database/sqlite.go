@@ -0,0 +1,54 @@
+package database
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"modernc.org/sqlite"
+)
+
+const (
+	// DriverPostgres é o banco padrão, com RLS e extensões do PostgreSQL
+	DriverPostgres = "postgres"
+	// DriverSQLite é o modo local (desenvolvimento e testes) em um único arquivo
+	DriverSQLite = "sqlite"
+)
+
+func init() {
+	// Substitui a função da extensão uuid-ossp usada nos DEFAULT das tabelas
+	sqlite.MustRegisterScalarFunction("uuid_generate_v4", 0, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+		return uuid.NewString(), nil
+	})
+}
+
+// OpenSQLite abre (criando se necessário) o banco SQLite em path. As chaves estrangeiras
+// ficam ativas e as transações reservam a escrita já no BEGIN (_txlock=immediate): as
+// requisições que escrevem são serializadas em vez de falhar com SQLITE_BUSY ao promover
+// a leitura a escrita. Transações ReadOnly (requisições GET, ver BeginTenant) começam com
+// BEGIN DEFERRED e, com o WAL, leem em paralelo às demais.
+func OpenSQLite(path string) (*sqlx.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_txlock", "immediate")
+	params.Set("_time_format", "sqlite")
+
+	db, err := sqlx.Open(DriverSQLite, "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("falha ao abrir banco SQLite %s: %w", path, err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("falha ao abrir banco SQLite %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// IsSQLite indica se a conexão global usa o modo SQLite
+func IsSQLite() bool {
+	return DB != nil && DB.DriverName() == DriverSQLite
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Querier é o conjunto de operações comum a *sqlx.DB e *sqlx.Tx usado pelos handlers
+type Querier interface {
+	sqlx.Ext
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Tx é uma transação (ou savepoint dentro da transação da requisição)
+type Tx interface {
+	Querier
+	Commit() error
+	Rollback() error
+}
+
+// BeginTenant abre a transação de uma requisição autenticada e define as variáveis
+// usadas pelas políticas de RLS. Sem bypass, apenas as linhas da empresa informada
+// (e o próprio usuário) ficam visíveis. Os comandos de configuração entram no trace de ctx.
+// readOnly marca requisições que só leem: no SQLite a transação começa sem reservar a
+// escrita (ver OpenSQLite), para que as leituras não esperem umas pelas outras.
+func BeginTenant(ctx context.Context, companyID *uuid.UUID, userID uuid.UUID, bypass, readOnly bool) (*sqlx.Tx, error) {
+	company := ""
+	if companyID != nil {
+		company = companyID.String()
+	}
+	return beginScoped(ctx, company, userID.String(), bypass, readOnly)
+}
+
+// BeginSystem abre uma transação em contexto de sistema, que ignora as políticas de RLS.
+// As conexões do pool não têm bypass: use-a apenas para login, inicialização, rotas
+// exclusivas de administradores e tarefas internas que atravessam empresas.
+func BeginSystem(ctx context.Context) (*sqlx.Tx, error) {
+	return beginScoped(ctx, "", "", true, false)
+}
+
+func beginScoped(ctx context.Context, company, user string, bypass, readOnly bool) (*sqlx.Tx, error) {
+	// O driver do SQLite abre transações ReadOnly com BEGIN DEFERRED no lugar do _txlock; no
+	// PostgreSQL a transação não muda, já que leituras não bloqueiam umas às outras
+	var opts *sql.TxOptions
+	if readOnly && IsSQLite() {
+		opts = &sql.TxOptions{ReadOnly: true}
+	}
+
+	tx, err := DB.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// O SQLite não tem RLS: o isolamento fica a cargo das verificações dos handlers
+	if IsSQLite() {
+		return tx, nil
+	}
+
+	bypassValue := "off"
+	if bypass {
+		bypassValue = "on"
+	}
+
+	// set_config com is_local = true vale apenas até o fim da transação
+	_, err = Trace(ctx, tx).Exec(`
+		SELECT set_config('app.company_id', $1, true),
+		       set_config('app.user_id', $2, true),
+		       set_config('app.bypass_rls', $3, true)
+	`, company, user, bypassValue)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("falha ao definir contexto da empresa: %w", err)
+	}
+
+	return tx, nil
+}
+
+var savepointSeq uint64
+
+// Begin abre uma transação a partir do Querier informado. Dentro de uma transação
+// existente (a da requisição) usa um savepoint, preservando o contexto de RLS. Sobre as
+// conexões da requisição (Trace), a transação segue o contexto e o prazo da requisição.
+func Begin(q Querier) (Tx, error) {
+	return begin(context.Background(), q)
+}
+
+func begin(ctx context.Context, q Querier) (Tx, error) {
+	switch db := q.(type) {
+	case *tracedQuerier:
+		tx, err := begin(db.ctx, db.q)
+		if err != nil {
+			return nil, err
+		}
+		return &tracedTx{tracedQuerier: &tracedQuerier{q: tx.(contextQuerier), ctx: db.ctx}, tx: tx}, nil
+	case *sqlx.Tx:
+		name := fmt.Sprintf("sp_%d", atomic.AddUint64(&savepointSeq, 1))
+		if _, err := db.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+			return nil, err
+		}
+		return &savepointTx{Tx: db, name: name}, nil
+	case *sqlx.DB:
+		return db.BeginTxx(ctx, nil)
+	default:
+		return nil, fmt.Errorf("tipo de conexão não suportado: %T", q)
+	}
+}
+
+type savepointTx struct {
+	*sqlx.Tx
+	name string
+	done bool
+}
+
+func (s *savepointTx) Commit() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.Tx.Exec("RELEASE SAVEPOINT " + s.name)
+	return err
+}
+
+func (s *savepointTx) Rollback() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.Tx.Exec("ROLLBACK TO SAVEPOINT " + s.name)
+	return err
+}
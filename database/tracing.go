@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("tivix-performance-tracker-backend/database")
+
+// contextQuerier são as variantes com contexto de *sqlx.DB e *sqlx.Tx
+type contextQuerier interface {
+	Querier
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Trace retorna um Querier que executa cada comando de q com ctx, em um span filho do span
+// da requisição com o texto sanitizado da query. Tipos sem as variantes com contexto são
+// devolvidos sem alteração.
+func Trace(ctx context.Context, q Querier) Querier {
+	if traced, ok := q.(*tracedQuerier); ok {
+		q = traced.q
+	}
+	inner, ok := q.(contextQuerier)
+	if !ok {
+		return q
+	}
+	return &tracedQuerier{q: inner, ctx: ctx}
+}
+
+type tracedQuerier struct {
+	q   contextQuerier
+	ctx context.Context
+}
+
+// tracedTx é uma transação aberta por Begin sobre um tracedQuerier
+type tracedTx struct {
+	*tracedQuerier
+	tx Tx
+}
+
+func (t *tracedTx) Commit() error   { return t.tx.Commit() }
+func (t *tracedTx) Rollback() error { return t.tx.Rollback() }
+
+// start abre o span do comando; o chamador encerra com finish
+func (t *tracedQuerier) start(query string) (context.Context, trace.Span) {
+	system := semconv.DBSystemPostgreSQL
+	if t.q.DriverName() == DriverSQLite {
+		system = semconv.DBSystemSqlite
+	}
+
+	operation := operationName(query)
+	return tracer.Start(t.ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			system,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(SanitizeQuery(query)),
+		),
+	)
+}
+
+func finish(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t *tracedQuerier) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := t.start(query)
+	rows, err := t.q.QueryContext(ctx, query, args...)
+	finish(span, err)
+	return rows, err
+}
+
+func (t *tracedQuerier) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	ctx, span := t.start(query)
+	rows, err := t.q.QueryxContext(ctx, query, args...)
+	finish(span, err)
+	return rows, err
+}
+
+func (t *tracedQuerier) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	ctx, span := t.start(query)
+	row := t.q.QueryRowxContext(ctx, query, args...)
+	finish(span, row.Err())
+	return row
+}
+
+func (t *tracedQuerier) QueryRow(query string, args ...interface{}) *sql.Row {
+	ctx, span := t.start(query)
+	row := t.q.QueryRowContext(ctx, query, args...)
+	finish(span, row.Err())
+	return row
+}
+
+func (t *tracedQuerier) Exec(query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := t.start(query)
+	result, err := t.q.ExecContext(ctx, query, args...)
+	if err == nil {
+		if affected, affectedErr := result.RowsAffected(); affectedErr == nil {
+			span.SetAttributes(attribute.Int64("db.rows_affected", affected))
+		}
+	}
+	finish(span, err)
+	return result, err
+}
+
+func (t *tracedQuerier) Get(dest interface{}, query string, args ...interface{}) error {
+	ctx, span := t.start(query)
+	err := t.q.GetContext(ctx, dest, query, args...)
+	finish(span, err)
+	return err
+}
+
+func (t *tracedQuerier) Select(dest interface{}, query string, args ...interface{}) error {
+	ctx, span := t.start(query)
+	err := t.q.SelectContext(ctx, dest, query, args...)
+	finish(span, err)
+	return err
+}
+
+func (t *tracedQuerier) DriverName() string { return t.q.DriverName() }
+
+func (t *tracedQuerier) Rebind(query string) string { return t.q.Rebind(query) }
+
+func (t *tracedQuerier) BindNamed(query string, arg interface{}) (string, []interface{}, error) {
+	return t.q.BindNamed(query, arg)
+}
+
+var (
+	stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	token          = regexp.MustCompile(`\$?\w+(?:\.\d+)?`)
+	numericLiteral = regexp.MustCompile(`^\d+(?:\.\d+)?$`)
+	whitespace     = regexp.MustCompile(`\s+`)
+)
+
+// SanitizeQuery prepara o texto da query para os spans: literais de texto e números viram
+// "?" (os valores das requisições vão nos parâmetros $N, que nunca são registrados) e os
+// espaços são compactados
+func SanitizeQuery(query string) string {
+	query = stringLiteral.ReplaceAllString(query, "?")
+	query = token.ReplaceAllStringFunc(query, func(word string) string {
+		// Mantém placeholders ($1) e identificadores com dígitos (uuid_generate_v4)
+		if numericLiteral.MatchString(word) {
+			return "?"
+		}
+		return word
+	})
+	return strings.TrimSpace(whitespace.ReplaceAllString(query, " "))
+}
+
+// operationName é o primeiro comando da query (SELECT, INSERT, WITH...), usado como nome do span
+func operationName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "SQL"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -1,65 +1,37 @@
 package handlers
 
 import (
-	"os"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
-	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/i18n"
 	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/services"
 )
 
 func CreateAdminUser(c *fiber.Ctx) error {
-	var userCount int
-	err := database.DB.Get(&userCount, "SELECT COUNT(*) FROM users")
+	userCount, err := repos(c).Users().Count()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao verificar usuários existentes",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	if userCount > 0 {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error":   true,
-			"message": "Sistema já possui usuários cadastrados",
-		})
+		return apierror.ErrAlreadyInstalled
 	}
 
-	type InitRequest struct {
-		InstallKey string `json:"installKey"`
-		Email      string `json:"email" validate:"required,email"`
-		Password   string `json:"password" validate:"required,min=6"`
-		Name       string `json:"name" validate:"required,min=2"`
-	}
-
-	var req InitRequest
+	var req models.InitRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados inválidos",
-		})
-	}
-	expectedKey := os.Getenv("INSTALL_KEY")
-	if expectedKey == "" {
-		expectedKey = "TIVIX_INSTALL_2024"
+		return apierror.ErrInvalidBody
 	}
-
-	if req.InstallKey != expectedKey {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error":   true,
-			"message": "Chave de instalação inválida",
-		})
+	if req.InstallKey != services.From(c).Config.InstallKey {
+		return apierror.ErrInvalidInstallKey
 	}
 
 	if err := validate.Struct(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados de entrada inválidos",
-			"details": err.Error(),
-		})
+		return apierror.Validation(err)
 	}
 
 	user := models.User{
@@ -73,27 +45,18 @@ func CreateAdminUser(c *fiber.Ctx) error {
 	}
 
 	if err := user.HashPassword(req.Password); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao processar senha",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
-	query := `
-		INSERT INTO users (id, email, password, name, role, company_id, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	`
-	_, err = database.DB.Exec(query, user.ID, user.Email, user.Password, user.Name, user.Role, user.CompanyID, user.IsActive, user.CreatedAt, user.UpdatedAt)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao criar usuário administrador",
-		})
+	if err := repos(c).Users().Create(&user); err != nil {
+		return apierror.ErrInternal.Wrap(err)
 	}
 
+	recordAudit(c, "create", "users", user.ID, nil, auditSnapshot(repos(c), "users", user.ID))
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"error":   false,
-		"message": "Usuário administrador criado com sucesso",
+		"message": i18n.T(c, "messages.admin_created"),
 		"data": fiber.Map{
 			"userId": user.ID,
 			"email":  user.Email,
@@ -104,13 +67,9 @@ func CreateAdminUser(c *fiber.Ctx) error {
 }
 
 func CheckInitialization(c *fiber.Ctx) error {
-	var userCount int
-	err := database.DB.Get(&userCount, "SELECT COUNT(*) FROM users")
+	userCount, err := repos(c).Users().Count()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao verificar inicialização",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	return c.JSON(fiber.Map{
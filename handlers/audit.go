@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+)
+
+// Tabelas que podem ser capturadas em snapshots de auditoria
+var auditableTables = map[string]bool{
+	"companies":                   true,
+	"users":                       true,
+	"teams":                       true,
+	"developers":                  true,
+	"performance_reports":         true,
+	"goals":                       true,
+	"one_on_ones":                 true,
+	"one_on_one_action_items":     true,
+	"report_comments":             true,
+	"career_tracks":               true,
+	"career_levels":               true,
+	"skills":                      true,
+	"developer_skill_assessments": true,
+}
+
+const auditLogColumns = `id, actor_id, COALESCE(actor_email, '') AS actor_email, COALESCE(actor_role, '') AS actor_role,
+	company_id, action, entity_type, entity_id, before_data, after_data,
+	COALESCE(ip_address, '') AS ip_address, COALESCE(user_agent, '') AS user_agent,
+	COALESCE(method, '') AS method, COALESCE(path, '') AS path, created_at`
+
+const maxAuditExportRows = 10000
+
+// auditSnapshot captura o estado atual de um registro para o log de auditoria
+// (nil se o registro não puder ser lido, como nos repositórios em memória)
+func auditSnapshot(store repository.Store, table string, id uuid.UUID) models.JSONB {
+	if !auditableTables[table] {
+		slog.Warn("Audit snapshot requested for unknown table", "table", table)
+		return nil
+	}
+
+	snapshot, err := store.Audit().Snapshot(table, id)
+	if err != nil {
+		if err != repository.ErrNotFound {
+			slog.Error("Error capturing audit snapshot", "table", table, "entity_id", id, "error", err)
+		}
+		return nil
+	}
+
+	// Nunca registrar hashes de senha no log
+	delete(snapshot, "password")
+
+	return snapshot
+}
+
+// recordAudit registra uma operação de escrita no log de auditoria.
+// Falhas são apenas logadas para não interromper a requisição já concluída.
+func recordAudit(c *fiber.Ctx, action, entityType string, entityID uuid.UUID, before, after models.JSONB) {
+	entry := models.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   &entityID,
+		Before:     before,
+		After:      after,
+		IPAddress:  c.IP(),
+		UserAgent:  c.Get(fiber.HeaderUserAgent),
+		Method:     c.Method(),
+		Path:       c.Path(),
+	}
+
+	if user, ok := c.Locals("user").(*middleware.JWTClaims); ok && user != nil {
+		entry.ActorID = &user.UserID
+		entry.ActorEmail = user.Email
+		entry.ActorRole = user.Role
+		entry.CompanyID = user.CompanyID
+	}
+
+	// A empresa do registro afetado tem precedência sobre a empresa do autor
+	if entityType == "companies" {
+		entry.CompanyID = &entityID
+	} else if id := snapshotCompanyID(after); id != nil {
+		entry.CompanyID = id
+	} else if id := snapshotCompanyID(before); id != nil {
+		entry.CompanyID = id
+	}
+
+	if err := repos(c).Audit().Record(&entry); err != nil {
+		logging.From(c).Error("Error recording audit log", "action", action, "entity_type", entityType, "entity_id", entityID, "error", err)
+	}
+}
+
+func snapshotCompanyID(snapshot models.JSONB) *uuid.UUID {
+	if snapshot == nil {
+		return nil
+	}
+	value, ok := snapshot["company_id"].(string)
+	if !ok {
+		return nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// buildAuditLogFilters monta a cláusula WHERE a partir dos filtros da query string
+func buildAuditLogFilters(c *fiber.Ctx) (string, []interface{}, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	uuidFilters := []struct {
+		param  string
+		column string
+	}{
+		{"actorId", "actor_id"},
+		{"companyId", "company_id"},
+		{"entityId", "entity_id"},
+	}
+	for _, f := range uuidFilters {
+		if value := c.Query(f.param); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return "", nil, apierror.InvalidID(f.param)
+			}
+			args = append(args, id)
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", f.column, len(args)))
+		}
+	}
+
+	if action := c.Query("action"); action != "" {
+		args = append(args, action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+	if entityType := c.Query("entityType"); entityType != "" {
+		args = append(args, entityType)
+		conditions = append(conditions, fmt.Sprintf("entity_type = $%d", len(args)))
+	}
+
+	timeFilters := []struct {
+		param    string
+		operator string
+	}{
+		{"from", ">="},
+		{"to", "<"},
+	}
+	for _, f := range timeFilters {
+		if value := c.Query(f.param); value != "" {
+			t, err := parseAuditTime(value)
+			if err != nil {
+				return "", nil, apierror.InvalidField(f.param, "date", "")
+			}
+			// Datas sem horário em "to" incluem o dia inteiro
+			if f.param == "to" && len(value) == len("2006-01-02") {
+				t = t.AddDate(0, 0, 1)
+			}
+			args = append(args, t)
+			conditions = append(conditions, fmt.Sprintf("created_at %s $%d", f.operator, len(args)))
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "", args, nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+func parseAuditTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// GetAuditLogs retorna o log de auditoria filtrado e paginado
+func GetAuditLogs(c *fiber.Ctx) error {
+	where, args, err := buildAuditLogFilters(c)
+	if err != nil {
+		return err
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "100"))
+	if err != nil || limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	var total int
+	if err := tenantDB(c).Get(&total, "SELECT COUNT(*) FROM audit_logs"+where, args...); err != nil {
+		logging.From(c).Error("Error counting audit logs", "error", err)
+		return apierror.ErrInternal
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM audit_logs%s ORDER BY created_at DESC LIMIT %d OFFSET %d", auditLogColumns, where, limit, offset)
+
+	logs := []models.AuditLog{}
+	if err := tenantDB(c).Select(&logs, query, args...); err != nil {
+		logging.From(c).Error("Error querying audit logs", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   logs,
+		"pagination": fiber.Map{
+			"total":  total,
+			"limit":  limit,
+			"offset": offset,
+		},
+	})
+}
+
+// ExportAuditLogs exporta o log de auditoria filtrado em CSV (padrão) ou JSON
+func ExportAuditLogs(c *fiber.Ctx) error {
+	where, args, err := buildAuditLogFilters(c)
+	if err != nil {
+		return err
+	}
+
+	format := c.Query("format", "csv")
+	if format != "csv" && format != "json" {
+		return apierror.InvalidField("format", "oneof", "csv json")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM audit_logs%s ORDER BY created_at ASC LIMIT %d", auditLogColumns, where, maxAuditExportRows)
+
+	logs := []models.AuditLog{}
+	if err := tenantDB(c).Select(&logs, query, args...); err != nil {
+		logging.From(c).Error("Error exporting audit logs", "error", err)
+		return apierror.ErrInternal
+	}
+
+	// Exportações filtradas por empresa usam o fuso horário configurado pela empresa
+	location := time.UTC
+	if value := c.Query("companyId"); value != "" {
+		if companyID, err := uuid.Parse(value); err == nil {
+			location = companyLocation(loadCompanySettings(repos(c), &companyID))
+		}
+	}
+
+	filename := fmt.Sprintf("audit-log-%s.%s", time.Now().In(location).Format("20060102-150405"), format)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	if format == "json" {
+		return c.JSON(logs)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{
+		"id", "created_at", "actor_id", "actor_email", "actor_role", "company_id", "action",
+		"entity_type", "entity_id", "ip_address", "user_agent", "method", "path", "before", "after",
+	})
+	for _, entry := range logs {
+		before, _ := json.Marshal(entry.Before)
+		after, _ := json.Marshal(entry.After)
+		w.Write([]string{
+			entry.ID.String(),
+			entry.CreatedAt.In(location).Format(time.RFC3339),
+			uuidString(entry.ActorID),
+			entry.ActorEmail,
+			entry.ActorRole,
+			uuidString(entry.CompanyID),
+			entry.Action,
+			entry.EntityType,
+			uuidString(entry.EntityID),
+			entry.IPAddress,
+			entry.UserAgent,
+			entry.Method,
+			entry.Path,
+			string(before),
+			string(after),
+		})
+	}
+	w.Flush()
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return c.Send(buf.Bytes())
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
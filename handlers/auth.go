@@ -1,18 +1,19 @@
 package handlers
 
 import (
-	"database/sql"
-	"fmt"
-	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
-	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/metrics"
 	"tivix-performance-tracker-backend/middleware"
 	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+	"tivix-performance-tracker-backend/services"
 	"tivix-performance-tracker-backend/utils"
 )
 
@@ -20,6 +21,7 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	validate.RegisterTagNameFunc(apierror.JSONFieldName)
 
 	validate.RegisterValidation("no_html", middleware.ValidateNoHTML)
 	validate.RegisterValidation("safe_string", middleware.ValidateSafeString)
@@ -30,33 +32,20 @@ func Register(c *fiber.Ctx) error {
 	var req models.RegisterRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	// Validar e sanitizar dados
 	if err := middleware.ValidateAndSanitize(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados de entrada inválidos",
-			"details": err.Error(),
-		})
-	}
-
-	var existingUser models.User
-	err := database.DB.Get(&existingUser, "SELECT id FROM users WHERE email = $1", req.Email)
-	if err == nil {
-		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-			"error":   true,
-			"message": "Email já está em uso",
-		})
-	} else if err != sql.ErrNoRows {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro interno do servidor",
-		})
+		return apierror.Validation(err)
+	}
+
+	emailTaken, err := repos(c).Users().EmailTaken(req.Email, nil)
+	if err != nil {
+		return apierror.ErrInternal.Wrap(err)
+	}
+	if emailTaken {
+		return apierror.ErrEmailInUse
 	}
 
 	user := models.User{
@@ -74,35 +63,23 @@ func Register(c *fiber.Ctx) error {
 	}
 
 	if err := user.HashPassword(req.Password); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao processar senha",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
-	query := `
-		INSERT INTO users (id, email, password, name, role, company_id, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	`
-	_, err = database.DB.Exec(query, user.ID, user.Email, user.Password, user.Name, user.Role, user.CompanyID, user.IsActive, user.CreatedAt, user.UpdatedAt)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao criar usuário",
-		})
+	if err := repos(c).Users().Create(&user); err != nil {
+		return apierror.ErrInternal.Wrap(err)
 	}
 
-	token, err := middleware.GenerateJWT(user)
+	recordAudit(c, "create", "users", user.ID, nil, auditSnapshot(repos(c), "users", user.ID))
+
+	token, err := middleware.GenerateJWT(services.From(c).Config, user)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao gerar token",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"error":   false,
-		"message": "Usuário criado com sucesso",
+		"message": i18n.T(c, "messages.user_created"),
 		"data": models.LoginResponse{
 			Token: token,
 			User:  user,
@@ -114,62 +91,45 @@ func Login(c *fiber.Ctx) error {
 	var req models.LoginRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	if err := validate.Struct(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados de entrada inválidos",
-			"details": err.Error(),
-		})
-	}
-
-	var user models.User
-	err := database.DB.Get(&user, "SELECT * FROM users WHERE email = $1", req.Email)
-	if err == sql.ErrNoRows {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error":   true,
-			"message": "Credenciais inválidas",
-		})
+		return apierror.Validation(err)
+	}
+
+	user, err := repos(c).Users().FindByEmail(req.Email)
+	if err == repository.ErrNotFound {
+		metrics.ObserveLogin(metrics.LoginInvalidCredentials)
+		return apierror.ErrInvalidCredentials
 	} else if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro interno do servidor",
-		})
+		metrics.ObserveLogin(metrics.LoginError)
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	if !user.IsActive {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error":   true,
-			"message": "Usuário inativo",
-		})
+		metrics.ObserveLogin(metrics.LoginInactiveUser)
+		return apierror.ErrUserInactive
 	}
 
 	if err := user.CheckPassword(req.Password); err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error":   true,
-			"message": "Credenciais inválidas",
-		})
+		metrics.ObserveLogin(metrics.LoginInvalidCredentials)
+		return apierror.ErrInvalidCredentials
 	}
 
-	token, err := middleware.GenerateJWT(user)
+	token, err := middleware.GenerateJWT(services.From(c).Config, *user)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao gerar token",
-		})
+		metrics.ObserveLogin(metrics.LoginError)
+		return apierror.ErrInternal.Wrap(err)
 	}
 
+	metrics.ObserveLogin(metrics.LoginSuccess)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "success",
-		"message": "Login realizado com sucesso",
+		"message": i18n.T(c, "messages.login_succeeded"),
 		"data": models.LoginResponse{
 			Token: token,
-			User:  user,
+			User:  *user,
 		},
 	})
 }
@@ -177,13 +137,9 @@ func Login(c *fiber.Ctx) error {
 func GetProfile(c *fiber.Ctx) error {
 	userClaims := c.Locals("user").(*middleware.JWTClaims)
 
-	var user models.User
-	err := database.DB.Get(&user, "SELECT * FROM users WHERE id = $1", userClaims.UserID)
+	user, err := repos(c).Users().FindByID(userClaims.UserID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":   true,
-			"message": "Usuário não encontrado",
-		})
+		return apierror.ErrUserNotFound
 	}
 
 	return c.JSON(fiber.Map{
@@ -195,28 +151,18 @@ func GetProfile(c *fiber.Ctx) error {
 func RefreshToken(c *fiber.Ctx) error {
 	userClaims := c.Locals("user").(*middleware.JWTClaims)
 
-	var user models.User
-	err := database.DB.Get(&user, "SELECT * FROM users WHERE id = $1", userClaims.UserID)
+	user, err := repos(c).Users().FindByID(userClaims.UserID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":   true,
-			"message": "Usuário não encontrado",
-		})
+		return apierror.ErrUserNotFound
 	}
 
 	if !user.IsActive {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error":   true,
-			"message": "Usuário inativo",
-		})
+		return apierror.ErrUserInactive
 	}
 
-	token, err := middleware.GenerateJWT(user)
+	token, err := middleware.GenerateJWT(services.From(c).Config, *user)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao gerar token",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	return c.JSON(fiber.Map{
@@ -233,18 +179,11 @@ func CreateUser(c *fiber.Ctx) error {
 	var req models.CreateUserRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	if err := validate.Struct(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados de entrada inválidos",
-			"details": err.Error(),
-		})
+		return apierror.Validation(err)
 	}
 
 	// Verificar regras de empresa
@@ -252,57 +191,35 @@ func CreateUser(c *fiber.Ctx) error {
 	if user.Role == "admin" {
 		// Admin pode especificar qualquer empresa (obrigatório agora)
 		if req.CompanyID == nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Admin deve especificar uma empresa para o usuário",
-			})
+			return apierror.Required("companyId")
 		}
 		finalCompanyID = req.CompanyID
 	} else if user.Role == "manager" {
 		// Manager só pode criar usuários na sua própria empresa
 		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Manager deve estar associado a uma empresa",
-			})
+			return apierror.ErrCompanyMembershipRequired
 		}
 		finalCompanyID = user.CompanyID
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Apenas admins e managers podem criar usuários",
-		})
+		return apierror.ErrManagerOrAdminRequired
 	}
 
 	// Verificar se a empresa existe
-	var companyExists bool
-	err := database.DB.Get(&companyExists, "SELECT EXISTS(SELECT 1 FROM companies WHERE id = $1 AND is_active = true)", *finalCompanyID)
+	companyExists, err := repos(c).Companies().IsActive(*finalCompanyID)
 	if err != nil || !companyExists {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Empresa não encontrada ou inativa",
-		})
+		return apierror.ErrCompanyUnavailable
 	}
 
 	if err := utils.ValidatePassword(req.TemporaryPassword); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": err.Error(),
-		})
-	}
-
-	var existingUser models.User
-	existingUserErr := database.DB.Get(&existingUser, "SELECT id FROM users WHERE email = $1", req.Email)
-	if existingUserErr == nil {
-		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Email já está em uso",
-		})
-	} else if existingUserErr != sql.ErrNoRows {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro interno do servidor",
-		})
+		return apierror.WeakPassword("temporaryPassword", err)
+	}
+
+	emailTaken, err := repos(c).Users().EmailTaken(req.Email, nil)
+	if err != nil {
+		return apierror.ErrInternal.Wrap(err)
+	}
+	if emailTaken {
+		return apierror.ErrEmailInUse
 	}
 
 	newUser := models.User{
@@ -318,24 +235,20 @@ func CreateUser(c *fiber.Ctx) error {
 	}
 
 	if err := newUser.HashPassword(req.TemporaryPassword); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao processar senha",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
-	query := `
-		INSERT INTO users (id, email, password, name, role, company_id, needs_password_change, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	`
-	_, err = database.DB.Exec(query, newUser.ID, newUser.Email, newUser.Password, newUser.Name, newUser.Role, newUser.CompanyID, newUser.NeedsPasswordChange, newUser.IsActive, newUser.CreatedAt, newUser.UpdatedAt)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao criar usuário",
-		})
+	if err := repos(c).Users().Create(&newUser); err != nil {
+		// O RLS esconde de gestores os usuários de outras empresas na verificação acima; o
+		// índice único do email cobre esses casos
+		if isUniqueViolation(err) {
+			return apierror.ErrEmailInUse
+		}
+		return apierror.ErrInternal.Wrap(err)
 	}
 
+	recordAudit(c, "create", "users", newUser.ID, nil, auditSnapshot(repos(c), "users", newUser.ID))
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"status": "success",
 		"data":   newUser,
@@ -346,81 +259,51 @@ func SetNewPassword(c *fiber.Ctx) error {
 	var req models.SetNewPasswordRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	if err := validate.Struct(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados de entrada inválidos",
-			"details": err.Error(),
-		})
+		return apierror.Validation(err)
 	}
 
 	if err := utils.ValidatePassword(req.NewPassword); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": err.Error(),
-		})
+		return apierror.WeakPassword("newPassword", err)
 	}
 
 	userClaims := c.Locals("user").(*middleware.JWTClaims)
 
-	var user models.User
-	err := database.DB.Get(&user, "SELECT * FROM users WHERE id = $1", userClaims.UserID)
+	user, err := repos(c).Users().FindByID(userClaims.UserID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Usuário não encontrado",
-		})
+		return apierror.ErrUserNotFound
 	}
 
 	if !user.NeedsPasswordChange {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Usuário não precisa trocar a senha",
-		})
+		return apierror.ErrPasswordChangeNotRequired
 	}
 
 	if err := user.HashPassword(req.NewPassword); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao processar nova senha",
-		})
-	}
-
-	query := `
-		UPDATE users 
-		SET password = $1, needs_password_change = false, updated_at = $2 
-		WHERE id = $3
-	`
-	_, err = database.DB.Exec(query, user.Password, time.Now(), user.ID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao atualizar senha",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	user.NeedsPasswordChange = false
 	user.UpdatedAt = time.Now()
 
-	token, err := middleware.GenerateJWT(user)
+	if err := repos(c).Users().Update(user); err != nil {
+		return apierror.ErrInternal.Wrap(err)
+	}
+
+	recordAudit(c, "set_password", "users", user.ID, nil, nil)
+
+	token, err := middleware.GenerateJWT(services.From(c).Config, *user)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao gerar token",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status": "success",
 		"data": models.LoginResponse{
 			Token: token,
-			User:  user,
+			User:  *user,
 		},
 	})
 }
@@ -429,105 +312,61 @@ func ChangePassword(c *fiber.Ctx) error {
 	var req models.ChangePasswordRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	if err := validate.Struct(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados de entrada inválidos",
-			"details": err.Error(),
-		})
+		return apierror.Validation(err)
 	}
 
 	if err := utils.ValidatePassword(req.NewPassword); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": err.Error(),
-		})
+		return apierror.WeakPassword("newPassword", err)
 	}
 
 	userClaims := c.Locals("user").(*middleware.JWTClaims)
 
-	var user models.User
-	err := database.DB.Get(&user, "SELECT * FROM users WHERE id = $1", userClaims.UserID)
+	user, err := repos(c).Users().FindByID(userClaims.UserID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Usuário não encontrado",
-		})
+		return apierror.ErrUserNotFound
 	}
 
 	if err := user.CheckPassword(req.CurrentPassword); err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Senha atual incorreta",
-		})
+		return apierror.ErrWrongPassword
 	}
 
 	if err := user.HashPassword(req.NewPassword); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao processar nova senha",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
-	query := `UPDATE users SET password = $1, updated_at = $2 WHERE id = $3`
-	_, err = database.DB.Exec(query, user.Password, time.Now(), user.ID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao atualizar senha",
-		})
+	user.UpdatedAt = time.Now()
+
+	if err := repos(c).Users().Update(user); err != nil {
+		return apierror.ErrInternal.Wrap(err)
 	}
 
+	recordAudit(c, "change_password", "users", user.ID, nil, nil)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "success",
-		"message": "Senha alterada com sucesso",
+		"message": i18n.T(c, "messages.password_changed"),
 	})
 }
 
 func ListUsers(c *fiber.Ctx) error {
 	user := c.Locals("user").(*middleware.JWTClaims)
-	var users []models.User
-
-	var query string
-	var args []interface{}
 
-	if user.Role == "admin" {
-		// Admins podem ver todos os usuários
-		query = `
-			SELECT id, email, name, role, company_id, needs_password_change, is_active, created_at, updated_at 
-			FROM users 
-			ORDER BY created_at DESC
-		`
-	} else {
-		// Managers e usuários só podem ver usuários da sua empresa
+	// Admins podem ver todos os usuários; managers e usuários só os da sua empresa
+	var companyID *uuid.UUID
+	if user.Role != "admin" {
 		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Usuário deve estar associado a uma empresa",
-			})
+			return apierror.ErrCompanyMembershipRequired
 		}
-
-		query = `
-			SELECT id, email, name, role, company_id, needs_password_change, is_active, created_at, updated_at 
-			FROM users 
-			WHERE company_id = $1
-			ORDER BY created_at DESC
-		`
-		args = append(args, *user.CompanyID)
+		companyID = user.CompanyID
 	}
 
-	err := database.DB.Select(&users, query, args...)
+	users, err := repos(c).Users().List(companyID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao buscar usuários",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -541,181 +380,109 @@ func UpdateUser(c *fiber.Ctx) error {
 	id := c.Params("id")
 	userID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "ID do usuário inválido",
-		})
+		return apierror.InvalidID("userId")
 	}
 
 	var req models.UpdateUserRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	if err := validate.Struct(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados de entrada inválidos",
-			"details": err.Error(),
-		})
+		return apierror.Validation(err)
 	}
 
 	// Verificar se o usuário existe e se pode ser editado
-	var existingUser models.User
-	err = database.DB.Get(&existingUser, "SELECT * FROM users WHERE id = $1", userID)
-	if err == sql.ErrNoRows {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Usuário não encontrado",
-		})
+	existingUser, err := repos(c).Users().FindByID(userID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrUserNotFound
 	} else if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao buscar usuário",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	// Verificar permissões de edição
 	if currentUser.Role != "admin" {
 		// Managers só podem editar usuários da sua própria empresa
 		if currentUser.CompanyID == nil || existingUser.CompanyID == nil || *currentUser.CompanyID != *existingUser.CompanyID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Sem permissão para editar este usuário",
-			})
+			return apierror.ErrUserEditForbidden
 		}
 
 		// Managers não podem editar admins
 		if existingUser.Role == "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Managers não podem editar administradores",
-			})
+			return apierror.ErrAdminEditForbidden
 		}
 
 		// Managers não podem promover usuários a admin
 		if req.Role != nil && *req.Role == "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Managers não podem promover usuários a administrador",
-			})
+			return apierror.ErrAdminPromotionForbidden
 		}
 	}
 
-	// Construir query de update dinamicamente
-	updates := []string{}
-	args := []interface{}{}
-	argCount := 1
+	if req.Name == nil && req.Email == nil && req.Role == nil && req.CompanyID == nil && req.IsActive == nil {
+		return apierror.ErrNothingToUpdate
+	}
+
+	updatedUser := *existingUser
 
 	if req.Name != nil {
-		updates = append(updates, fmt.Sprintf("name = $%d", argCount))
-		args = append(args, *req.Name)
-		argCount++
+		updatedUser.Name = *req.Name
 	}
 
 	if req.Email != nil {
 		// Verificar se email já existe em outro usuário
-		var emailExists bool
-		err := database.DB.Get(&emailExists, "SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND id != $2)", *req.Email, userID)
+		emailTaken, err := repos(c).Users().EmailTaken(*req.Email, &userID)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Erro ao verificar email",
-			})
+			return apierror.ErrInternal.Wrap(err)
 		}
-		if emailExists {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Email já está em uso",
-			})
+		if emailTaken {
+			return apierror.ErrEmailInUse
 		}
 
-		updates = append(updates, fmt.Sprintf("email = $%d", argCount))
-		args = append(args, *req.Email)
-		argCount++
+		updatedUser.Email = *req.Email
 	}
 
 	if req.Role != nil {
-		updates = append(updates, fmt.Sprintf("role = $%d", argCount))
-		args = append(args, *req.Role)
-		argCount++
+		updatedUser.Role = *req.Role
 	}
 
 	if req.CompanyID != nil {
 		// Apenas admin pode mudar a empresa do usuário
 		if currentUser.Role != "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Apenas administradores podem alterar a empresa do usuário",
-			})
+			return apierror.ErrUserCompanyChange
 		}
 
 		// Verificar se a empresa existe
-		var companyExists bool
-		err := database.DB.Get(&companyExists, "SELECT EXISTS(SELECT 1 FROM companies WHERE id = $1 AND is_active = true)", *req.CompanyID)
+		companyExists, err := repos(c).Companies().IsActive(*req.CompanyID)
 		if err != nil || !companyExists {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Empresa não encontrada ou inativa",
-			})
+			return apierror.ErrCompanyUnavailable
 		}
 
-		updates = append(updates, fmt.Sprintf("company_id = $%d", argCount))
-		args = append(args, *req.CompanyID)
-		argCount++
+		updatedUser.CompanyID = req.CompanyID
 	}
 
 	if req.IsActive != nil {
 		// Apenas admin pode ativar/desativar usuários
 		if currentUser.Role != "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Apenas administradores podem ativar/desativar usuários",
-			})
+			return apierror.ErrUserStatusChange
 		}
 
-		updates = append(updates, fmt.Sprintf("is_active = $%d", argCount))
-		args = append(args, *req.IsActive)
-		argCount++
+		updatedUser.IsActive = *req.IsActive
 	}
 
-	if len(updates) == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Nenhum campo foi fornecido para atualização",
-		})
-	}
-
-	// Adicionar updated_at
-	updates = append(updates, fmt.Sprintf("updated_at = $%d", argCount))
-	args = append(args, time.Now())
-	argCount++
-
-	// Adicionar ID para WHERE clause
-	args = append(args, userID)
+	updatedUser.UpdatedAt = time.Now()
 
-	query := fmt.Sprintf("UPDATE users SET %s WHERE id = $%d", strings.Join(updates, ", "), argCount)
+	before := auditSnapshot(repos(c), "users", userID)
 
-	_, err = database.DB.Exec(query, args...)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao atualizar usuário",
-		})
+	if err := repos(c).Users().Update(&updatedUser); err != nil {
+		// O RLS esconde de gestores os usuários de outras empresas na verificação acima; o
+		// índice único do email cobre esses casos
+		if isUniqueViolation(err) {
+			return apierror.ErrEmailInUse
+		}
+		return apierror.ErrInternal.Wrap(err)
 	}
 
-	// Buscar usuário atualizado
-	var updatedUser models.User
-	err = database.DB.Get(&updatedUser, "SELECT * FROM users WHERE id = $1", userID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao buscar usuário atualizado",
-		})
-	}
+	recordAudit(c, "update", "users", userID, before, auditSnapshot(repos(c), "users", userID))
 
 	return c.JSON(fiber.Map{
 		"status": "success",
@@ -728,72 +495,53 @@ func DeleteUser(c *fiber.Ctx) error {
 	userID := c.Params("id")
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "ID de usuário inválido",
-		})
+		return apierror.InvalidID("userId")
 	}
 
 	// Obter usuário atual das claims do JWT
 	currentUser := c.Locals("user").(*middleware.JWTClaims)
 
 	// Buscar o usuário a ser excluído
-	var userToDelete models.User
-	err = database.DB.Get(&userToDelete, "SELECT * FROM users WHERE id = $1", userUUID)
-	if err == sql.ErrNoRows {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Usuário não encontrado",
-		})
+	userToDelete, err := repos(c).Users().FindByID(userUUID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrUserNotFound
 	} else if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao buscar usuário",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	// Verificar permissões de exclusão
 	if currentUser.Role != "admin" {
 		// Managers só podem excluir usuários da sua própria empresa
 		if currentUser.CompanyID == nil || userToDelete.CompanyID == nil || *currentUser.CompanyID != *userToDelete.CompanyID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Sem permissão para excluir este usuário",
-			})
+			return apierror.ErrUserDeleteForbidden
 		}
 
 		// Managers não podem excluir admins ou outros managers
 		if userToDelete.Role == "admin" || userToDelete.Role == "manager" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Sem permissão para excluir administradores ou gerentes",
-			})
+			return apierror.ErrUserDeleteForbidden
 		}
 	}
 
 	// Verificar se o usuário está tentando excluir a si mesmo
 	if currentUser.UserID == userUUID {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Você não pode excluir sua própria conta",
-		})
+		return apierror.ErrCannotDeleteSelf
 	}
 
 	// Verificar se existem dados associados ao usuário (se necessário)
 	// Por exemplo, verificar se o usuário criou algum relatório ou outro dado importante
 
+	before := auditSnapshot(repos(c), "users", userUUID)
+
 	// Executar a exclusão
-	_, err = database.DB.Exec("DELETE FROM users WHERE id = $1", userUUID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao excluir usuário",
-		})
+	if err := repos(c).Users().Delete(userUUID); err != nil {
+		return apierror.ErrInternal.Wrap(err)
 	}
 
+	recordAudit(c, "delete", "users", userUUID, before, nil)
+
 	return c.JSON(fiber.Map{
 		"status":  "success",
-		"message": "Usuário excluído com sucesso",
+		"message": i18n.T(c, "messages.user_deleted"),
 		"data": fiber.Map{
 			"deletedUser": fiber.Map{
 				"id":    userToDelete.ID,
@@ -0,0 +1,765 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+)
+
+const careerTrackColumns = `id, company_id, name, description, created_at, updated_at`
+
+const careerLevelColumns = `id, track_id, company_id, code, name, rank, expectations, created_at, updated_at`
+
+// Quantidade padrão de relatórios recentes considerados nas análises de carreira
+const defaultCareerReportWindow = 3
+
+// findCareerTrackForUser busca uma trilha garantindo que pertence à empresa do usuário
+func findCareerTrackForUser(db database.Querier, user *middleware.JWTClaims, trackID uuid.UUID) (*models.CareerTrack, error) {
+	var track models.CareerTrack
+	if err := db.Get(&track, "SELECT "+careerTrackColumns+" FROM career_tracks WHERE id = $1", trackID); err != nil {
+		return nil, err
+	}
+
+	if user.Role != "admin" && (user.CompanyID == nil || *user.CompanyID != track.CompanyID) {
+		return nil, sql.ErrNoRows
+	}
+
+	return &track, nil
+}
+
+// findCareerLevelForUser busca um nível garantindo que pertence à empresa do usuário
+func findCareerLevelForUser(db database.Querier, user *middleware.JWTClaims, levelID uuid.UUID) (*models.CareerLevel, error) {
+	var level models.CareerLevel
+	if err := db.Get(&level, "SELECT "+careerLevelColumns+" FROM career_levels WHERE id = $1", levelID); err != nil {
+		return nil, err
+	}
+
+	if user.Role != "admin" && (user.CompanyID == nil || *user.CompanyID != level.CompanyID) {
+		return nil, sql.ErrNoRows
+	}
+
+	return &level, nil
+}
+
+// loadCareerLevels preenche os níveis das trilhas informadas, ordenados por rank
+func loadCareerLevels(db database.Querier, tracks []models.CareerTrack) error {
+	if len(tracks) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(tracks))
+	index := make(map[uuid.UUID]int, len(tracks))
+	for i := range tracks {
+		tracks[i].Levels = []models.CareerLevel{}
+		ids = append(ids, tracks[i].ID)
+		index[tracks[i].ID] = i
+	}
+
+	trackIDs, args := inList(1, ids)
+	levels := []models.CareerLevel{}
+	err := db.Select(&levels,
+		"SELECT "+careerLevelColumns+" FROM career_levels WHERE track_id IN "+trackIDs+" ORDER BY rank ASC",
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, level := range levels {
+		i := index[level.TrackID]
+		tracks[i].Levels = append(tracks[i].Levels, level)
+	}
+	return nil
+}
+
+// companyScopeFilter restringe consultas à empresa do usuário; admins podem filtrar com ?companyId
+func companyScopeFilter(c *fiber.Ctx, user *middleware.JWTClaims) (*uuid.UUID, error) {
+	if user.Role != "admin" {
+		if user.CompanyID == nil {
+			return nil, apierror.ErrCompanyMembershipRequired
+		}
+		return user.CompanyID, nil
+	}
+
+	if value := c.Query("companyId"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, apierror.InvalidID("companyId")
+		}
+		return &id, nil
+	}
+
+	return nil, nil
+}
+
+// levelChangeType classifica a mudança de nível comparando trilha e rank
+func levelChangeType(from, to *models.CareerLevel) string {
+	if from == nil {
+		return "initial"
+	}
+	if from.TrackID != to.TrackID || from.Rank == to.Rank {
+		return "lateral"
+	}
+	if to.Rank > from.Rank {
+		return "promotion"
+	}
+	return "demotion"
+}
+
+// GetCareerTracks retorna as trilhas de carreira da empresa com seus níveis
+func GetCareerTracks(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	companyID, err := companyScopeFilter(c, user)
+	if err != nil {
+		return err
+	}
+
+	query := "SELECT " + careerTrackColumns + " FROM career_tracks"
+	args := []interface{}{}
+	if companyID != nil {
+		query += " WHERE company_id = $1"
+		args = append(args, *companyID)
+	}
+	query += " ORDER BY name ASC"
+
+	tracks := []models.CareerTrack{}
+	if err := tenantDB(c).Select(&tracks, query, args...); err != nil {
+		logging.From(c).Error("Error querying career tracks", "error", err)
+		return apierror.ErrInternal
+	}
+
+	if err := loadCareerLevels(tenantDB(c), tracks); err != nil {
+		logging.From(c).Error("Error querying career levels", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    tracks,
+	})
+}
+
+// GetCareerTrackByID retorna uma trilha de carreira com seus níveis
+func GetCareerTrackByID(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	trackUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	track, err := findCareerTrackForUser(tenantDB(c), user, trackUUID)
+	if err != nil {
+		return apierror.ErrCareerTrackNotFound
+	}
+
+	tracks := []models.CareerTrack{*track}
+	if err := loadCareerLevels(tenantDB(c), tracks); err != nil {
+		logging.From(c).Error("Error querying career levels", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    tracks[0],
+	})
+}
+
+// CreateCareerTrack cria uma nova trilha de carreira
+func CreateCareerTrack(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	var req models.CreateCareerTrackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	// Determinar a empresa da trilha
+	var companyID *uuid.UUID
+	if user.Role == "admin" && req.CompanyID != nil {
+		companyID = req.CompanyID
+	} else if user.CompanyID != nil {
+		companyID = user.CompanyID
+	} else {
+		return apierror.ErrCompanyMembershipRequired
+	}
+
+	var track models.CareerTrack
+	err := tenantDB(c).Get(&track, `
+		INSERT INTO career_tracks (company_id, name, description)
+		VALUES ($1, $2, $3)
+		RETURNING `+careerTrackColumns,
+		*companyID, req.Name, req.Description,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return apierror.ErrCareerTrackExists
+		}
+		logging.From(c).Error("Error creating career track", "error", err)
+		return apierror.ErrInternal
+	}
+	track.Levels = []models.CareerLevel{}
+
+	recordAudit(c, "create", "career_tracks", track.ID, nil, auditSnapshot(repos(c), "career_tracks", track.ID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    track,
+	})
+}
+
+// UpdateCareerTrack atualiza uma trilha de carreira
+func UpdateCareerTrack(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	trackUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	var req models.UpdateCareerTrackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	if _, err := findCareerTrackForUser(tenantDB(c), user, trackUUID); err != nil {
+		return apierror.ErrCareerTrackNotFound
+	}
+
+	setParts := []string{}
+	args := []interface{}{}
+	argIndex := 1
+
+	if req.Name != nil {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, *req.Name)
+		argIndex++
+	}
+	if req.Description != nil {
+		setParts = append(setParts, fmt.Sprintf("description = $%d", argIndex))
+		args = append(args, *req.Description)
+		argIndex++
+	}
+
+	if len(setParts) == 0 {
+		return apierror.ErrNothingToUpdate
+	}
+
+	query := fmt.Sprintf("UPDATE career_tracks SET %s WHERE id = $%d RETURNING %s", strings.Join(setParts, ", "), argIndex, careerTrackColumns)
+	args = append(args, trackUUID)
+
+	before := auditSnapshot(repos(c), "career_tracks", trackUUID)
+
+	var track models.CareerTrack
+	if err := tenantDB(c).Get(&track, query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return apierror.ErrCareerTrackExists
+		}
+		logging.From(c).Error("Error updating career track", "error", err)
+		return apierror.ErrInternal
+	}
+
+	tracks := []models.CareerTrack{track}
+	if err := loadCareerLevels(tenantDB(c), tracks); err != nil {
+		logging.From(c).Error("Error querying career levels", "error", err)
+	}
+
+	recordAudit(c, "update", "career_tracks", trackUUID, before, auditSnapshot(repos(c), "career_tracks", trackUUID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    tracks[0],
+	})
+}
+
+// DeleteCareerTrack exclui uma trilha e seus níveis; desenvolvedores nesses níveis ficam sem nível
+func DeleteCareerTrack(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	trackUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	if _, err := findCareerTrackForUser(tenantDB(c), user, trackUUID); err != nil {
+		return apierror.ErrCareerTrackNotFound
+	}
+
+	before := auditSnapshot(repos(c), "career_tracks", trackUUID)
+
+	if _, err := tenantDB(c).Exec("DELETE FROM career_tracks WHERE id = $1", trackUUID); err != nil {
+		logging.From(c).Error("Error deleting career track", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "delete", "career_tracks", trackUUID, before, nil)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "messages.career_track_deleted"),
+	})
+}
+
+// CreateCareerLevel adiciona um nível a uma trilha de carreira
+func CreateCareerLevel(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	trackUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	var req models.CreateCareerLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	track, err := findCareerTrackForUser(tenantDB(c), user, trackUUID)
+	if err != nil {
+		return apierror.ErrCareerTrackNotFound
+	}
+
+	expectations := req.Expectations
+	if expectations == nil {
+		expectations = []string{}
+	}
+
+	var level models.CareerLevel
+	err = tenantDB(c).Get(&level, `
+		INSERT INTO career_levels (track_id, company_id, code, name, rank, expectations)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+careerLevelColumns,
+		track.ID, track.CompanyID, req.Code, req.Name, req.Rank, models.Array[string](expectations),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return apierror.ErrCareerLevelExists
+		}
+		logging.From(c).Error("Error creating career level", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "create", "career_levels", level.ID, nil, auditSnapshot(repos(c), "career_levels", level.ID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    level,
+	})
+}
+
+// UpdateCareerLevel atualiza um nível de carreira
+func UpdateCareerLevel(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	levelUUID, err := uuid.Parse(c.Params("levelId"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	var req models.UpdateCareerLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	if _, err := findCareerLevelForUser(tenantDB(c), user, levelUUID); err != nil {
+		return apierror.ErrCareerLevelNotFound
+	}
+
+	setParts := []string{}
+	args := []interface{}{}
+	argIndex := 1
+
+	if req.Code != nil {
+		setParts = append(setParts, fmt.Sprintf("code = $%d", argIndex))
+		args = append(args, *req.Code)
+		argIndex++
+	}
+	if req.Name != nil {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, *req.Name)
+		argIndex++
+	}
+	if req.Rank != nil {
+		setParts = append(setParts, fmt.Sprintf("rank = $%d", argIndex))
+		args = append(args, *req.Rank)
+		argIndex++
+	}
+	if req.Expectations != nil {
+		setParts = append(setParts, fmt.Sprintf("expectations = $%d", argIndex))
+		args = append(args, models.Array[string](req.Expectations))
+		argIndex++
+	}
+
+	if len(setParts) == 0 {
+		return apierror.ErrNothingToUpdate
+	}
+
+	query := fmt.Sprintf("UPDATE career_levels SET %s WHERE id = $%d RETURNING %s", strings.Join(setParts, ", "), argIndex, careerLevelColumns)
+	args = append(args, levelUUID)
+
+	before := auditSnapshot(repos(c), "career_levels", levelUUID)
+
+	var level models.CareerLevel
+	if err := tenantDB(c).Get(&level, query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return apierror.ErrCareerLevelExists
+		}
+		logging.From(c).Error("Error updating career level", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "update", "career_levels", levelUUID, before, auditSnapshot(repos(c), "career_levels", levelUUID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    level,
+	})
+}
+
+// DeleteCareerLevel exclui um nível de carreira
+func DeleteCareerLevel(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	levelUUID, err := uuid.Parse(c.Params("levelId"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	if _, err := findCareerLevelForUser(tenantDB(c), user, levelUUID); err != nil {
+		return apierror.ErrCareerLevelNotFound
+	}
+
+	var inUse bool
+	if err := tenantDB(c).Get(&inUse, "SELECT EXISTS(SELECT 1 FROM developers WHERE level_id = $1)", levelUUID); err != nil {
+		logging.From(c).Error("Error checking career level usage", "error", err)
+		return apierror.ErrInternal
+	}
+	if inUse {
+		return apierror.ErrCareerLevelInUse
+	}
+
+	before := auditSnapshot(repos(c), "career_levels", levelUUID)
+
+	if _, err := tenantDB(c).Exec("DELETE FROM career_levels WHERE id = $1", levelUUID); err != nil {
+		logging.From(c).Error("Error deleting career level", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "delete", "career_levels", levelUUID, before, nil)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "messages.career_level_deleted"),
+	})
+}
+
+// ChangeDeveloperLevel atribui um novo nível ao desenvolvedor e registra a mudança no histórico
+func ChangeDeveloperLevel(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+
+	var req models.ChangeDeveloperLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	companyID, err := developerCompanyForUser(repos(c), user, developerUUID)
+	if err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	toLevel, err := findCareerLevelForUser(tenantDB(c), user, req.LevelID)
+	if err != nil || companyID == nil || toLevel.CompanyID != *companyID {
+		return apierror.ErrCareerLevelNotInCompany
+	}
+
+	// O nível do desenvolvedor é atualizado imediatamente, então só se registram mudanças já em vigor
+	// (a data é comparada com o dia atual no fuso horário da empresa)
+	if req.EffectiveDate > time.Now().In(companyLocation(loadCompanySettings(repos(c), companyID))).Format("2006-01-02") {
+		return apierror.ErrLevelChangeInFuture
+	}
+
+	var currentLevelID *uuid.UUID
+	if err := tenantDB(c).Get(&currentLevelID, "SELECT level_id FROM developers WHERE id = $1", developerUUID); err != nil {
+		logging.From(c).Error("Error querying developer level", "error", err)
+		return apierror.ErrInternal
+	}
+
+	var fromLevel *models.CareerLevel
+	if currentLevelID != nil {
+		if *currentLevelID == toLevel.ID {
+			return apierror.ErrAlreadyAtLevel
+		}
+		var level models.CareerLevel
+		if err := tenantDB(c).Get(&level, "SELECT "+careerLevelColumns+" FROM career_levels WHERE id = $1", *currentLevelID); err == nil {
+			fromLevel = &level
+		}
+	}
+
+	changeType := levelChangeType(fromLevel, toLevel)
+	before := auditSnapshot(repos(c), "developers", developerUUID)
+
+	tx, err := database.Begin(tenantDB(c))
+	if err != nil {
+		logging.From(c).Error("Error starting transaction", "error", err)
+		return apierror.ErrInternal
+	}
+	defer tx.Rollback()
+
+	var change models.DeveloperLevelChange
+	err = tx.Get(&change, `
+		INSERT INTO developer_level_changes (developer_id, company_id, from_level_id, to_level_id, change_type,
+		                                     effective_date, justification, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, developer_id, company_id, from_level_id, to_level_id, change_type, effective_date,
+		          justification, created_by, created_at
+	`, developerUUID, companyID, currentLevelID, toLevel.ID, changeType, req.EffectiveDate, req.Justification, user.UserID)
+	if err != nil {
+		logging.From(c).Error("Error creating level change", "error", err)
+		return apierror.ErrInternal
+	}
+
+	if _, err := tx.Exec("UPDATE developers SET level_id = $1, updated_by = $2 WHERE id = $3", toLevel.ID, user.UserID, developerUUID); err != nil {
+		logging.From(c).Error("Error updating developer level", "error", err)
+		return apierror.ErrInternal
+	}
+
+	if err := tx.Commit(); err != nil {
+		logging.From(c).Error("Error committing level change", "error", err)
+		return apierror.ErrInternal
+	}
+
+	change.ToLevelName = toLevel.Name
+	if fromLevel != nil {
+		change.FromLevelName = fromLevel.Name
+	}
+
+	recordAudit(c, "level_change", "developers", developerUUID, before, auditSnapshot(repos(c), "developers", developerUUID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    change,
+	})
+}
+
+// GetDeveloperLevelHistory retorna o histórico de níveis e promoções de um desenvolvedor
+func GetDeveloperLevelHistory(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+
+	if _, err := developerCompanyForUser(repos(c), user, developerUUID); err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	history := []models.DeveloperLevelChange{}
+	err = tenantDB(c).Select(&history, `
+		SELECT ch.id, ch.developer_id, ch.company_id, ch.from_level_id, ch.to_level_id, ch.change_type,
+		       ch.effective_date, ch.justification, ch.created_by, ch.created_at,
+		       COALESCE(fl.name, '') AS from_level_name, COALESCE(tl.name, '') AS to_level_name
+		FROM developer_level_changes ch
+		LEFT JOIN career_levels fl ON fl.id = ch.from_level_id
+		LEFT JOIN career_levels tl ON tl.id = ch.to_level_id
+		WHERE ch.developer_id = $1
+		ORDER BY ch.effective_date DESC, ch.created_at DESC
+	`, developerUUID)
+	if err != nil {
+		logging.From(c).Error("Error querying level history", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    history,
+	})
+}
+
+// GetCareerAnalytics compara as notas por nível e aponta candidatos à promoção.
+// Um candidato tem média recente (?reports=N relatórios) igual ou superior à média do nível seguinte
+// da mesma trilha e está no nível atual há pelo menos ?minMonths meses.
+func GetCareerAnalytics(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	companyID, err := companyScopeFilter(c, user)
+	if err != nil {
+		return err
+	}
+
+	window, err := strconv.Atoi(c.Query("reports", strconv.Itoa(defaultCareerReportWindow)))
+	if err != nil || window <= 0 {
+		window = defaultCareerReportWindow
+	}
+	minMonths, err := strconv.Atoi(c.Query("minMonths", "0"))
+	if err != nil || minMonths < 0 {
+		minMonths = 0
+	}
+
+	recentScores := `
+		WITH recent AS (
+			SELECT developer_id, weighted_average_score,
+			       ROW_NUMBER() OVER (PARTITION BY developer_id ORDER BY month DESC) AS rn
+			FROM performance_reports
+		),
+		developer_scores AS (
+			SELECT developer_id, AVG(weighted_average_score) AS average_score, COUNT(*) AS report_count
+			FROM recent
+			WHERE rn <= $1
+			GROUP BY developer_id
+		)`
+
+	// Sem empresa (admin sem ?companyId), a análise cobre todas as empresas
+	args := []interface{}{window}
+	companyFilter := ""
+	if companyID != nil {
+		args = append(args, *companyID)
+		companyFilter = " AND l.company_id = $2"
+	}
+
+	var levelRows []struct {
+		models.CareerLevelStats
+		CompanyID *uuid.UUID `db:"company_id"`
+	}
+	err = tenantDB(c).Select(&levelRows, recentScores+`
+		SELECT l.id AS level_id, l.code AS level_code, l.name AS level_name, l.rank,
+		       t.id AS track_id, t.name AS track_name, l.company_id,
+		       COUNT(ds.developer_id) AS developer_count,
+		       COALESCE(AVG(ds.average_score), 0) AS average_score,
+		       COALESCE(MIN(ds.average_score), 0) AS min_score,
+		       COALESCE(MAX(ds.average_score), 0) AS max_score
+		FROM career_levels l
+		INNER JOIN career_tracks t ON t.id = l.track_id`+companyFilter+`
+		LEFT JOIN developers d ON d.level_id = l.id AND d.archived_at IS NULL
+		LEFT JOIN developer_scores ds ON ds.developer_id = d.id
+		GROUP BY l.id, l.code, l.name, l.rank, t.id, t.name, l.company_id
+		ORDER BY t.name ASC, t.id ASC, l.rank ASC
+	`, args...)
+	if err != nil {
+		logging.From(c).Error("Error querying career level stats", "error", err)
+		return apierror.ErrInternal
+	}
+
+	// As notas são arredondadas com a precisão configurada pela empresa de cada nível
+	settingsFor := companySettingsLoader(repos(c))
+	levelStats := make([]models.CareerLevelStats, 0, len(levelRows))
+	for _, row := range levelRows {
+		settings := settingsFor(row.CompanyID)
+		level := row.CareerLevelStats
+		level.AverageScore = roundScore(settings, level.AverageScore)
+		level.MinScore = roundScore(settings, level.MinScore)
+		level.MaxScore = roundScore(settings, level.MaxScore)
+		levelStats = append(levelStats, level)
+	}
+
+	var developers []struct {
+		ID           uuid.UUID     `db:"id"`
+		Name         string        `db:"name"`
+		LevelID      uuid.UUID     `db:"level_id"`
+		CompanyID    *uuid.UUID    `db:"company_id"`
+		AverageScore float64       `db:"average_score"`
+		ReportCount  int           `db:"report_count"`
+		LevelSince   models.DBTime `db:"level_since"`
+	}
+	err = tenantDB(c).Select(&developers, recentScores+`
+		SELECT d.id, d.name, d.level_id, d.company_id, ds.average_score, ds.report_count,
+		       COALESCE(
+		           (SELECT MAX(ch.effective_date) FROM developer_level_changes ch
+		            WHERE ch.developer_id = d.id AND ch.to_level_id = d.level_id),
+		           d.created_at
+		       ) AS level_since
+		FROM developers d
+		INNER JOIN developer_scores ds ON ds.developer_id = d.id
+		INNER JOIN career_levels l ON l.id = d.level_id
+		WHERE d.archived_at IS NULL`+companyFilter+`
+	`, args...)
+	if err != nil {
+		logging.From(c).Error("Error querying promotion candidates", "error", err)
+		return apierror.ErrInternal
+	}
+
+	// Nível seguinte de cada nível dentro da mesma trilha (levelStats já está ordenado por trilha e rank;
+	// trilhas com o mesmo nome, de empresas diferentes, não se intercalam porque o id desempata)
+	levelsByID := make(map[uuid.UUID]models.CareerLevelStats, len(levelStats))
+	nextLevel := make(map[uuid.UUID]models.CareerLevelStats)
+	for i, level := range levelStats {
+		levelsByID[level.LevelID] = level
+		if i+1 < len(levelStats) && levelStats[i+1].TrackID == level.TrackID {
+			nextLevel[level.LevelID] = levelStats[i+1]
+		}
+	}
+
+	now := time.Now()
+	candidates := []models.PromotionCandidate{}
+	for _, dev := range developers {
+		dev.AverageScore = roundScore(settingsFor(dev.CompanyID), dev.AverageScore)
+
+		next, ok := nextLevel[dev.LevelID]
+		if !ok || next.DeveloperCount == 0 || dev.AverageScore < next.AverageScore {
+			continue
+		}
+
+		months := (now.Year()-dev.LevelSince.Year())*12 + int(now.Month()-dev.LevelSince.Month())
+		if months < minMonths {
+			continue
+		}
+
+		current := levelsByID[dev.LevelID]
+		candidates = append(candidates, models.PromotionCandidate{
+			DeveloperID:       dev.ID,
+			DeveloperName:     dev.Name,
+			CurrentLevelID:    current.LevelID,
+			CurrentLevelName:  current.LevelName,
+			NextLevelID:       next.LevelID,
+			NextLevelName:     next.LevelName,
+			RecentAverage:     dev.AverageScore,
+			NextLevelAverage:  next.AverageScore,
+			ReportsConsidered: dev.ReportCount,
+			MonthsInLevel:     months,
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].RecentAverage > candidates[j].RecentAverage
+	})
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"levels":              levelStats,
+			"promotionCandidates": candidates,
+			"reportsConsidered":   window,
+		},
+	})
+}
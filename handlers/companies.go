@@ -1,49 +1,35 @@
 package handlers
 
 import (
-	"database/sql"
-	"fmt"
-	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
-	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/i18n"
 	"tivix-performance-tracker-backend/middleware"
 	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
 )
 
 func CreateCompany(c *fiber.Ctx) error {
 	var req models.CreateCompanyRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	if err := validate.Struct(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados de entrada inválidos",
-			"details": err.Error(),
-		})
+		return apierror.Validation(err)
 	}
 
-	var existingCompany models.Company
-	err := database.DB.Get(&existingCompany, "SELECT id FROM companies WHERE name = $1", req.Name)
-	if err == nil {
-		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Já existe uma empresa com esse nome",
-		})
-	} else if err != sql.ErrNoRows {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro interno do servidor",
-		})
+	nameTaken, err := repos(c).Companies().NameTaken(req.Name, nil)
+	if err != nil {
+		return apierror.ErrInternal.Wrap(err)
+	}
+	if nameTaken {
+		return apierror.ErrCompanyAlreadyExists
 	}
 
 	company := models.Company{
@@ -55,18 +41,12 @@ func CreateCompany(c *fiber.Ctx) error {
 		UpdatedAt:   time.Now(),
 	}
 
-	query := `
-		INSERT INTO companies (id, name, description, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
-	_, err = database.DB.Exec(query, company.ID, company.Name, company.Description, company.IsActive, company.CreatedAt, company.UpdatedAt)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao criar empresa",
-		})
+	if err := repos(c).Companies().Create(&company); err != nil {
+		return apierror.ErrInternal.Wrap(err)
 	}
 
+	recordAudit(c, "create", "companies", company.ID, nil, auditSnapshot(repos(c), "companies", company.ID))
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"status": "success",
 		"data":   company,
@@ -75,40 +55,18 @@ func CreateCompany(c *fiber.Ctx) error {
 
 func GetAllCompanies(c *fiber.Ctx) error {
 	user := c.Locals("user").(*middleware.JWTClaims)
-	var companies []models.Company
-
-	var query string
-	var args []interface{}
-
-	if user.Role == "admin" {
-		query = `
-			SELECT id, name, description, is_active, created_at, updated_at
-			FROM companies
-			ORDER BY name ASC
-		`
-	} else {
+
+	var companyID *uuid.UUID
+	if user.Role != "admin" {
 		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Usuário deve estar associado a uma empresa",
-			})
+			return apierror.ErrCompanyMembershipRequired
 		}
-
-		query = `
-			SELECT id, name, description, is_active, created_at, updated_at
-			FROM companies
-			WHERE id = $1
-			ORDER BY name ASC
-		`
-		args = append(args, *user.CompanyID)
+		companyID = user.CompanyID
 	}
 
-	err := database.DB.Select(&companies, query, args...)
+	companies, err := repos(c).Companies().List(companyID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao buscar empresas",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	return c.JSON(fiber.Map{
@@ -121,29 +79,14 @@ func GetCompanyByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	companyID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "ID da empresa inválido",
-		})
+		return apierror.InvalidID("companyId")
 	}
 
-	var company models.Company
-	query := `
-		SELECT id, name, description, is_active, created_at, updated_at
-		FROM companies
-		WHERE id = $1
-	`
-	err = database.DB.Get(&company, query, companyID)
-	if err == sql.ErrNoRows {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Empresa não encontrada",
-		})
+	company, err := repos(c).Companies().FindByID(companyID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrCompanyNotFound
 	} else if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao buscar empresa",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	return c.JSON(fiber.Map{
@@ -156,105 +99,57 @@ func UpdateCompany(c *fiber.Ctx) error {
 	id := c.Params("id")
 	companyID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "ID da empresa inválido",
-		})
+		return apierror.InvalidID("companyId")
 	}
 
 	var req models.UpdateCompanyRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
-	var existingCompany models.Company
-	err = database.DB.Get(&existingCompany, "SELECT * FROM companies WHERE id = $1", companyID)
-	if err == sql.ErrNoRows {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Empresa não encontrada",
-		})
+	company, err := repos(c).Companies().FindByID(companyID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrCompanyNotFound
 	} else if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao buscar empresa",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
-	var updates []string
-	var args []interface{}
-	argCount := 1
+	if req.Name == nil && req.Description == nil && req.IsActive == nil {
+		return apierror.ErrNothingToUpdate
+	}
 
 	if req.Name != nil {
-		var nameCheckCompany models.Company
-		err := database.DB.Get(&nameCheckCompany, "SELECT id FROM companies WHERE name = $1 AND id != $2", *req.Name, companyID)
-		if err == nil {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Já existe uma empresa com esse nome",
-			})
-		} else if err != sql.ErrNoRows {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Erro interno do servidor",
-			})
+		nameTaken, err := repos(c).Companies().NameTaken(*req.Name, &companyID)
+		if err != nil {
+			return apierror.ErrInternal.Wrap(err)
 		}
-
-		updates = append(updates, fmt.Sprintf("name = $%d", argCount))
-		args = append(args, *req.Name)
-		argCount++
+		if nameTaken {
+			return apierror.ErrCompanyAlreadyExists
+		}
+		company.Name = *req.Name
 	}
 
 	if req.Description != nil {
-		updates = append(updates, fmt.Sprintf("description = $%d", argCount))
-		args = append(args, *req.Description)
-		argCount++
+		company.Description = *req.Description
 	}
 
 	if req.IsActive != nil {
-		updates = append(updates, fmt.Sprintf("is_active = $%d", argCount))
-		args = append(args, *req.IsActive)
-		argCount++
+		company.IsActive = *req.IsActive
 	}
 
-	if len(updates) == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Nenhum campo foi fornecido para atualização",
-		})
-	}
+	company.UpdatedAt = time.Now()
 
-	updates = append(updates, fmt.Sprintf("updated_at = $%d", argCount))
-	args = append(args, time.Now())
-	argCount++
+	before := auditSnapshot(repos(c), "companies", companyID)
 
-	args = append(args, companyID)
-
-	query := fmt.Sprintf("UPDATE companies SET %s WHERE id = $%d", strings.Join(updates, ", "), argCount)
-
-	_, err = database.DB.Exec(query, args...)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao atualizar empresa",
-		})
+	if err := repos(c).Companies().Update(company); err != nil {
+		return apierror.ErrInternal.Wrap(err)
 	}
 
-	var updatedCompany models.Company
-	err = database.DB.Get(&updatedCompany, "SELECT * FROM companies WHERE id = $1", companyID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao buscar empresa atualizada",
-		})
-	}
+	recordAudit(c, "update", "companies", companyID, before, auditSnapshot(repos(c), "companies", companyID))
 
 	return c.JSON(fiber.Map{
 		"status": "success",
-		"data":   updatedCompany,
+		"data":   company,
 	})
 }
 
@@ -262,52 +157,35 @@ func DeleteCompany(c *fiber.Ctx) error {
 	id := c.Params("id")
 	companyID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "ID da empresa inválido",
-		})
+		return apierror.InvalidID("companyId")
 	}
 
-	var company models.Company
-	err = database.DB.Get(&company, "SELECT id FROM companies WHERE id = $1", companyID)
-	if err == sql.ErrNoRows {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Empresa não encontrada",
-		})
+	_, err = repos(c).Companies().FindByID(companyID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrCompanyNotFound
 	} else if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao buscar empresa",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
-	var userCount int
-	err = database.DB.Get(&userCount, "SELECT COUNT(*) FROM users WHERE company_id = $1", companyID)
+	userCount, err := repos(c).Users().CountByCompany(companyID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao verificar usuários associados",
-		})
+		return apierror.ErrInternal.Wrap(err)
 	}
 
 	if userCount > 0 {
-		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Não é possível excluir uma empresa que possui usuários associados",
-		})
+		return apierror.ErrCompanyHasUsers
 	}
 
-	_, err = database.DB.Exec("DELETE FROM companies WHERE id = $1", companyID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Erro ao excluir empresa",
-		})
+	before := auditSnapshot(repos(c), "companies", companyID)
+
+	if err := repos(c).Companies().Delete(companyID); err != nil {
+		return apierror.ErrInternal.Wrap(err)
 	}
 
+	recordAudit(c, "delete", "companies", companyID, before, nil)
+
 	return c.JSON(fiber.Map{
 		"status":  "success",
-		"message": "Empresa excluída com sucesso",
+		"message": i18n.T(c, "messages.company_deleted"),
 	})
 }
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+	_ "time/tzdata" // fusos horários disponíveis mesmo em imagens sem zoneinfo
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+)
+
+// defaultCompanySettings reproduz o comportamento anterior às configurações por empresa
+func defaultCompanySettings(companyID uuid.UUID) models.CompanySettings {
+	return models.CompanySettings{
+		CompanyID:            companyID,
+		ScoreMin:             0,
+		ScoreMax:             10,
+		ScoreStep:            0.01,
+		DecimalPlaces:        2,
+		Locale:               "pt-BR",
+		Timezone:             "America/Sao_Paulo",
+		FiscalYearStartMonth: 1,
+		TeamColorPalette:     models.Array[string]{"blue", "green", "purple", "orange", "red", "teal"},
+	}
+}
+
+// loadCompanySettings retorna as configurações da empresa ou os valores padrão
+func loadCompanySettings(store repository.Store, companyID *uuid.UUID) models.CompanySettings {
+	if companyID == nil {
+		return defaultCompanySettings(uuid.Nil)
+	}
+
+	settings, err := store.Companies().Settings(*companyID)
+	if err != nil {
+		if err != repository.ErrNotFound {
+			slog.Error("Error querying company settings", "error", err)
+		}
+		return defaultCompanySettings(*companyID)
+	}
+
+	return *settings
+}
+
+// companySettingsLoader carrega as configurações de cada empresa uma única vez, para respostas
+// que reúnem dados de várias empresas
+func companySettingsLoader(store repository.Store) func(companyID *uuid.UUID) models.CompanySettings {
+	loaded := make(map[uuid.UUID]models.CompanySettings)
+	return func(companyID *uuid.UUID) models.CompanySettings {
+		key := uuid.Nil
+		if companyID != nil {
+			key = *companyID
+		}
+		settings, ok := loaded[key]
+		if !ok {
+			settings = loadCompanySettings(store, companyID)
+			loaded[key] = settings
+		}
+		return settings
+	}
+}
+
+// validateScore verifica se a nota respeita a escala da empresa
+func validateScore(settings models.CompanySettings, score float64) error {
+	if score < settings.ScoreMin || score > settings.ScoreMax {
+		return apierror.ErrScoreOutOfRange.WithArgs(formatScore(settings, settings.ScoreMin), formatScore(settings, settings.ScoreMax))
+	}
+
+	// Tolerância para erros de ponto flutuante ao verificar o incremento
+	steps := (score - settings.ScoreMin) / settings.ScoreStep
+	if math.Abs(steps-math.Round(steps)) > 1e-6 {
+		return apierror.ErrScoreStepMismatch.WithArgs(formatScore(settings, settings.ScoreStep))
+	}
+
+	return nil
+}
+
+// validateScores aplica validateScore a cada nota do mapa (questionScores, categoryScores);
+// valores que não são números são recusados
+func validateScores(settings models.CompanySettings, field string, scores models.JSONB) error {
+	keys := make([]string, 0, len(scores))
+	for key := range scores {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		score, ok := scores[key].(float64)
+		if !ok {
+			return apierror.InvalidField(field+"."+key, "invalid", "")
+		}
+		if err := validateScore(settings, score); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// roundScore arredonda uma nota conforme a precisão configurada
+func roundScore(settings models.CompanySettings, score float64) float64 {
+	factor := math.Pow(10, float64(settings.DecimalPlaces))
+	return math.Round(score*factor) / factor
+}
+
+func formatScore(settings models.CompanySettings, score float64) string {
+	return fmt.Sprintf("%.*f", settings.DecimalPlaces, score)
+}
+
+// companyLocation retorna o fuso horário configurado, com UTC como fallback
+func companyLocation(settings models.CompanySettings) *time.Location {
+	location, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// fiscalYearMonths retorna o primeiro e o último mês (YYYY-MM) do ano fiscal iniciado no ano informado
+func fiscalYearMonths(settings models.CompanySettings, fiscalYear int) (string, string) {
+	start := time.Date(fiscalYear, time.Month(settings.FiscalYearStartMonth), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 11, 0)
+	return start.Format("2006-01"), end.Format("2006-01")
+}
+
+// nextTeamColor escolhe a próxima cor da paleta da empresa com base na quantidade de times
+func nextTeamColor(db database.Querier, settings models.CompanySettings, companyID *uuid.UUID) string {
+	if len(settings.TeamColorPalette) == 0 {
+		return "blue"
+	}
+
+	var count int
+	if companyID != nil {
+		if err := db.Get(&count, "SELECT COUNT(*) FROM teams WHERE company_id = $1", *companyID); err != nil {
+			slog.Error("Error counting company teams", "error", err)
+		}
+	}
+
+	return settings.TeamColorPalette[count%len(settings.TeamColorPalette)]
+}
+
+// settingsCompanyForUser resolve a empresa das configurações: a do usuário ou ?companyId para admins
+func settingsCompanyForUser(c *fiber.Ctx, user *middleware.JWTClaims) (*uuid.UUID, error) {
+	companyID, err := companyScopeFilter(c, user)
+	if err != nil {
+		return nil, err
+	}
+	if companyID == nil {
+		return nil, apierror.Required("companyId")
+	}
+	return companyID, nil
+}
+
+// GetCompanySettings retorna as configurações da empresa do usuário
+func GetCompanySettings(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	companyID, err := settingsCompanyForUser(c, user)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    loadCompanySettings(repos(c), companyID),
+	})
+}
+
+// UpdateCompanySettings atualiza as configurações da empresa (gestores da empresa e admins)
+func UpdateCompanySettings(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	companyID, err := settingsCompanyForUser(c, user)
+	if err != nil {
+		return err
+	}
+
+	var req models.UpdateCompanySettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	if _, err := repos(c).Companies().FindByID(*companyID); err != nil {
+		return apierror.ErrCompanyNotFound
+	}
+
+	settings := loadCompanySettings(repos(c), companyID)
+	if req.ScoreMin != nil {
+		settings.ScoreMin = *req.ScoreMin
+	}
+	if req.ScoreMax != nil {
+		settings.ScoreMax = *req.ScoreMax
+	}
+	if req.ScoreStep != nil {
+		settings.ScoreStep = *req.ScoreStep
+	}
+	if req.DecimalPlaces != nil {
+		settings.DecimalPlaces = *req.DecimalPlaces
+	}
+	if req.Locale != nil {
+		settings.Locale = *req.Locale
+	}
+	if req.Timezone != nil {
+		settings.Timezone = *req.Timezone
+	}
+	if req.FiscalYearStartMonth != nil {
+		settings.FiscalYearStartMonth = *req.FiscalYearStartMonth
+	}
+	if req.TeamColorPalette != nil {
+		settings.TeamColorPalette = req.TeamColorPalette
+	}
+
+	if settings.ScoreMax <= settings.ScoreMin {
+		return apierror.ErrScoreScaleInverted
+	}
+	if settings.ScoreStep > settings.ScoreMax-settings.ScoreMin {
+		return apierror.ErrScoreStepTooLarge
+	}
+	if settings.ScoreMin < -9999 || settings.ScoreMax > 9999 {
+		return apierror.ErrScoreScaleOutOfRange
+	}
+
+	before := loadCompanySettings(repos(c), companyID)
+
+	updated := settings
+	updated.UpdatedBy = &user.UserID
+	if err := repos(c).Companies().SaveSettings(&updated); err != nil {
+		logging.From(c).Error("Error updating company settings", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "update", "company_settings", *companyID, companySettingsSnapshot(before), companySettingsSnapshot(updated))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    updated,
+	})
+}
+
+// companySettingsSnapshot converte as configurações para o formato do log de auditoria
+func companySettingsSnapshot(settings models.CompanySettings) models.JSONB {
+	return models.JSONB{
+		"company_id":              settings.CompanyID.String(),
+		"score_min":               settings.ScoreMin,
+		"score_max":               settings.ScoreMax,
+		"score_step":              settings.ScoreStep,
+		"decimal_places":          settings.DecimalPlaces,
+		"locale":                  settings.Locale,
+		"timezone":                settings.Timezone,
+		"fiscal_year_start_month": settings.FiscalYearStartMonth,
+		"team_color_palette":      []string(settings.TeamColorPalette),
+	}
+}
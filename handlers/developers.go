@@ -1,90 +1,42 @@
 package handlers
 
 import (
-	"database/sql"
-	"fmt"
-	"log"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
-	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
 	"tivix-performance-tracker-backend/middleware"
 	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
 )
 
 // GetAllDevelopers retorna todos os desenvolvedores
 func GetAllDevelopers(c *fiber.Ctx) error {
 	user := c.Locals("user").(*middleware.JWTClaims)
 	
+	filter := repository.DeveloperFilter{}
+
 	// Verificar se deve incluir arquivados
-	includeArchived := c.Query("includeArchived", "false")
-
-	var query string
-	var args []interface{}
-
-	if user.Role == "admin" {
-		// Admins podem ver todos os desenvolvedores
-		query = `
-			SELECT id, name, role, latest_performance_score, team_id, company_id, archived_at, created_at, updated_at 
-			FROM developers 
-		`
-		if includeArchived != "true" {
-			query += " WHERE archived_at IS NULL"
-		}
-	} else {
-		// Managers e usuários só podem ver desenvolvedores da sua empresa
+	if c.Query("includeArchived", "false") == "true" {
+		filter.Archived = repository.IncludeArchived
+	}
+
+	// Admins podem ver todos os desenvolvedores; managers e usuários só os da sua empresa
+	if user.Role != "admin" {
 		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   true,
-				"message": "Usuário deve estar associado a uma empresa",
-			})
-		}
-		
-		query = `
-			SELECT id, name, role, latest_performance_score, team_id, company_id, archived_at, created_at, updated_at 
-			FROM developers 
-			WHERE company_id = $1
-		`
-		args = append(args, *user.CompanyID)
-		
-		if includeArchived != "true" {
-			query += " AND archived_at IS NULL"
+			return apierror.ErrCompanyMembershipRequired
 		}
+		filter.CompanyID = user.CompanyID
 	}
 
-	query += " ORDER BY created_at DESC"
-
-	rows, err := database.DB.Query(query, args...)
+	developers, err := repos(c).Developers().List(filter)
 	if err != nil {
-		log.Printf("Error querying developers: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar desenvolvedores",
-		})
-	}
-	defer rows.Close()
-
-	var developers []models.Developer
-	for rows.Next() {
-		var developer models.Developer
-		err := rows.Scan(
-			&developer.ID,
-			&developer.Name,
-			&developer.Role,
-			&developer.LatestPerformanceScore,
-			&developer.TeamID,
-			&developer.CompanyID,
-			&developer.ArchivedAt,
-			&developer.CreatedAt,
-			&developer.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning developer: %v", err)
-			continue
-		}
-		developers = append(developers, developer)
+		logging.From(c).Error("Error querying developers", "error", err)
+		return apierror.ErrInternal
 	}
 
 	return c.JSON(fiber.Map{
@@ -95,41 +47,22 @@ func GetAllDevelopers(c *fiber.Ctx) error {
 
 // GetArchivedDevelopers retorna apenas desenvolvedores arquivados
 func GetArchivedDevelopers(c *fiber.Ctx) error {
-	query := `
-		SELECT id, name, role, latest_performance_score, team_id, archived_at, created_at, updated_at 
-		FROM developers 
-		WHERE archived_at IS NOT NULL
-		ORDER BY archived_at DESC
-	`
-
-	rows, err := database.DB.Query(query)
-	if err != nil {
-		log.Printf("Error querying archived developers: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar desenvolvedores arquivados",
-		})
-	}
-	defer rows.Close()
-
-	var developers []models.Developer
-	for rows.Next() {
-		var developer models.Developer
-		err := rows.Scan(
-			&developer.ID,
-			&developer.Name,
-			&developer.Role,
-			&developer.LatestPerformanceScore,
-			&developer.TeamID,
-			&developer.ArchivedAt,
-			&developer.CreatedAt,
-			&developer.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning developer: %v", err)
-			continue
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	filter := repository.DeveloperFilter{Archived: repository.OnlyArchived}
+
+	// Managers e usuários só podem ver desenvolvedores da sua empresa
+	if user.Role != "admin" {
+		if user.CompanyID == nil {
+			return apierror.ErrCompanyMembershipRequired
 		}
-		developers = append(developers, developer)
+		filter.CompanyID = user.CompanyID
+	}
+
+	developers, err := repos(c).Developers().List(filter)
+	if err != nil {
+		logging.From(c).Error("Error querying archived developers", "error", err)
+		return apierror.ErrInternal
 	}
 
 	return c.JSON(fiber.Map{
@@ -140,45 +73,20 @@ func GetArchivedDevelopers(c *fiber.Ctx) error {
 
 // GetDeveloperByID retorna um desenvolvedor específico por ID
 func GetDeveloperByID(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
 	id := c.Params("id")
 	developerUUID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID inválido",
-		})
-	}
-
-	query := `
-		SELECT id, name, role, latest_performance_score, team_id, archived_at, created_at, updated_at 
-		FROM developers 
-		WHERE id = $1
-	`
-
-	var developer models.Developer
-	err = database.DB.QueryRow(query, developerUUID).Scan(
-		&developer.ID,
-		&developer.Name,
-		&developer.Role,
-		&developer.LatestPerformanceScore,
-		&developer.TeamID,
-		&developer.ArchivedAt,
-		&developer.CreatedAt,
-		&developer.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return c.Status(404).JSON(fiber.Map{
-			"error":   true,
-			"message": "Desenvolvedor não encontrado",
-		})
+		return apierror.InvalidID("id")
+	}
+
+	developer, err := findDeveloperForUser(c, user, developerUUID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrDeveloperNotFound
 	}
 	if err != nil {
-		log.Printf("Error querying developer: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar desenvolvedor",
-		})
+		logging.From(c).Error("Error querying developer", "error", err)
+		return apierror.ErrInternal
 	}
 
 	return c.JSON(fiber.Map{
@@ -193,24 +101,15 @@ func CreateDeveloper(c *fiber.Ctx) error {
 	
 	var req models.CreateDeveloperRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	if req.Name == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Nome é obrigatório",
-		})
+		return apierror.Required("name")
 	}
 
 	if req.Role == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Cargo é obrigatório",
-		})
+		return apierror.Required("role")
 	}
 
 	// Determinar a empresa do desenvolvedor
@@ -222,64 +121,46 @@ func CreateDeveloper(c *fiber.Ctx) error {
 		// Managers e usuários criam desenvolvedores na sua própria empresa
 		companyID = user.CompanyID
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error":   true,
-			"message": "Usuário deve estar associado a uma empresa",
-		})
+		return apierror.ErrCompanyMembershipRequired
 	}
 
 	// Verificar se o team_id existe e pertence à mesma empresa (se fornecido)
 	if req.TeamID != nil {
-		var teamCompanyID *uuid.UUID
-		err := database.DB.QueryRow("SELECT company_id FROM teams WHERE id = $1", *req.TeamID).Scan(&teamCompanyID)
-		if err == sql.ErrNoRows {
-			return c.Status(400).JSON(fiber.Map{
-				"error":   true,
-				"message": "Time não encontrado",
-			})
+		teamCompanyID, err := repos(c).Teams().CompanyOf(*req.TeamID)
+		if err == repository.ErrNotFound {
+			return apierror.ErrTeamNotInCompany
 		} else if err != nil {
-			return c.Status(500).JSON(fiber.Map{
-				"error":   true,
-				"message": "Erro ao verificar time",
-			})
+			return apierror.ErrInternal.Wrap(err)
 		}
 
 		// Verificar se o time pertence à mesma empresa
 		if user.Role != "admin" && (teamCompanyID == nil || companyID == nil || *teamCompanyID != *companyID) {
-			return c.Status(400).JSON(fiber.Map{
-				"error":   true,
-				"message": "Time não pertence à sua empresa",
-			})
+			return apierror.ErrTeamNotInCompany
 		}
 	}
 
-	query := `
-		INSERT INTO developers (name, role, team_id, company_id)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, name, role, latest_performance_score, team_id, company_id, archived_at, created_at, updated_at
-	`
-
-	var developer models.Developer
-	err := database.DB.QueryRow(query, req.Name, req.Role, req.TeamID, companyID).Scan(
-		&developer.ID,
-		&developer.Name,
-		&developer.Role,
-		&developer.LatestPerformanceScore,
-		&developer.TeamID,
-		&developer.CompanyID,
-		&developer.ArchivedAt,
-		&developer.CreatedAt,
-		&developer.UpdatedAt,
-	)
+	// Verificar se o usuário vinculado pertence à mesma empresa (se fornecido)
+	if req.UserID != nil && !userInCompany(repos(c), *req.UserID, companyID) {
+		return apierror.ErrLinkedUserNotInCompany
+	}
+
+	developer := models.Developer{
+		Name:      req.Name,
+		Role:      req.Role,
+		TeamID:    req.TeamID,
+		CompanyID: companyID,
+		UserID:    req.UserID,
+		CreatedBy: &user.UserID,
+	}
 
+	err := repos(c).Developers().Create(&developer)
 	if err != nil {
-		log.Printf("Error creating developer: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao criar desenvolvedor",
-		})
+		logging.From(c).Error("Error creating developer", "error", err)
+		return apierror.ErrInternal
 	}
 
+	recordAudit(c, "create", "developers", developer.ID, nil, auditSnapshot(repos(c), "developers", developer.ID))
+
 	return c.Status(201).JSON(fiber.Map{
 		"success": true,
 		"data":    developer,
@@ -288,264 +169,184 @@ func CreateDeveloper(c *fiber.Ctx) error {
 
 // UpdateDeveloper atualiza um desenvolvedor existente
 func UpdateDeveloper(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
 	id := c.Params("id")
 	developerUUID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID inválido",
-		})
+		return apierror.InvalidID("id")
 	}
 
 	var req models.UpdateDeveloperRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
+	}
+
+	// Verificar se o desenvolvedor existe e pertence à empresa do usuário
+	developer, err := findDeveloperForUser(c, user, developerUUID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrDeveloperNotFound
+	}
+	if err != nil {
+		logging.From(c).Error("Error querying developer", "error", err)
+		return apierror.ErrInternal
 	}
 
-	// Verificar se o desenvolvedor existe
-	var exists bool
-	err = database.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM developers WHERE id = $1)", developerUUID).Scan(&exists)
-	if err != nil || !exists {
-		return c.Status(404).JSON(fiber.Map{
-			"error":   true,
-			"message": "Desenvolvedor não encontrado",
-		})
+	// Verificar se o usuário vinculado pertence à mesma empresa (se fornecido)
+	if req.UserID != nil && !userInCompany(repos(c), *req.UserID, developer.CompanyID) {
+		return apierror.ErrLinkedUserNotInCompany
 	}
 
-	// Verificar se o team_id existe (se fornecido)
+	// Verificar se o time existe e pertence à empresa do desenvolvedor (se fornecido)
 	if req.TeamID != nil {
-		var teamExists bool
-		err := database.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM teams WHERE id = $1)", *req.TeamID).Scan(&teamExists)
-		if err != nil || !teamExists {
-			return c.Status(400).JSON(fiber.Map{
-				"error":   true,
-				"message": "Time não encontrado",
-			})
+		teamCompanyID, err := repos(c).Teams().CompanyOf(*req.TeamID)
+		if err != nil || teamCompanyID == nil || developer.CompanyID == nil || *teamCompanyID != *developer.CompanyID {
+			return apierror.ErrTeamNotInCompany
 		}
 	}
 
-	// Construir query dinâmica
-	setParts := []string{}
-	args := []interface{}{}
-	argIndex := 1
+	if req.Name == nil && req.Role == nil && req.LatestPerformanceScore == nil && req.TeamID == nil && req.UserID == nil {
+		return apierror.ErrNothingToUpdate
+	}
 
 	if req.Name != nil {
-		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
-		args = append(args, *req.Name)
-		argIndex++
+		developer.Name = *req.Name
 	}
 	if req.Role != nil {
-		setParts = append(setParts, fmt.Sprintf("role = $%d", argIndex))
-		args = append(args, *req.Role)
-		argIndex++
+		developer.Role = *req.Role
 	}
 	if req.LatestPerformanceScore != nil {
-		setParts = append(setParts, fmt.Sprintf("latest_performance_score = $%d", argIndex))
-		args = append(args, *req.LatestPerformanceScore)
-		argIndex++
+		developer.LatestPerformanceScore = *req.LatestPerformanceScore
 	}
 	if req.TeamID != nil {
-		setParts = append(setParts, fmt.Sprintf("team_id = $%d", argIndex))
-		args = append(args, *req.TeamID)
-		argIndex++
-	}
-
-	if len(setParts) == 0 {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Nenhum campo para atualizar",
-		})
+		developer.TeamID = req.TeamID
 	}
-
-	query := "UPDATE developers SET "
-	for i, part := range setParts {
-		if i > 0 {
-			query += ", "
-		}
-		query += part
+	if req.UserID != nil {
+		developer.UserID = req.UserID
 	}
-	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, name, role, latest_performance_score, team_id, archived_at, created_at, updated_at", argIndex)
-
-	args = append(args, developerUUID)
+	developer.UpdatedBy = &user.UserID
 
-	var developer models.Developer
-	err = database.DB.QueryRow(query, args...).Scan(
-		&developer.ID,
-		&developer.Name,
-		&developer.Role,
-		&developer.LatestPerformanceScore,
-		&developer.TeamID,
-		&developer.ArchivedAt,
-		&developer.CreatedAt,
-		&developer.UpdatedAt,
-	)
+	before := auditSnapshot(repos(c), "developers", developerUUID)
 
-	if err != nil {
-		log.Printf("Error updating developer: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao atualizar desenvolvedor",
-		})
+	if err := repos(c).Developers().Update(developer); err != nil {
+		logging.From(c).Error("Error updating developer", "error", err)
+		return apierror.ErrInternal
 	}
 
+	recordAudit(c, "update", "developers", developerUUID, before, auditSnapshot(repos(c), "developers", developerUUID))
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    developer,
 	})
 }
 
+// findDeveloperForUser busca um desenvolvedor garantindo que pertence à empresa do usuário;
+// desenvolvedores de outras empresas são tratados como inexistentes
+func findDeveloperForUser(c *fiber.Ctx, user *middleware.JWTClaims, developerID uuid.UUID) (*models.Developer, error) {
+	developer, err := repos(c).Developers().FindByID(developerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != "admin" {
+		if user.CompanyID == nil || developer.CompanyID == nil || *user.CompanyID != *developer.CompanyID {
+			return nil, repository.ErrNotFound
+		}
+	}
+
+	return developer, nil
+}
+
 // ArchiveDeveloper arquiva ou restaura um desenvolvedor
 func ArchiveDeveloper(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
 	id := c.Params("id")
 	developerUUID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID inválido",
-		})
+		return apierror.InvalidID("id")
 	}
 
 	var req models.ArchiveDeveloperRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
-	var query string
-	var archivedAt *time.Time
+	if user.Role != "admin" {
+		existingDeveloper, err := repos(c).Developers().FindByID(developerUUID)
+		if err == nil && (user.CompanyID == nil || existingDeveloper.CompanyID == nil || *user.CompanyID != *existingDeveloper.CompanyID) {
+			err = repository.ErrNotFound
+		}
+		if err == repository.ErrNotFound {
+			return apierror.ErrDeveloperNotFound
+		}
+		if err != nil {
+			logging.From(c).Error("Error checking developer existence", "error", err)
+			return apierror.ErrInternal
+		}
+	}
 
+	// Sem data de arquivamento o desenvolvedor é restaurado
+	var archivedAt *time.Time
 	if req.Archive {
-		// Arquivar desenvolvedor
 		now := time.Now()
 		archivedAt = &now
-		query = `
-			UPDATE developers 
-			SET archived_at = $1 
-			WHERE id = $2 
-			RETURNING id, name, role, latest_performance_score, team_id, archived_at, created_at, updated_at
-		`
-	} else {
-		// Restaurar desenvolvedor
-		query = `
-			UPDATE developers 
-			SET archived_at = NULL 
-			WHERE id = $1 
-			RETURNING id, name, role, latest_performance_score, team_id, archived_at, created_at, updated_at
-		`
 	}
 
-	var developer models.Developer
-	var scanErr error
+	before := auditSnapshot(repos(c), "developers", developerUUID)
 
-	if req.Archive {
-		scanErr = database.DB.QueryRow(query, archivedAt, developerUUID).Scan(
-			&developer.ID,
-			&developer.Name,
-			&developer.Role,
-			&developer.LatestPerformanceScore,
-			&developer.TeamID,
-			&developer.ArchivedAt,
-			&developer.CreatedAt,
-			&developer.UpdatedAt,
-		)
-	} else {
-		scanErr = database.DB.QueryRow(query, developerUUID).Scan(
-			&developer.ID,
-			&developer.Name,
-			&developer.Role,
-			&developer.LatestPerformanceScore,
-			&developer.TeamID,
-			&developer.ArchivedAt,
-			&developer.CreatedAt,
-			&developer.UpdatedAt,
-		)
-	}
-
-	if scanErr == sql.ErrNoRows {
-		return c.Status(404).JSON(fiber.Map{
-			"error":   true,
-			"message": "Desenvolvedor não encontrado",
-		})
+	developer, scanErr := repos(c).Developers().SetArchived(developerUUID, archivedAt, user.UserID)
+	if scanErr == repository.ErrNotFound {
+		return apierror.ErrDeveloperNotFound
 	}
 	if scanErr != nil {
-		log.Printf("Error archiving/restoring developer: %v", scanErr)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao arquivar/restaurar desenvolvedor",
-		})
+		logging.From(c).Error("Error archiving/restoring developer", "error", scanErr)
+		return apierror.ErrInternal
 	}
 
-	action := "restaurado"
+	message := "messages.developer_restored"
+	auditAction := "restore"
 	if req.Archive {
-		action = "arquivado"
+		message = "messages.developer_archived"
+		auditAction = "archive"
 	}
 
+	recordAudit(c, auditAction, "developers", developerUUID, before, auditSnapshot(repos(c), "developers", developerUUID))
+
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": fmt.Sprintf("Desenvolvedor %s com sucesso", action),
+		"message": i18n.T(c, message),
 		"data":    developer,
 	})
 }
 
 // GetDevelopersByTeam retorna desenvolvedores de um time específico
 func GetDevelopersByTeam(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
 	teamID := c.Params("teamId")
 	teamUUID, err := uuid.Parse(teamID)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID do time inválido",
-		})
+		return apierror.InvalidID("teamId")
 	}
 
-	// Verificar se deve incluir arquivados
-	includeArchived := c.Query("includeArchived", "false")
-
-	query := `
-		SELECT id, name, role, latest_performance_score, team_id, archived_at, created_at, updated_at 
-		FROM developers 
-		WHERE team_id = $1
-	`
+	filter := repository.DeveloperFilter{TeamID: &teamUUID}
 
-	if includeArchived != "true" {
-		query += " AND archived_at IS NULL"
+	// Verificar se deve incluir arquivados
+	if c.Query("includeArchived", "false") == "true" {
+		filter.Archived = repository.IncludeArchived
 	}
 
-	query += " ORDER BY created_at DESC"
+	// Managers e usuários só veem os desenvolvedores da sua empresa
+	if user.Role != "admin" {
+		if user.CompanyID == nil {
+			return apierror.ErrCompanyMembershipRequired
+		}
+		filter.CompanyID = user.CompanyID
+	}
 
-	rows, err := database.DB.Query(query, teamUUID)
+	developers, err := repos(c).Developers().List(filter)
 	if err != nil {
-		log.Printf("Error querying developers by team: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar desenvolvedores do time",
-		})
-	}
-	defer rows.Close()
-
-	var developers []models.Developer
-	for rows.Next() {
-		var developer models.Developer
-		err := rows.Scan(
-			&developer.ID,
-			&developer.Name,
-			&developer.Role,
-			&developer.LatestPerformanceScore,
-			&developer.TeamID,
-			&developer.ArchivedAt,
-			&developer.CreatedAt,
-			&developer.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning developer: %v", err)
-			continue
-		}
-		developers = append(developers, developer)
+		logging.From(c).Error("Error querying developers by team", "error", err)
+		return apierror.ErrInternal
 	}
 
 	return c.JSON(fiber.Map{
@@ -558,111 +359,46 @@ func DeleteDeveloper(c *fiber.Ctx) error {
 	id := c.Params("id")
 	developerUUID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID inválido",
-		})
+		return apierror.InvalidID("id")
 	}
 
 	// Obter usuário atual das claims do JWT
 	user := c.Locals("user").(*middleware.JWTClaims)
 
-	var existingDeveloper models.Developer
-	checkQuery := `
-		SELECT id, name, role, latest_performance_score, team_id, company_id, archived_at, created_at, updated_at 
-		FROM developers 
-		WHERE id = $1
-	`
-
-	err = database.DB.QueryRow(checkQuery, developerUUID).Scan(
-		&existingDeveloper.ID,
-		&existingDeveloper.Name,
-		&existingDeveloper.Role,
-		&existingDeveloper.LatestPerformanceScore,
-		&existingDeveloper.TeamID,
-		&existingDeveloper.CompanyID,
-		&existingDeveloper.ArchivedAt,
-		&existingDeveloper.CreatedAt,
-		&existingDeveloper.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return c.Status(404).JSON(fiber.Map{
-			"error":   true,
-			"message": "Desenvolvedor não encontrado",
-		})
+	existingDeveloper, err := repos(c).Developers().FindByID(developerUUID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrDeveloperNotFound
 	}
 	if err != nil {
-		log.Printf("Error checking developer existence: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao verificar desenvolvedor",
-		})
+		logging.From(c).Error("Error checking developer existence", "error", err)
+		return apierror.ErrInternal
 	}
 
 	// Verificar permissões de exclusão para managers
 	if user.Role != "admin" {
 		// Managers só podem excluir desenvolvedores da sua própria empresa
 		if user.CompanyID == nil || existingDeveloper.CompanyID == nil || *user.CompanyID != *existingDeveloper.CompanyID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   true,
-				"message": "Sem permissão para excluir este desenvolvedor",
-			})
+			return apierror.ErrDeveloperDeleteForbidden
 		}
 	}
 
-	// Inicia uma transação para garantir consistência
-	tx, err := database.DB.Begin()
-	if err != nil {
-		log.Printf("Error starting transaction: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro interno do servidor",
-		})
-	}
-	defer tx.Rollback()
+	before := auditSnapshot(repos(c), "developers", developerUUID)
 
-	// Primeiro, exclui todos os relatórios de performance do desenvolvedor
-	_, err = tx.Exec("DELETE FROM performance_reports WHERE developer_id = $1", developerUUID)
-	if err != nil {
-		log.Printf("Error deleting performance reports: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao excluir relatórios de performance",
-		})
+	// Exclui o desenvolvedor junto com seus relatórios de performance
+	err = repos(c).Developers().Delete(developerUUID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrDeveloperNotFound
 	}
-
-	// Agora exclui o desenvolvedor
-	result, err := tx.Exec("DELETE FROM developers WHERE id = $1", developerUUID)
 	if err != nil {
-		log.Printf("Error deleting developer: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao excluir desenvolvedor",
-		})
-	}
-
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return c.Status(404).JSON(fiber.Map{
-			"error":   true,
-			"message": "Desenvolvedor não encontrado",
-		})
+		logging.From(c).Error("Error deleting developer", "error", err)
+		return apierror.ErrInternal
 	}
 
-	// Confirma a transação
-	err = tx.Commit()
-	if err != nil {
-		log.Printf("Error committing transaction: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao confirmar exclusão",
-		})
-	}
+	recordAudit(c, "delete", "developers", developerUUID, before, nil)
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Desenvolvedor excluído com sucesso",
+		"message": i18n.T(c, "messages.developer_deleted"),
 		"data": fiber.Map{
 			"deletedDeveloper": existingDeveloper,
 		},
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+)
+
+func TestUpdateDeveloper(t *testing.T) {
+	provider := repository.NewMemoryProvider()
+	store := provider.Store(nil)
+
+	alpha, beta := uuid.New(), uuid.New()
+	alphaTeam := seedTeam(t, store, alpha, nil)
+	betaTeam := seedTeam(t, store, beta, nil)
+
+	users := map[string]*models.User{
+		"alpha": {Email: "alpha@example.com", Name: "Alpha", Role: "user", CompanyID: &alpha, IsActive: true},
+		"beta":  {Email: "beta@example.com", Name: "Beta", Role: "user", CompanyID: &beta, IsActive: true},
+		"admin": {Email: "admin@example.com", Name: "Admin", Role: "admin", IsActive: true},
+	}
+	for _, user := range users {
+		if err := store.Users().Create(user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	developer := models.Developer{Name: "Dev", Role: "Engineer", CompanyID: &alpha}
+	if err := store.Developers().Create(&developer); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &alpha}
+	outsider := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &beta}
+	path := "/developers/" + developer.ID.String()
+
+	cases := []struct {
+		name   string
+		claims *middleware.JWTClaims
+		body   map[string]interface{}
+		status int
+		code   string
+	}{
+		{"desenvolvedor de outra empresa", outsider, map[string]interface{}{"name": "Outro"}, fiber.StatusNotFound, "DEVELOPER_NOT_FOUND"},
+		{"time de outra empresa", manager, map[string]interface{}{"teamId": betaTeam.ID}, fiber.StatusBadRequest, "TEAM_NOT_IN_COMPANY"},
+		{"usuário de outra empresa", manager, map[string]interface{}{"userId": users["beta"].ID}, fiber.StatusBadRequest, "LINKED_USER_NOT_IN_COMPANY"},
+		{"sem campos", manager, map[string]interface{}{}, fiber.StatusBadRequest, "NOTHING_TO_UPDATE"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, raw := serve(t, provider, tc.claims, "PUT", "/developers/:id", path, tc.body, UpdateDeveloper)
+			if code := errorCode(t, raw); status != tc.status || code != tc.code {
+				t.Fatalf("obtido %d %s; esperado %d %s", status, code, tc.status, tc.code)
+			}
+		})
+	}
+
+	updates := []struct {
+		name string
+		body map[string]interface{}
+		want func(models.Developer) bool
+	}{
+		{"nome e time da empresa", map[string]interface{}{"name": "Ana", "teamId": alphaTeam.ID}, func(d models.Developer) bool {
+			return d.Name == "Ana" && d.Role == "Engineer" && d.TeamID != nil && *d.TeamID == alphaTeam.ID
+		}},
+		{"usuário da empresa", map[string]interface{}{"userId": users["alpha"].ID}, func(d models.Developer) bool {
+			return d.UserID != nil && *d.UserID == users["alpha"].ID && d.Name == "Ana"
+		}},
+		{"admins podem ser vinculados", map[string]interface{}{"userId": users["admin"].ID}, func(d models.Developer) bool {
+			return d.UserID != nil && *d.UserID == users["admin"].ID
+		}},
+	}
+
+	for _, tc := range updates {
+		t.Run(tc.name, func(t *testing.T) {
+			status, raw := serve(t, provider, manager, "PUT", "/developers/:id", path, tc.body, UpdateDeveloper)
+			if status != fiber.StatusOK {
+				t.Fatalf("status %d\n%s", status, raw)
+			}
+
+			var resp struct {
+				Data models.Developer `json:"data"`
+			}
+			if err := json.Unmarshal(raw, &resp); err != nil {
+				t.Fatal(err)
+			}
+			stored, err := store.Developers().FindByID(developer.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, got := range []models.Developer{resp.Data, *stored} {
+				if !tc.want(got) || got.UpdatedBy == nil || *got.UpdatedBy != manager.UserID {
+					t.Errorf("desenvolvedor %+v não reflete a alteração feita por %s", got, manager.UserID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"tivix-performance-tracker-backend/database"
+)
+
+// pqUniqueViolation é o SQLSTATE de violação de restrição UNIQUE no PostgreSQL
+const pqUniqueViolation = "23505"
+
+// inList monta "($first, $first+1, ...)" para "coluna IN ...", no lugar de ANY($n) com pq.Array,
+// que só existe no PostgreSQL. Uma lista vazia vira (NULL), que não casa com nenhuma linha.
+func inList[T any](first int, values []T) (string, []interface{}) {
+	if len(values) == 0 {
+		return "(NULL)", nil
+	}
+
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		placeholders[i] = fmt.Sprintf("$%d", first+i)
+		args[i] = value
+	}
+	return "(" + strings.Join(placeholders, ", ") + ")", args
+}
+
+// arrayContains retorna a condição SQL "o array em column contém o valor do placeholder".
+// No SQLite os arrays são gravados como array JSON (models.Array) e os elementos são percorridos
+// por json_each; o DEFAULT '{}' das colunas é um objeto JSON vazio, sem elementos. O valor precisa
+// coincidir com um elemento inteiro, como em = ANY.
+func arrayContains(column, placeholder string) string {
+	if database.IsSQLite() {
+		return "EXISTS (SELECT 1 FROM json_each(" + column + ") WHERE value = " + placeholder + ")"
+	}
+	return placeholder + " = ANY(" + column + ")"
+}
+
+// isUniqueViolation indica se err é uma violação de restrição UNIQUE (ou de chave primária),
+// pelo código de erro do driver em uso, e não pela mensagem, que muda entre os bancos
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqUniqueViolation
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"path/filepath"
+	"testing"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/models"
+)
+
+func TestArrayContainsOnSQLite(t *testing.T) {
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "dialect.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+
+	query := "SELECT " + arrayContains("a.elements", "$1") + " FROM (SELECT $2 AS elements) a"
+
+	cases := []struct {
+		array interface{}
+		value string
+		want  bool
+	}{
+		{models.Array[string]{"tech"}, "tech", true},
+		{models.Array[string]{"tech"}, "ec", false},
+		{models.Array[string]{"backend", "tech lead"}, "tech", false},
+		{models.Array[string]{"backend", "tech lead"}, "tech lead", true},
+		{models.Array[string]{"a,b", "c"}, "b", false},
+		{models.Array[string]{"a,b", "c"}, "a,b", true},
+		{models.Array[string]{`aspas "duplas"`}, `aspas "duplas"`, true},
+		{models.Array[string]{}, "", false},
+		// DEFAULT das colunas nas linhas gravadas sem o array
+		{"{}", "", false},
+	}
+
+	for _, tc := range cases {
+		var got bool
+		if err := db.Get(&got, query, tc.value, tc.array); err != nil {
+			t.Fatalf("%v contém %q: %v", tc.array, tc.value, err)
+		}
+		if got != tc.want {
+			t.Errorf("%v contém %q: %v; esperado %v", tc.array, tc.value, got, tc.want)
+		}
+	}
+}
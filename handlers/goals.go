@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+)
+
+var validGoalStatuses = map[string]bool{
+	"open":        true,
+	"in_progress": true,
+	"completed":   true,
+	"cancelled":   true,
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// developerCompanyForUser retorna a empresa do desenvolvedor se o usuário tiver acesso a ele
+func developerCompanyForUser(store repository.Store, user *middleware.JWTClaims, developerID uuid.UUID) (*uuid.UUID, error) {
+	developer, err := store.Developers().FindByID(developerID)
+	if err != nil {
+		return nil, err
+	}
+	companyID := developer.CompanyID
+
+	if user.Role != "admin" {
+		if user.CompanyID == nil || companyID == nil || *user.CompanyID != *companyID {
+			return nil, sql.ErrNoRows
+		}
+	}
+
+	return companyID, nil
+}
+
+// findGoalForUser busca uma meta garantindo que pertence à empresa do usuário
+func findGoalForUser(store repository.Store, user *middleware.JWTClaims, goalID uuid.UUID) (*models.Goal, error) {
+	goal, err := store.Goals().FindByID(goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != "admin" {
+		if user.CompanyID == nil || goal.CompanyID == nil || *user.CompanyID != *goal.CompanyID {
+			return nil, sql.ErrNoRows
+		}
+	}
+
+	return goal, nil
+}
+
+// userInCompany verifica se o usuário pertence à empresa informada (admins são aceitos em qualquer empresa)
+func userInCompany(store repository.Store, userID uuid.UUID, companyID *uuid.UUID) bool {
+	user, err := store.Users().FindByID(userID)
+	if err != nil {
+		return false
+	}
+	return user.Role == "admin" || (companyID != nil && user.CompanyID != nil && *user.CompanyID == *companyID)
+}
+
+// newGoal monta a meta do desenvolvedor a partir da requisição
+func newGoal(developerID uuid.UUID, companyID *uuid.UUID, reportID *uuid.UUID, ownerID *uuid.UUID, req models.CreateGoalRequest) (*models.Goal, error) {
+	dueDate, err := parseOptionalDate(req.DueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Goal{
+		DeveloperID: developerID,
+		CompanyID:   companyID,
+		ReportID:    reportID,
+		OwnerID:     ownerID,
+		Title:       req.Title,
+		Description: req.Description,
+		Categories:  models.Array[string](req.Categories),
+		DueDate:     dueDate,
+	}, nil
+}
+
+// GetGoalsByDeveloper retorna as metas de um desenvolvedor, opcionalmente filtradas por status
+func GetGoalsByDeveloper(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+
+	if _, err := developerCompanyForUser(repos(c), user, developerUUID); err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	var statuses []string
+	if status := c.Query("status"); status != "" {
+		statuses = strings.Split(status, ",")
+		for _, s := range statuses {
+			if !validGoalStatuses[s] {
+				return apierror.InvalidField("status", "invalid", "")
+			}
+		}
+	}
+
+	goals, err := repos(c).Goals().ListByDeveloper(developerUUID, statuses)
+	if err != nil {
+		logging.From(c).Error("Error querying goals", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    goals,
+	})
+}
+
+// GetGoalByID retorna uma meta específica com seu histórico de progresso
+func GetGoalByID(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	goalUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	goal, err := findGoalForUser(repos(c), user, goalUUID)
+	if err == sql.ErrNoRows {
+		return apierror.ErrGoalNotFound
+	}
+	if err != nil {
+		logging.From(c).Error("Error querying goal", "error", err)
+		return apierror.ErrInternal
+	}
+
+	goals := []models.Goal{*goal}
+	if err := repos(c).Goals().LoadProgressUpdates(goals); err != nil {
+		logging.From(c).Error("Error loading goal progress updates", "error", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    goals[0],
+	})
+}
+
+// CreateGoal cria uma nova meta para um desenvolvedor
+func CreateGoal(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+
+	var req models.CreateGoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	companyID, err := developerCompanyForUser(repos(c), user, developerUUID)
+	if err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	if req.ReportID != nil {
+		report, err := repos(c).Reports().FindByID(*req.ReportID, nil)
+		if err != nil || report.DeveloperID != developerUUID {
+			return apierror.ErrReportNotForDeveloper
+		}
+	}
+
+	ownerID := &user.UserID
+	if req.OwnerID != nil {
+		if !userInCompany(repos(c), *req.OwnerID, companyID) {
+			return apierror.ErrOwnerNotInCompany
+		}
+		ownerID = req.OwnerID
+	}
+
+	goal, err := newGoal(developerUUID, companyID, req.ReportID, ownerID, req)
+	if err != nil {
+		return apierror.InvalidField("dueDate", "date", "")
+	}
+
+	if err := repos(c).Goals().Create(goal); err != nil {
+		logging.From(c).Error("Error creating goal", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "create", "goals", goal.ID, nil, auditSnapshot(repos(c), "goals", goal.ID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    goal,
+	})
+}
+
+// UpdateGoal atualiza os dados descritivos de uma meta
+func UpdateGoal(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	goalUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	var req models.UpdateGoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	goal, err := findGoalForUser(repos(c), user, goalUUID)
+	if err != nil {
+		return apierror.ErrGoalNotFound
+	}
+
+	if req.Title == nil && req.Description == nil && req.OwnerID == nil && req.Categories == nil && req.DueDate == nil {
+		return apierror.ErrNothingToUpdate
+	}
+
+	if req.Title != nil {
+		goal.Title = *req.Title
+	}
+	if req.Description != nil {
+		goal.Description = *req.Description
+	}
+	if req.OwnerID != nil {
+		if !userInCompany(repos(c), *req.OwnerID, goal.CompanyID) {
+			return apierror.ErrOwnerNotInCompany
+		}
+		goal.OwnerID = req.OwnerID
+	}
+	if req.Categories != nil {
+		goal.Categories = models.Array[string](req.Categories)
+	}
+	if req.DueDate != nil {
+		dueDate, err := parseOptionalDate(req.DueDate)
+		if err != nil {
+			return apierror.InvalidField("dueDate", "date", "")
+		}
+		goal.DueDate = dueDate
+	}
+
+	before := auditSnapshot(repos(c), "goals", goalUUID)
+
+	if err := repos(c).Goals().Update(goal); err != nil {
+		logging.From(c).Error("Error updating goal", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "update", "goals", goalUUID, before, auditSnapshot(repos(c), "goals", goalUUID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    goal,
+	})
+}
+
+// AddGoalProgress registra uma atualização de progresso e de status em uma meta
+func AddGoalProgress(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	goalUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	var req models.CreateGoalProgressRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	goal, err := findGoalForUser(repos(c), user, goalUUID)
+	if err != nil {
+		return apierror.ErrGoalNotFound
+	}
+
+	status := req.Status
+	if status == "" {
+		switch {
+		case req.Progress >= 100:
+			status = "completed"
+		case req.Progress > 0:
+			status = "in_progress"
+		default:
+			status = goal.Status
+		}
+	}
+
+	before := auditSnapshot(repos(c), "goals", goalUUID)
+
+	update := models.GoalProgressUpdate{
+		AuthorID: &user.UserID,
+		Progress: req.Progress,
+		Status:   status,
+		Note:     req.Note,
+	}
+	if err := repos(c).Goals().AddProgress(goal, &update); err != nil {
+		logging.From(c).Error("Error adding goal progress", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "progress", "goals", goalUUID, before, auditSnapshot(repos(c), "goals", goalUUID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"goal":   goal,
+			"update": update,
+		},
+	})
+}
+
+// DeleteGoal exclui uma meta e seu histórico de progresso
+func DeleteGoal(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	goalUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	if _, err := findGoalForUser(repos(c), user, goalUUID); err != nil {
+		return apierror.ErrGoalNotFound
+	}
+
+	before := auditSnapshot(repos(c), "goals", goalUUID)
+
+	if err := repos(c).Goals().Delete(goalUUID); err != nil {
+		logging.From(c).Error("Error deleting goal", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "delete", "goals", goalUUID, before, nil)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "messages.goal_deleted"),
+	})
+}
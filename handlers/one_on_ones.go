@@ -0,0 +1,446 @@
+package handlers
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+)
+
+// parseOptionalDate converte uma data no formato YYYY-MM-DD, tratando string vazia como nula
+func parseOptionalDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// newActionItem monta o item de ação a partir da requisição
+func newActionItem(meetingID uuid.UUID, req models.CreateOneOnOneActionItemRequest) (*models.OneOnOneActionItem, error) {
+	dueDate, err := parseOptionalDate(req.DueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OneOnOneActionItem{
+		OneOnOneID:  meetingID,
+		Description: req.Description,
+		OwnerID:     req.OwnerID,
+		DueDate:     dueDate,
+	}, nil
+}
+
+// oneOnOneFilterForUser restringe usuários comuns às reuniões compartilhadas do desenvolvedor vinculado a eles
+func oneOnOneFilterForUser(user *middleware.JWTClaims, filter repository.OneOnOneFilter) repository.OneOnOneFilter {
+	if user.Role != "admin" && user.Role != "manager" {
+		filter.SharedWith = &user.UserID
+	}
+	return filter
+}
+
+// oneOnOnesForMonth retorna as reuniões 1:1 de um desenvolvedor registradas no mês (YYYY-MM) visíveis ao usuário
+func oneOnOnesForMonth(store repository.Store, user *middleware.JWTClaims, developerID uuid.UUID, month string) ([]models.OneOnOne, error) {
+	return store.OneOnOnes().List(oneOnOneFilterForUser(user, repository.OneOnOneFilter{DeveloperID: &developerID, Month: month}))
+}
+
+// findOneOnOneForUser busca uma reunião 1:1 respeitando empresa e visibilidade
+func findOneOnOneForUser(store repository.Store, user *middleware.JWTClaims, meetingID uuid.UUID) (*models.OneOnOne, error) {
+	filter := repository.OneOnOneFilter{ID: &meetingID}
+	if user.Role != "admin" {
+		if user.CompanyID == nil {
+			return nil, sql.ErrNoRows
+		}
+		filter.CompanyID = user.CompanyID
+	}
+
+	meetings, err := store.OneOnOnes().List(oneOnOneFilterForUser(user, filter))
+	if err != nil {
+		return nil, err
+	}
+	if len(meetings) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &meetings[0], nil
+}
+
+// GetOneOnOnesByDeveloper retorna as reuniões 1:1 de um desenvolvedor, com filtro opcional por mês
+func GetOneOnOnesByDeveloper(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+
+	if _, err := developerCompanyForUser(repos(c), user, developerUUID); err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	filter := repository.OneOnOneFilter{DeveloperID: &developerUUID, Month: c.Query("month")}
+	meetings, err := repos(c).OneOnOnes().List(oneOnOneFilterForUser(user, filter))
+	if err != nil {
+		logging.From(c).Error("Error querying one-on-ones", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    meetings,
+	})
+}
+
+// GetOneOnOneByID retorna uma reunião 1:1 específica com seus itens de ação
+func GetOneOnOneByID(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	meetingUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	meeting, err := findOneOnOneForUser(repos(c), user, meetingUUID)
+	if err == sql.ErrNoRows {
+		return apierror.ErrMeetingNotFound
+	}
+	if err != nil {
+		logging.From(c).Error("Error querying one-on-one", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    meeting,
+	})
+}
+
+// CreateOneOnOne registra uma nova reunião 1:1 para um desenvolvedor
+func CreateOneOnOne(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+
+	var req models.CreateOneOnOneRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	companyID, err := developerCompanyForUser(repos(c), user, developerUUID)
+	if err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	for _, item := range req.ActionItems {
+		if item.OwnerID != nil && !userInCompany(repos(c), *item.OwnerID, companyID) {
+			return apierror.ErrOwnerNotInCompany
+		}
+	}
+
+	if req.Visibility == "" {
+		req.Visibility = "private"
+	}
+
+	meetingDate, err := time.Parse("2006-01-02", req.MeetingDate)
+	if err != nil {
+		return apierror.InvalidField("meetingDate", "date", "")
+	}
+
+	meeting := models.OneOnOne{
+		DeveloperID: developerUUID,
+		CompanyID:   companyID,
+		AuthorID:    &user.UserID,
+		MeetingDate: meetingDate,
+		Agenda:      models.Array[string](req.Agenda),
+		Notes:       req.Notes,
+		Visibility:  req.Visibility,
+	}
+	for _, itemReq := range req.ActionItems {
+		item, err := newActionItem(uuid.Nil, itemReq)
+		if err != nil {
+			return apierror.InvalidField("dueDate", "date", "")
+		}
+		meeting.ActionItems = append(meeting.ActionItems, *item)
+	}
+
+	if err := repos(c).OneOnOnes().Create(&meeting); err != nil {
+		logging.From(c).Error("Error creating one-on-one", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "create", "one_on_ones", meeting.ID, nil, auditSnapshot(repos(c), "one_on_ones", meeting.ID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    meeting,
+	})
+}
+
+// UpdateOneOnOne atualiza data, pauta, anotações ou visibilidade de uma reunião 1:1
+func UpdateOneOnOne(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	meetingUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	var req models.UpdateOneOnOneRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	meeting, err := findOneOnOneForUser(repos(c), user, meetingUUID)
+	if err != nil {
+		return apierror.ErrMeetingNotFound
+	}
+
+	if req.MeetingDate == nil && req.Agenda == nil && req.Notes == nil && req.Visibility == nil {
+		return apierror.ErrNothingToUpdate
+	}
+
+	if req.MeetingDate != nil {
+		meetingDate, err := time.Parse("2006-01-02", *req.MeetingDate)
+		if err != nil {
+			return apierror.InvalidField("meetingDate", "date", "")
+		}
+		meeting.MeetingDate = meetingDate
+	}
+	if req.Agenda != nil {
+		meeting.Agenda = models.Array[string](req.Agenda)
+	}
+	if req.Notes != nil {
+		meeting.Notes = *req.Notes
+	}
+	if req.Visibility != nil {
+		meeting.Visibility = *req.Visibility
+	}
+
+	before := auditSnapshot(repos(c), "one_on_ones", meetingUUID)
+
+	if err := repos(c).OneOnOnes().Update(meeting); err != nil {
+		logging.From(c).Error("Error updating one-on-one", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "update", "one_on_ones", meetingUUID, before, auditSnapshot(repos(c), "one_on_ones", meetingUUID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    meeting,
+	})
+}
+
+// DeleteOneOnOne exclui uma reunião 1:1 e seus itens de ação
+func DeleteOneOnOne(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	meetingUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	if _, err := findOneOnOneForUser(repos(c), user, meetingUUID); err != nil {
+		return apierror.ErrMeetingNotFound
+	}
+
+	before := auditSnapshot(repos(c), "one_on_ones", meetingUUID)
+
+	if err := repos(c).OneOnOnes().Delete(meetingUUID); err != nil {
+		logging.From(c).Error("Error deleting one-on-one", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "delete", "one_on_ones", meetingUUID, before, nil)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "messages.meeting_deleted"),
+	})
+}
+
+// CreateOneOnOneActionItem adiciona um item de ação a uma reunião 1:1
+func CreateOneOnOneActionItem(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	meetingUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	var req models.CreateOneOnOneActionItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	meeting, err := findOneOnOneForUser(repos(c), user, meetingUUID)
+	if err != nil {
+		return apierror.ErrMeetingNotFound
+	}
+
+	if req.OwnerID != nil && !userInCompany(repos(c), *req.OwnerID, meeting.CompanyID) {
+		return apierror.ErrOwnerNotInCompany
+	}
+
+	item, err := newActionItem(meetingUUID, req)
+	if err != nil {
+		return apierror.InvalidField("dueDate", "date", "")
+	}
+
+	if err := repos(c).OneOnOnes().CreateActionItem(item); err != nil {
+		logging.From(c).Error("Error creating action item", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "create", "one_on_one_action_items", item.ID, nil, auditSnapshot(repos(c), "one_on_one_action_items", item.ID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    item,
+	})
+}
+
+// UpdateOneOnOneActionItem atualiza um item de ação; o responsável pelo item pode marcá-lo como concluído
+func UpdateOneOnOneActionItem(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	meetingUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+	itemUUID, err := uuid.Parse(c.Params("itemId"))
+	if err != nil {
+		return apierror.InvalidID("actionItemId")
+	}
+
+	var req models.UpdateOneOnOneActionItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	meeting, err := findOneOnOneForUser(repos(c), user, meetingUUID)
+	if err != nil {
+		return apierror.ErrMeetingNotFound
+	}
+
+	var item *models.OneOnOneActionItem
+	for i := range meeting.ActionItems {
+		if meeting.ActionItems[i].ID == itemUUID {
+			item = &meeting.ActionItems[i]
+			break
+		}
+	}
+	if item == nil {
+		return apierror.ErrActionItemNotFound
+	}
+
+	isManager := user.Role == "admin" || user.Role == "manager"
+	isOwner := item.OwnerID != nil && *item.OwnerID == user.UserID
+	if !isManager {
+		if !isOwner || req.Description != nil || req.OwnerID != nil || req.DueDate != nil {
+			return apierror.ErrActionItemOwnerRequired
+		}
+	}
+
+	if req.Description == nil && req.OwnerID == nil && req.DueDate == nil && req.Completed == nil {
+		return apierror.ErrNothingToUpdate
+	}
+
+	if req.Description != nil {
+		item.Description = *req.Description
+	}
+	if req.OwnerID != nil {
+		if !userInCompany(repos(c), *req.OwnerID, meeting.CompanyID) {
+			return apierror.ErrOwnerNotInCompany
+		}
+		item.OwnerID = req.OwnerID
+	}
+	if req.DueDate != nil {
+		dueDate, err := parseOptionalDate(req.DueDate)
+		if err != nil {
+			return apierror.InvalidField("dueDate", "date", "")
+		}
+		item.DueDate = dueDate
+	}
+	// Concluir de novo um item já concluído mantém a data e o autor da primeira conclusão
+	if req.Completed != nil {
+		if !*req.Completed {
+			item.CompletedAt, item.CompletedBy = nil, nil
+		} else if item.CompletedAt == nil {
+			now := time.Now()
+			item.CompletedAt, item.CompletedBy = &now, &user.UserID
+		}
+	}
+
+	before := auditSnapshot(repos(c), "one_on_one_action_items", itemUUID)
+
+	if err := repos(c).OneOnOnes().UpdateActionItem(item); err != nil {
+		logging.From(c).Error("Error updating action item", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "update", "one_on_one_action_items", itemUUID, before, auditSnapshot(repos(c), "one_on_one_action_items", itemUUID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    item,
+	})
+}
+
+// DeleteOneOnOneActionItem remove um item de ação de uma reunião 1:1
+func DeleteOneOnOneActionItem(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	meetingUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+	itemUUID, err := uuid.Parse(c.Params("itemId"))
+	if err != nil {
+		return apierror.InvalidID("actionItemId")
+	}
+
+	if _, err := findOneOnOneForUser(repos(c), user, meetingUUID); err != nil {
+		return apierror.ErrMeetingNotFound
+	}
+
+	before := auditSnapshot(repos(c), "one_on_one_action_items", itemUUID)
+
+	err = repos(c).OneOnOnes().DeleteActionItem(meetingUUID, itemUUID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrActionItemNotFound
+	}
+	if err != nil {
+		logging.From(c).Error("Error deleting action item", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "delete", "one_on_one_action_items", itemUUID, before, nil)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "messages.action_item_deleted"),
+	})
+}
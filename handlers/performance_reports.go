@@ -1,88 +1,34 @@
 package handlers
 
 import (
-	"database/sql"
-	"log"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
-	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/logging"
 	"tivix-performance-tracker-backend/middleware"
 	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
 )
 
 func GetAllPerformanceReports(c *fiber.Ctx) error {
 	user := c.Locals("user").(*middleware.JWTClaims)
 
-	var query string
-	var args []interface{}
-
-	if user.Role == "admin" {
-		query = `
-			SELECT pr.id, pr.developer_id, pr.month, pr.question_scores, pr.category_scores, 
-			       pr.weighted_average_score, pr.highlights, pr.points_to_develop, 
-			       pr.created_at, pr.updated_at
-			FROM performance_reports pr
-			ORDER BY pr.month DESC, pr.created_at DESC
-		`
-	} else {
+	var companyID *uuid.UUID
+	if user.Role != "admin" {
 		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   true,
-				"message": "Usuário deve estar associado a uma empresa",
-			})
+			return apierror.ErrCompanyMembershipRequired
 		}
-
-		query = `
-			SELECT pr.id, pr.developer_id, pr.month, pr.question_scores, pr.category_scores, 
-			       pr.weighted_average_score, pr.highlights, pr.points_to_develop, 
-			       pr.created_at, pr.updated_at
-			FROM performance_reports pr
-			INNER JOIN developers d ON pr.developer_id = d.id
-			WHERE d.company_id = $1
-			ORDER BY pr.month DESC, pr.created_at DESC
-		`
-		args = append(args, *user.CompanyID)
+		companyID = user.CompanyID
 	}
 
-	var rows *sql.Rows
-	var err error
-
-	if len(args) > 0 {
-		rows, err = database.DB.Query(query, args...)
-	} else {
-		rows, err = database.DB.Query(query)
-	}
+	reports, err := repos(c).Reports().List(repository.ReportFilter{CompanyID: companyID})
 	if err != nil {
-		log.Printf("Error querying performance reports: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar relatórios de performance",
-		})
-	}
-	defer rows.Close()
-
-	var reports []models.PerformanceReport
-	for rows.Next() {
-		var report models.PerformanceReport
-		err := rows.Scan(
-			&report.ID,
-			&report.DeveloperID,
-			&report.Month,
-			&report.QuestionScores,
-			&report.CategoryScores,
-			&report.WeightedAverageScore,
-			&report.Highlights,
-			&report.PointsToDevelop,
-			&report.CreatedAt,
-			&report.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning performance report: %v", err)
-			continue
-		}
-		reports = append(reports, report)
+		logging.From(c).Error("Error querying performance reports", "error", err)
+		return apierror.ErrInternal
 	}
 
 	return c.JSON(fiber.Map{
@@ -96,77 +42,21 @@ func GetPerformanceReportsByDeveloper(c *fiber.Ctx) error {
 	developerID := c.Params("developerId")
 	developerUUID, err := uuid.Parse(developerID)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID do desenvolvedor inválido",
-		})
+		return apierror.InvalidID("developerId")
 	}
 
-	var accessQuery string
-	var accessArgs []interface{}
-
-	if user.Role == "admin" {
-		accessQuery = "SELECT EXISTS(SELECT 1 FROM developers WHERE id = $1)"
-		accessArgs = []interface{}{developerUUID}
-	} else {
-		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   true,
-				"message": "Usuário deve estar associado a uma empresa",
-			})
-		}
-		accessQuery = "SELECT EXISTS(SELECT 1 FROM developers WHERE id = $1 AND company_id = $2)"
-		accessArgs = []interface{}{developerUUID, *user.CompanyID}
+	if user.Role != "admin" && user.CompanyID == nil {
+		return apierror.ErrCompanyMembershipRequired
 	}
 
-	var hasAccess bool
-	err = database.DB.QueryRow(accessQuery, accessArgs...).Scan(&hasAccess)
-	if err != nil || !hasAccess {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error":   true,
-			"message": "Acesso negado ao desenvolvedor",
-		})
+	if _, err := findDeveloperForUser(c, user, developerUUID); err != nil {
+		return apierror.ErrDeveloperAccessDenied
 	}
 
-	query := `
-		SELECT id, developer_id, month, question_scores, category_scores, 
-		       weighted_average_score, highlights, points_to_develop, 
-		       created_at, updated_at
-		FROM performance_reports 
-		WHERE developer_id = $1
-		ORDER BY month DESC, created_at DESC
-	`
-
-	rows, err := database.DB.Query(query, developerUUID)
+	reports, err := repos(c).Reports().List(repository.ReportFilter{DeveloperID: &developerUUID})
 	if err != nil {
-		log.Printf("Error querying performance reports by developer: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar relatórios do desenvolvedor",
-		})
-	}
-	defer rows.Close()
-
-	var reports []models.PerformanceReport
-	for rows.Next() {
-		var report models.PerformanceReport
-		err := rows.Scan(
-			&report.ID,
-			&report.DeveloperID,
-			&report.Month,
-			&report.QuestionScores,
-			&report.CategoryScores,
-			&report.WeightedAverageScore,
-			&report.Highlights,
-			&report.PointsToDevelop,
-			&report.CreatedAt,
-			&report.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning performance report: %v", err)
-			continue
-		}
-		reports = append(reports, report)
+		logging.From(c).Error("Error querying performance reports by developer", "error", err)
+		return apierror.ErrInternal
 	}
 
 	return c.JSON(fiber.Map{
@@ -180,62 +70,18 @@ func GetPerformanceReportsByMonth(c *fiber.Ctx) error {
 	month := c.Params("month")
 	user := c.Locals("user").(*middleware.JWTClaims)
 
-	var query string
-	var args []interface{}
-
-	if user.Role == "admin" {
-		query = `
-			SELECT pr.id, pr.developer_id, pr.month, pr.question_scores, pr.category_scores, 
-			       pr.weighted_average_score, pr.highlights, pr.points_to_develop, 
-			       pr.created_at, pr.updated_at
-			FROM performance_reports pr
-			WHERE pr.month = $1
-			ORDER BY pr.weighted_average_score DESC, pr.created_at DESC
-		`
-		args = []interface{}{month}
-	} else {
-		query = `
-			SELECT pr.id, pr.developer_id, pr.month, pr.question_scores, pr.category_scores, 
-			       pr.weighted_average_score, pr.highlights, pr.points_to_develop, 
-			       pr.created_at, pr.updated_at
-			FROM performance_reports pr
-			JOIN users d ON pr.developer_id = d.id
-			WHERE pr.month = $1 AND d.company_id = $2
-			ORDER BY pr.weighted_average_score DESC, pr.created_at DESC
-		`
-		args = []interface{}{month, user.CompanyID}
-	}
-
-	rows, err := database.DB.Query(query, args...)
-	if err != nil {
-		log.Printf("Error querying performance reports by month: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar relatórios do mês",
-		})
-	}
-	defer rows.Close()
-
-	var reports []models.PerformanceReport
-	for rows.Next() {
-		var report models.PerformanceReport
-		err := rows.Scan(
-			&report.ID,
-			&report.DeveloperID,
-			&report.Month,
-			&report.QuestionScores,
-			&report.CategoryScores,
-			&report.WeightedAverageScore,
-			&report.Highlights,
-			&report.PointsToDevelop,
-			&report.CreatedAt,
-			&report.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning performance report: %v", err)
-			continue
+	var companyID *uuid.UUID
+	if user.Role != "admin" {
+		if user.CompanyID == nil {
+			return apierror.ErrCompanyMembershipRequired
 		}
-		reports = append(reports, report)
+		companyID = user.CompanyID
+	}
+
+	reports, err := repos(c).Reports().ListByMonth(month, companyID)
+	if err != nil {
+		logging.From(c).Error("Error querying performance reports by month", "error", err)
+		return apierror.ErrInternal
 	}
 
 	return c.JSON(fiber.Map{
@@ -249,232 +95,182 @@ func GetPerformanceReportByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	reportUUID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID inválido",
-		})
+		return apierror.InvalidID("id")
 	}
 
-	var query string
-	var args []interface{}
-
-	if user.Role == "admin" {
-		query = `
-			SELECT id, developer_id, month, question_scores, category_scores, 
-			       weighted_average_score, highlights, points_to_develop, 
-			       created_at, updated_at
-			FROM performance_reports 
-			WHERE id = $1
-		`
-		args = []interface{}{reportUUID}
-	} else {
+	var companyID *uuid.UUID
+	if user.Role != "admin" {
 		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   true,
-				"message": "Usuário deve estar associado a uma empresa",
-			})
+			return apierror.ErrCompanyMembershipRequired
 		}
+		companyID = user.CompanyID
+	}
 
-		query = `
-			SELECT pr.id, pr.developer_id, pr.month, pr.question_scores, pr.category_scores, 
-			       pr.weighted_average_score, pr.highlights, pr.points_to_develop, 
-			       pr.created_at, pr.updated_at
-			FROM performance_reports pr
-			INNER JOIN developers d ON pr.developer_id = d.id
-			WHERE pr.id = $1 AND d.company_id = $2
-		`
-		args = []interface{}{reportUUID, *user.CompanyID}
-	}
-
-	var report models.PerformanceReport
-	err = database.DB.QueryRow(query, args...).Scan(
-		&report.ID,
-		&report.DeveloperID,
-		&report.Month,
-		&report.QuestionScores,
-		&report.CategoryScores,
-		&report.WeightedAverageScore,
-		&report.Highlights,
-		&report.PointsToDevelop,
-		&report.CreatedAt,
-		&report.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return c.Status(404).JSON(fiber.Map{
-			"error":   true,
-			"message": "Relatório não encontrado ou acesso negado",
-		})
+	report, err := repos(c).Reports().FindByID(reportUUID, companyID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrReportNotFound
 	}
 	if err != nil {
-		log.Printf("Error querying performance report: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar relatório",
-		})
+		logging.From(c).Error("Error querying performance report", "error", err)
+		return apierror.ErrInternal
+	}
+
+	// Metas definidas neste relatório e metas anteriores que seguiam em aberto
+	createdGoals, err := repos(c).Goals().ListByReport(report.ID)
+	if err != nil {
+		logging.From(c).Error("Error querying report goals", "error", err)
+		createdGoals = []models.Goal{}
+	}
+
+	openGoals, err := repos(c).Goals().ListOpenAtReport(*report)
+	if err != nil {
+		logging.From(c).Error("Error querying open goals for report", "error", err)
+		openGoals = []models.Goal{}
+	}
+
+	// Reuniões 1:1 registradas no mês do relatório
+	oneOnOnes, err := oneOnOnesForMonth(repos(c), user, report.DeveloperID, report.Month)
+	if err != nil {
+		logging.From(c).Error("Error querying one-on-ones for report", "error", err)
+		oneOnOnes = []models.OneOnOne{}
 	}
 
 	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    report,
+		"success":   true,
+		"data":      report,
+		"goals":     createdGoals,
+		"openGoals": openGoals,
+		"oneOnOnes": oneOnOnes,
 	})
 }
 
 func CreatePerformanceReport(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
 	var req models.CreatePerformanceReportRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	if req.DeveloperID == uuid.Nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID do desenvolvedor é obrigatório",
-		})
+		return apierror.Required("developerId")
 	}
 
 	if req.Month == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Mês é obrigatório",
-		})
+		return apierror.Required("month")
 	}
 
-	if req.WeightedAverageScore < 0 || req.WeightedAverageScore > 10 {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Pontuação deve estar entre 0 e 10",
-		})
+	if _, err := time.Parse("2006-01", req.Month); err != nil {
+		return apierror.InvalidField("month", "month", "")
 	}
 
-	var developerExists bool
-	err := database.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM developers WHERE id = $1)", req.DeveloperID).Scan(&developerExists)
-	if err != nil || !developerExists {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Desenvolvedor não encontrado",
-		})
+	for _, goal := range req.Goals {
+		if err := validate.Struct(&goal); err != nil {
+			return apierror.Validation(err)
+		}
 	}
 
-	var existingReportExists bool
-	err = database.DB.QueryRow(
-		"SELECT EXISTS(SELECT 1 FROM performance_reports WHERE developer_id = $1 AND month = $2)",
-		req.DeveloperID, req.Month,
-	).Scan(&existingReportExists)
+	developer, err := findDeveloperForUser(c, user, req.DeveloperID)
 	if err != nil {
-		log.Printf("Error checking existing report: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao verificar relatório existente",
-		})
+		return apierror.ErrDeveloperNotInCompany
 	}
-	if existingReportExists {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Já existe um relatório para este desenvolvedor neste mês",
-		})
+	developerCompanyID := developer.CompanyID
+
+	// As notas seguem a escala configurada para a empresa do desenvolvedor
+	settings := loadCompanySettings(repos(c), developerCompanyID)
+	if err := validateScore(settings, req.WeightedAverageScore); err != nil {
+		return err
+	}
+	if err := validateScores(settings, "questionScores", req.QuestionScores); err != nil {
+		return err
+	}
+	if err := validateScores(settings, "categoryScores", req.CategoryScores); err != nil {
+		return err
 	}
 
-	query := `
-		INSERT INTO performance_reports (developer_id, month, question_scores, category_scores, 
-		                               weighted_average_score, highlights, points_to_develop)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, developer_id, month, question_scores, category_scores, 
-		          weighted_average_score, highlights, points_to_develop, created_at, updated_at
-	`
-
-	var report models.PerformanceReport
-	err = database.DB.QueryRow(
-		query,
-		req.DeveloperID,
-		req.Month,
-		req.QuestionScores,
-		req.CategoryScores,
-		req.WeightedAverageScore,
-		req.Highlights,
-		req.PointsToDevelop,
-	).Scan(
-		&report.ID,
-		&report.DeveloperID,
-		&report.Month,
-		&report.QuestionScores,
-		&report.CategoryScores,
-		&report.WeightedAverageScore,
-		&report.Highlights,
-		&report.PointsToDevelop,
-		&report.CreatedAt,
-		&report.UpdatedAt,
-	)
+	for _, goal := range req.Goals {
+		if goal.OwnerID != nil && !userInCompany(repos(c), *goal.OwnerID, developerCompanyID) {
+			return apierror.ErrOwnerNotInCompany
+		}
+	}
 
+	existingReportExists, err := repos(c).Reports().ExistsForMonth(req.DeveloperID, req.Month)
 	if err != nil {
-		log.Printf("Error creating performance report: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao criar relatório",
-		})
+		logging.From(c).Error("Error checking existing report", "error", err)
+		return apierror.ErrInternal
+	}
+	if existingReportExists {
+		return apierror.ErrReportAlreadyExists
+	}
+
+	report := models.PerformanceReport{
+		DeveloperID:          req.DeveloperID,
+		Month:                req.Month,
+		QuestionScores:       req.QuestionScores,
+		CategoryScores:       req.CategoryScores,
+		WeightedAverageScore: req.WeightedAverageScore,
+		Highlights:           req.Highlights,
+		PointsToDevelop:      req.PointsToDevelop,
+		CreatedBy:            &user.UserID,
+	}
+
+	// Metas do plano de desenvolvimento definidas junto com o relatório
+	goals := []models.Goal{}
+	for _, goalReq := range req.Goals {
+		ownerID := goalReq.OwnerID
+		if ownerID == nil {
+			ownerID = &user.UserID
+		}
+		goal, err := newGoal(req.DeveloperID, developerCompanyID, nil, ownerID, goalReq)
+		if err != nil {
+			return apierror.InvalidField("dueDate", "date", "")
+		}
+		goals = append(goals, *goal)
+	}
+
+	if err := repos(c).Reports().CreateWithGoals(&report, goals); err != nil {
+		logging.From(c).Error("Error creating performance report", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "create", "performance_reports", report.ID, nil, auditSnapshot(repos(c), "performance_reports", report.ID))
+	for _, goal := range goals {
+		recordAudit(c, "create", "goals", goal.ID, nil, auditSnapshot(repos(c), "goals", goal.ID))
+	}
+
+	if err := repos(c).Developers().SetLatestScore(req.DeveloperID, req.WeightedAverageScore); err != nil {
+		logging.From(c).Error("Error updating developer latest score", "error", err)
 	}
 
-	_, err = database.DB.Exec(
-		"UPDATE developers SET latest_performance_score = $1 WHERE id = $2",
-		req.WeightedAverageScore,
-		req.DeveloperID,
-	)
+	oneOnOnes, err := oneOnOnesForMonth(repos(c), user, report.DeveloperID, report.Month)
 	if err != nil {
-		log.Printf("Error updating developer latest score: %v", err)
+		logging.From(c).Error("Error querying one-on-ones for report", "error", err)
+		oneOnOnes = []models.OneOnOne{}
 	}
 
 	return c.Status(201).JSON(fiber.Map{
-		"success": true,
-		"data":    report,
+		"success":   true,
+		"data":      report,
+		"goals":     goals,
+		"oneOnOnes": oneOnOnes,
 	})
 }
 
 func GetAvailableMonths(c *fiber.Ctx) error {
 	user := c.Locals("user").(*middleware.JWTClaims)
 
-	var query string
-	var args []interface{}
-
-	if user.Role == "admin" {
-		query = `
-			SELECT DISTINCT month 
-			FROM performance_reports 
-			ORDER BY month DESC
-		`
-		args = []interface{}{}
-	} else {
-		query = `
-			SELECT DISTINCT pr.month 
-			FROM performance_reports pr
-			JOIN users d ON pr.developer_id = d.id
-			WHERE d.company_id = $1
-			ORDER BY pr.month DESC
-		`
-		args = []interface{}{user.CompanyID}
-	}
-
-	rows, err := database.DB.Query(query, args...)
-	if err != nil {
-		log.Printf("Error querying available months: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar meses disponíveis",
-		})
+	var companyID *uuid.UUID
+	if user.Role != "admin" {
+		if user.CompanyID == nil {
+			return apierror.ErrCompanyMembershipRequired
+		}
+		companyID = user.CompanyID
 	}
-	defer rows.Close()
 
-	var months []string
-	for rows.Next() {
-		var month string
-		err := rows.Scan(&month)
-		if err != nil {
-			log.Printf("Error scanning month: %v", err)
-			continue
-		}
-		months = append(months, month)
+	months, err := repos(c).Reports().AvailableMonths(companyID)
+	if err != nil {
+		logging.From(c).Error("Error querying available months", "error", err)
+		return apierror.ErrInternal
 	}
 
 	return c.JSON(fiber.Map{
@@ -483,76 +279,129 @@ func GetAvailableMonths(c *fiber.Ctx) error {
 	})
 }
 
+// GetPerformanceStats resume as notas no ano fiscal informado. Precisão e ano fiscal seguem as
+// configurações de cada empresa; admins sem ?companyId recebem o consolidado e o detalhamento por empresa.
 func GetPerformanceStats(c *fiber.Ctx) error {
 	user := c.Locals("user").(*middleware.JWTClaims)
 
-	var query string
-	var args []interface{}
-
-	if user.Role == "admin" {
-		query = `
-			SELECT 
-				COUNT(*) as total_reports,
-				ROUND(AVG(weighted_average_score)::numeric, 2) as average_score,
-				MAX(weighted_average_score) as highest_score,
-				MIN(weighted_average_score) as lowest_score
-			FROM performance_reports
-		`
-	} else {
-		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   true,
-				"message": "Usuário deve estar associado a uma empresa",
-			})
+	companyID, err := companyScopeFilter(c, user)
+	if err != nil {
+		return err
+	}
+
+	fiscalYear := 0
+	if value := c.Query("fiscalYear"); value != "" {
+		fiscalYear, err = strconv.Atoi(value)
+		if err != nil || fiscalYear < 1900 || fiscalYear > 9999 {
+			return apierror.InvalidField("fiscalYear", "range", "1900-9999")
 		}
+	}
 
-		query = `
-			SELECT 
-				COUNT(*) as total_reports,
-				ROUND(AVG(pr.weighted_average_score)::numeric, 2) as average_score,
-				MAX(pr.weighted_average_score) as highest_score,
-				MIN(pr.weighted_average_score) as lowest_score
-			FROM performance_reports pr
-			INNER JOIN developers d ON pr.developer_id = d.id
-			WHERE d.company_id = $1
-		`
-		args = append(args, *user.CompanyID)
-	}
-
-	var stats struct {
-		TotalReports int     `json:"totalReports"`
-		AverageScore float64 `json:"averageScore"`
-		HighestScore float64 `json:"highestScore"`
-		LowestScore  float64 `json:"lowestScore"`
-	}
-
-	var err error
-	if len(args) > 0 {
-		err = database.DB.QueryRow(query, args...).Scan(
-			&stats.TotalReports,
-			&stats.AverageScore,
-			&stats.HighestScore,
-			&stats.LowestScore,
-		)
-	} else {
-		err = database.DB.QueryRow(query).Scan(
-			&stats.TotalReports,
-			&stats.AverageScore,
-			&stats.HighestScore,
-			&stats.LowestScore,
-		)
+	if companyID == nil {
+		return crossCompanyPerformanceStats(c, fiscalYear)
 	}
 
+	settings := loadCompanySettings(repos(c), companyID)
+	fiscalFrom, fiscalTo := fiscalPeriod(settings, fiscalYear)
+
+	stats, err := repos(c).Reports().Stats(companyID, fiscalFrom, fiscalTo)
+	if err != nil {
+		logging.From(c).Error("Error querying performance stats", "error", err)
+		return apierror.ErrInternal
+	}
+
+	response := fiber.Map{
+		"success": true,
+		"data":    roundStats(settings, stats),
+		"scale": fiber.Map{
+			"min":           settings.ScoreMin,
+			"max":           settings.ScoreMax,
+			"step":          settings.ScoreStep,
+			"decimalPlaces": settings.DecimalPlaces,
+		},
+	}
+	if fiscalFrom != "" {
+		response["period"] = fiber.Map{
+			"from": fiscalFrom,
+			"to":   fiscalTo,
+		}
+	}
+
+	return c.JSON(response)
+}
+
+// crossCompanyPerformanceStats calcula as estatísticas de cada empresa com as configurações dela.
+// O consolidado mistura escalas diferentes e é arredondado com a maior precisão entre as empresas.
+func crossCompanyPerformanceStats(c *fiber.Ctx, fiscalYear int) error {
+	store := repos(c)
+
+	companies, err := store.Companies().List(nil)
 	if err != nil {
-		log.Printf("Error querying performance stats: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar estatísticas",
+		logging.From(c).Error("Error querying companies", "error", err)
+		return apierror.ErrInternal
+	}
+
+	breakdown := make([]models.CompanyPerformanceStats, 0, len(companies))
+	var total models.PerformanceStats
+	var sum float64
+	decimalPlaces := defaultCompanySettings(uuid.Nil).DecimalPlaces
+	for i, company := range companies {
+		settings := loadCompanySettings(store, &company.ID)
+		from, to := fiscalPeriod(settings, fiscalYear)
+
+		stats, err := store.Reports().Stats(&company.ID, from, to)
+		if err != nil {
+			logging.From(c).Error("Error querying performance stats", "company_id", company.ID, "error", err)
+			return apierror.ErrInternal
+		}
+
+		if i == 0 || settings.DecimalPlaces > decimalPlaces {
+			decimalPlaces = settings.DecimalPlaces
+		}
+		if stats.TotalReports > 0 {
+			if total.TotalReports == 0 || stats.HighestScore > total.HighestScore {
+				total.HighestScore = stats.HighestScore
+			}
+			if total.TotalReports == 0 || stats.LowestScore < total.LowestScore {
+				total.LowestScore = stats.LowestScore
+			}
+			sum += stats.AverageScore * float64(stats.TotalReports)
+			total.TotalReports += stats.TotalReports
+		}
+
+		breakdown = append(breakdown, models.CompanyPerformanceStats{
+			CompanyID:     company.ID,
+			CompanyName:   company.Name,
+			Stats:         roundStats(settings, stats),
+			DecimalPlaces: settings.DecimalPlaces,
+			PeriodFrom:    from,
+			PeriodTo:      to,
 		})
 	}
+	if total.TotalReports > 0 {
+		total.AverageScore = sum / float64(total.TotalReports)
+	}
 
 	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    stats,
+		"success":       true,
+		"data":          roundStats(models.CompanySettings{DecimalPlaces: decimalPlaces}, total),
+		"decimalPlaces": decimalPlaces,
+		"companies":     breakdown,
 	})
 }
+
+// fiscalPeriod retorna os meses do ano fiscal da empresa, ou nenhum filtro se fiscalYear for zero
+func fiscalPeriod(settings models.CompanySettings, fiscalYear int) (string, string) {
+	if fiscalYear == 0 {
+		return "", ""
+	}
+	return fiscalYearMonths(settings, fiscalYear)
+}
+
+// roundStats arredonda as notas das estatísticas conforme a precisão configurada
+func roundStats(settings models.CompanySettings, stats models.PerformanceStats) models.PerformanceStats {
+	stats.AverageScore = roundScore(settings, stats.AverageScore)
+	stats.HighestScore = roundScore(settings, stats.HighestScore)
+	stats.LowestScore = roundScore(settings, stats.LowestScore)
+	return stats
+}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/config"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+	"tivix-performance-tracker-backend/services"
+)
+
+// serve executa o handler registrado em route com os repositórios em memória, autenticado com
+// as claims informadas, e retorna o status e o corpo da resposta
+func serve(t *testing.T, provider repository.Provider, claims *middleware.JWTClaims, method, route, path string, body interface{}, handler fiber.Handler) (int, []byte) {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Use(services.New(provider, config.Defaults()).Middleware())
+	app.Add(method, route, func(c *fiber.Ctx) error {
+		c.Locals("user", claims)
+		return c.Next()
+	}, handler)
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, raw
+}
+
+// errorCode extrai o código do envelope de erro da resposta
+func errorCode(t *testing.T, body []byte) string {
+	t.Helper()
+
+	var resp apierror.Response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("resposta inválida: %v\n%s", err, body)
+	}
+	return resp.Code
+}
+
+// seedReports cria uma empresa com um desenvolvedor e um relatório para cada mês informado
+func seedReports(t *testing.T, store repository.Store, months ...string) uuid.UUID {
+	t.Helper()
+
+	company := models.Company{Name: uuid.NewString(), IsActive: true}
+	if err := store.Companies().Create(&company); err != nil {
+		t.Fatal(err)
+	}
+	developer := models.Developer{Name: "Dev", Role: "Engineer", CompanyID: &company.ID}
+	if err := store.Developers().Create(&developer); err != nil {
+		t.Fatal(err)
+	}
+	for i, month := range months {
+		report := models.PerformanceReport{DeveloperID: developer.ID, Month: month, WeightedAverageScore: float64(5 + i)}
+		if err := store.Reports().Create(&report); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return company.ID
+}
+
+func TestGetAvailableMonthsIsScopedToCompany(t *testing.T) {
+	provider := repository.NewMemoryProvider()
+	store := provider.Store(nil)
+
+	alpha := seedReports(t, store, "2025-01", "2025-03")
+	seedReports(t, store, "2025-02")
+
+	cases := []struct {
+		name   string
+		claims *middleware.JWTClaims
+		want   []string
+	}{
+		{"manager", &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &alpha}, []string{"2025-03", "2025-01"}},
+		{"admin", &middleware.JWTClaims{UserID: uuid.New(), Role: "admin"}, []string{"2025-03", "2025-02", "2025-01"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, raw := serve(t, provider, tc.claims, "GET", "/months", "/months", nil, GetAvailableMonths)
+
+			var body struct {
+				Data []string `json:"data"`
+			}
+			if err := json.Unmarshal(raw, &body); err != nil {
+				t.Fatal(err)
+			}
+			if status != fiber.StatusOK || !reflect.DeepEqual(body.Data, tc.want) {
+				t.Fatalf("status %d, meses %v; esperado %v", status, body.Data, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetPerformanceStats(t *testing.T) {
+	provider := repository.NewMemoryProvider()
+	store := provider.Store(nil)
+
+	// Notas 5, 6 e 7 na empresa alpha; 5 e 6 na beta, que arredonda sem casas decimais
+	// e tem o ano fiscal iniciado em fevereiro
+	alpha := seedReports(t, store, "2024-12", "2025-01", "2025-02")
+	beta := seedReports(t, store, "2025-01", "2025-02")
+
+	settings := defaultCompanySettings(beta)
+	settings.DecimalPlaces = 0
+	settings.FiscalYearStartMonth = 2
+	if err := store.Companies().SaveSettings(&settings); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &alpha}
+	admin := &middleware.JWTClaims{UserID: uuid.New(), Role: "admin", CompanyID: &beta}
+
+	cases := []struct {
+		name   string
+		claims *middleware.JWTClaims
+		path   string
+		want   models.PerformanceStats
+	}{
+		{"empresa do manager", manager, "/stats", models.PerformanceStats{TotalReports: 3, AverageScore: 6, HighestScore: 7, LowestScore: 5}},
+		{"ano fiscal", manager, "/stats?fiscalYear=2025", models.PerformanceStats{TotalReports: 2, AverageScore: 6.5, HighestScore: 7, LowestScore: 6}},
+		{"admin vê todas as empresas", admin, "/stats", models.PerformanceStats{TotalReports: 5, AverageScore: 5.8, HighestScore: 7, LowestScore: 5}},
+		{"admin com ano fiscal de cada empresa", admin, "/stats?fiscalYear=2025", models.PerformanceStats{TotalReports: 3, AverageScore: 6.33, HighestScore: 7, LowestScore: 6}},
+		{"admin filtrando a empresa", admin, "/stats?companyId=" + beta.String(), models.PerformanceStats{TotalReports: 2, AverageScore: 6, HighestScore: 6, LowestScore: 5}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, raw := serve(t, provider, tc.claims, "GET", "/stats", tc.path, nil, GetPerformanceStats)
+
+			var body struct {
+				Data models.PerformanceStats `json:"data"`
+			}
+			if err := json.Unmarshal(raw, &body); err != nil {
+				t.Fatal(err)
+			}
+			if status != fiber.StatusOK || body.Data != tc.want {
+				t.Fatalf("status %d, estatísticas %+v; esperado %+v", status, body.Data, tc.want)
+			}
+		})
+	}
+
+	// O detalhamento por empresa usa a precisão e o ano fiscal de cada empresa, não os do admin
+	_, raw := serve(t, provider, admin, "GET", "/stats", "/stats?fiscalYear=2025", nil, GetPerformanceStats)
+	var body struct {
+		Companies []models.CompanyPerformanceStats `json:"companies"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatal(err)
+	}
+	want := map[uuid.UUID]models.CompanyPerformanceStats{
+		alpha: {Stats: models.PerformanceStats{TotalReports: 2, AverageScore: 6.5, HighestScore: 7, LowestScore: 6}, DecimalPlaces: 2, PeriodFrom: "2025-01", PeriodTo: "2025-12"},
+		beta:  {Stats: models.PerformanceStats{TotalReports: 1, AverageScore: 6, HighestScore: 6, LowestScore: 6}, DecimalPlaces: 0, PeriodFrom: "2025-02", PeriodTo: "2026-01"},
+	}
+	if len(body.Companies) != len(want) {
+		t.Fatalf("empresas: %+v", body.Companies)
+	}
+	for _, company := range body.Companies {
+		expected := want[company.CompanyID]
+		if company.Stats != expected.Stats || company.DecimalPlaces != expected.DecimalPlaces ||
+			company.PeriodFrom != expected.PeriodFrom || company.PeriodTo != expected.PeriodTo {
+			t.Errorf("empresa %s: %+v; esperado %+v", company.CompanyID, company, expected)
+		}
+	}
+
+	status, raw := serve(t, provider, &middleware.JWTClaims{UserID: uuid.New(), Role: "manager"}, "GET", "/stats", "/stats", nil, GetPerformanceStats)
+	if code := errorCode(t, raw); status != fiber.StatusForbidden || code != "COMPANY_MEMBERSHIP_REQUIRED" {
+		t.Fatalf("manager sem empresa: obtido %d %s; esperado 403 COMPANY_MEMBERSHIP_REQUIRED", status, code)
+	}
+}
+
+func TestCreatePerformanceReportValidatesInput(t *testing.T) {
+	provider := repository.NewMemoryProvider()
+	store := provider.Store(nil)
+
+	alpha := seedReports(t, store, "2025-01")
+	beta := seedReports(t, store)
+
+	developers, err := store.Developers().List(repository.DeveloperFilter{CompanyID: &alpha})
+	if err != nil || len(developers) != 1 {
+		t.Fatalf("desenvolvedores: %v %v", developers, err)
+	}
+	others, err := store.Developers().List(repository.DeveloperFilter{CompanyID: &beta})
+	if err != nil || len(others) != 1 {
+		t.Fatalf("desenvolvedores: %v %v", others, err)
+	}
+
+	manager := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &alpha}
+	report := func(developerID uuid.UUID) map[string]interface{} {
+		return map[string]interface{}{
+			"developerId":          developerID,
+			"month":                "2025-01",
+			"questionScores":       map[string]interface{}{},
+			"categoryScores":       map[string]interface{}{},
+			"weightedAverageScore": 8,
+		}
+	}
+
+	cases := []struct {
+		name string
+		body map[string]interface{}
+		code string
+	}{
+		{"mês com relatório", report(developers[0].ID), "REPORT_ALREADY_EXISTS"},
+		{"desenvolvedor de outra empresa", report(others[0].ID), "DEVELOPER_NOT_IN_COMPANY"},
+		{"nota de pergunta fora da escala", withScores(report(developers[0].ID), "questionScores", 11), "SCORE_OUT_OF_RANGE"},
+		{"nota de categoria fora do incremento", withScores(report(developers[0].ID), "categoryScores", 7.005), "SCORE_STEP_MISMATCH"},
+		{"nota de categoria que não é número", withScores(report(developers[0].ID), "categoryScores", "alto"), "VALIDATION_FAILED"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, raw := serve(t, provider, manager, "POST", "/reports", "/reports", tc.body, CreatePerformanceReport)
+			if code := errorCode(t, raw); status != fiber.StatusBadRequest || code != tc.code {
+				t.Fatalf("obtido %d %s; esperado 400 %s", status, code, tc.code)
+			}
+		})
+	}
+}
+
+// withScores troca o mês do relatório por um sem relatório e define uma nota no mapa informado
+func withScores(report map[string]interface{}, field string, score interface{}) map[string]interface{} {
+	report["month"] = "2025-03"
+	report[field] = map[string]interface{}{"q1": 8, "q2": score}
+	return report
+}
+
+func TestCreateAndGetPerformanceReport(t *testing.T) {
+	provider := repository.NewMemoryProvider()
+	store := provider.Store(nil)
+
+	alpha := seedReports(t, store)
+	developers, err := store.Developers().List(repository.DeveloperFilter{CompanyID: &alpha})
+	if err != nil || len(developers) != 1 {
+		t.Fatalf("desenvolvedores: %v %v", developers, err)
+	}
+	developer := developers[0]
+
+	meeting := models.OneOnOne{DeveloperID: developer.ID, CompanyID: &alpha, MeetingDate: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), Visibility: "private"}
+	if err := store.OneOnOnes().Create(&meeting); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &alpha}
+	status, raw := serve(t, provider, manager, "POST", "/reports", "/reports", map[string]interface{}{
+		"developerId":          developer.ID,
+		"month":                "2025-02",
+		"questionScores":       map[string]interface{}{},
+		"categoryScores":       map[string]interface{}{},
+		"weightedAverageScore": 7.5,
+		"goals":                []map[string]interface{}{{"title": "Conduzir a retrospectiva", "dueDate": "2025-04-30"}},
+	}, CreatePerformanceReport)
+	if status != fiber.StatusCreated {
+		t.Fatalf("criação: status %d\n%s", status, raw)
+	}
+
+	var created struct {
+		Data  models.PerformanceReport `json:"data"`
+		Goals []models.Goal            `json:"goals"`
+	}
+	if err := json.Unmarshal(raw, &created); err != nil {
+		t.Fatal(err)
+	}
+	if len(created.Goals) != 1 || created.Goals[0].ReportID == nil || *created.Goals[0].ReportID != created.Data.ID {
+		t.Fatalf("metas criadas: %+v", created.Goals)
+	}
+	if owner := created.Goals[0].OwnerID; owner == nil || *owner != manager.UserID {
+		t.Errorf("responsável pela meta: %v; esperado o autor do relatório", owner)
+	}
+
+	stored, err := store.Developers().FindByID(developer.ID)
+	if err != nil || stored.LatestPerformanceScore != 7.5 {
+		t.Errorf("nota mais recente: %+v %v", stored, err)
+	}
+
+	var audited []string
+	for _, entry := range provider.AuditLogs() {
+		audited = append(audited, entry.EntityType)
+	}
+	if want := []string{"performance_reports", "goals"}; !reflect.DeepEqual(audited, want) {
+		t.Errorf("auditoria: %v; esperado %v", audited, want)
+	}
+
+	path := "/reports/" + created.Data.ID.String()
+	status, raw = serve(t, provider, manager, "GET", "/reports/:id", path, nil, GetPerformanceReportByID)
+	if status != fiber.StatusOK {
+		t.Fatalf("consulta: status %d\n%s", status, raw)
+	}
+
+	var fetched struct {
+		Data      models.PerformanceReport `json:"data"`
+		Goals     []models.Goal            `json:"goals"`
+		OpenGoals []models.Goal            `json:"openGoals"`
+		OneOnOnes []models.OneOnOne        `json:"oneOnOnes"`
+	}
+	if err := json.Unmarshal(raw, &fetched); err != nil {
+		t.Fatal(err)
+	}
+	if fetched.Data.ID != created.Data.ID || fetched.Data.WeightedAverageScore != 7.5 {
+		t.Errorf("relatório: %+v", fetched.Data)
+	}
+	if len(fetched.Goals) != 1 || fetched.Goals[0].ID != created.Goals[0].ID || len(fetched.OpenGoals) != 0 {
+		t.Errorf("metas: %+v, em aberto: %+v", fetched.Goals, fetched.OpenGoals)
+	}
+	if len(fetched.OneOnOnes) != 1 || fetched.OneOnOnes[0].ID != meeting.ID {
+		t.Errorf("reuniões 1:1 do mês: %+v", fetched.OneOnOnes)
+	}
+
+	// Usuários de outra empresa não encontram o relatório
+	beta := seedReports(t, store)
+	outsider := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &beta}
+	status, raw = serve(t, provider, outsider, "GET", "/reports/:id", path, nil, GetPerformanceReportByID)
+	if code := errorCode(t, raw); status != fiber.StatusNotFound || code != "REPORT_NOT_FOUND" {
+		t.Fatalf("outra empresa: obtido %d %s; esperado 404 REPORT_NOT_FOUND", status, code)
+	}
+}
@@ -0,0 +1,416 @@
+package handlers
+
+import (
+	"database/sql"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+)
+
+const reportCommentColumns = `c.id, c.report_id, c.company_id, c.author_id, c.parent_id, c.body, c.mentions,
+	c.edited_at, c.deleted_at, c.created_at, c.updated_at, COALESCE(u.name, '')`
+
+func scanReportComment(row rowScanner, comment *models.ReportComment) error {
+	return row.Scan(
+		&comment.ID,
+		&comment.ReportID,
+		&comment.CompanyID,
+		&comment.AuthorID,
+		&comment.ParentID,
+		&comment.Body,
+		&comment.Mentions,
+		&comment.EditedAt,
+		&comment.DeletedAt,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&comment.AuthorName,
+	)
+}
+
+// reportCompanyForCommenter aplica as mesmas regras de empresa de GetPerformanceReportByID;
+// usuários comuns só acessam relatórios do desenvolvedor vinculado a eles
+func reportCompanyForCommenter(db database.Querier, user *middleware.JWTClaims, reportID uuid.UUID) (*uuid.UUID, error) {
+	var companyID, developerUserID *uuid.UUID
+	err := db.QueryRow(`
+		SELECT d.company_id, d.user_id
+		FROM performance_reports pr
+		INNER JOIN developers d ON pr.developer_id = d.id
+		WHERE pr.id = $1
+	`, reportID).Scan(&companyID, &developerUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == "admin" {
+		return companyID, nil
+	}
+
+	if user.CompanyID == nil || companyID == nil || *user.CompanyID != *companyID {
+		return nil, sql.ErrNoRows
+	}
+
+	if user.Role != "manager" && (developerUserID == nil || *developerUserID != user.UserID) {
+		return nil, sql.ErrNoRows
+	}
+
+	return companyID, nil
+}
+
+func findReportComment(db database.Querier, reportID, commentID uuid.UUID) (*models.ReportComment, error) {
+	var comment models.ReportComment
+	err := scanReportComment(db.QueryRow(`
+		SELECT `+reportCommentColumns+`
+		FROM report_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.id = $1 AND c.report_id = $2
+	`, commentID, reportID), &comment)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func mentionsInCompany(store repository.Store, mentions []uuid.UUID, companyID *uuid.UUID) bool {
+	for _, mentionedID := range mentions {
+		if !userInCompany(store, mentionedID, companyID) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetReportComments retorna os comentários de um relatório organizados em threads
+func GetReportComments(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	reportUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	if _, err := reportCompanyForCommenter(tenantDB(c), user, reportUUID); err != nil {
+		return apierror.ErrReportNotFound
+	}
+
+	rows, err := tenantDB(c).Query(`
+		SELECT `+reportCommentColumns+`
+		FROM report_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.report_id = $1
+		ORDER BY c.created_at ASC
+	`, reportUUID)
+	if err != nil {
+		logging.From(c).Error("Error querying report comments", "error", err)
+		return apierror.ErrInternal
+	}
+	defer rows.Close()
+
+	var all []models.ReportComment
+	for rows.Next() {
+		var comment models.ReportComment
+		if err := scanReportComment(rows, &comment); err != nil {
+			logging.From(c).Error("Error scanning report comment", "error", err)
+			return apierror.ErrInternal
+		}
+		all = append(all, comment)
+	}
+	if err := rows.Err(); err != nil {
+		logging.From(c).Error("Error iterating report comments", "error", err)
+		return apierror.ErrInternal
+	}
+
+	// Respostas são agrupadas sob o comentário principal
+	threads := []models.ReportComment{}
+	index := make(map[uuid.UUID]int)
+	for _, comment := range all {
+		if comment.ParentID == nil {
+			index[comment.ID] = len(threads)
+			threads = append(threads, comment)
+		}
+	}
+	for _, comment := range all {
+		if comment.ParentID != nil {
+			if i, ok := index[*comment.ParentID]; ok {
+				threads[i].Replies = append(threads[i].Replies, comment)
+			}
+		}
+	}
+
+	var unreadCount int
+	err = tenantDB(c).QueryRow(`
+		SELECT COUNT(*)
+		FROM report_comments c
+		LEFT JOIN report_comment_reads r ON r.report_id = c.report_id AND r.user_id = $2
+		WHERE c.report_id = $1
+		  AND c.deleted_at IS NULL
+		  AND (c.author_id IS NULL OR c.author_id <> $2)
+		  AND (r.last_read_at IS NULL OR c.created_at > r.last_read_at)
+	`, reportUUID, user.UserID).Scan(&unreadCount)
+	if err != nil {
+		logging.From(c).Error("Error counting unread comments", "error", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success":     true,
+		"data":        threads,
+		"unreadCount": unreadCount,
+	})
+}
+
+// CreateReportComment adiciona um comentário (ou resposta) a um relatório
+func CreateReportComment(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	reportUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	var req models.CreateReportCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	companyID, err := reportCompanyForCommenter(tenantDB(c), user, reportUUID)
+	if err != nil {
+		return apierror.ErrReportNotFound
+	}
+
+	if req.ParentID != nil {
+		parent, err := findReportComment(tenantDB(c), reportUUID, *req.ParentID)
+		if err != nil || parent.ParentID != nil {
+			return apierror.ErrInvalidParentComment
+		}
+	}
+
+	if !mentionsInCompany(repos(c), req.Mentions, companyID) {
+		return apierror.ErrMentionedUserNotInCompany
+	}
+
+	mentions := req.Mentions
+	if mentions == nil {
+		mentions = []uuid.UUID{}
+	}
+
+	var commentID uuid.UUID
+	err = tenantDB(c).QueryRow(`
+		INSERT INTO report_comments (report_id, company_id, author_id, parent_id, body, mentions)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, reportUUID, companyID, user.UserID, req.ParentID, req.Body, models.Array[uuid.UUID](mentions)).Scan(&commentID)
+	if err != nil {
+		logging.From(c).Error("Error creating report comment", "error", err)
+		return apierror.ErrInternal
+	}
+
+	comment, err := findReportComment(tenantDB(c), reportUUID, commentID)
+	if err != nil {
+		logging.From(c).Error("Error querying created report comment", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "create", "report_comments", commentID, nil, auditSnapshot(repos(c), "report_comments", commentID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    comment,
+	})
+}
+
+// UpdateReportComment edita um comentário do próprio usuário
+func UpdateReportComment(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	reportUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+	commentUUID, err := uuid.Parse(c.Params("commentId"))
+	if err != nil {
+		return apierror.InvalidID("commentId")
+	}
+
+	var req models.UpdateReportCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	companyID, err := reportCompanyForCommenter(tenantDB(c), user, reportUUID)
+	if err != nil {
+		return apierror.ErrReportNotFound
+	}
+
+	comment, err := findReportComment(tenantDB(c), reportUUID, commentUUID)
+	if err != nil || comment.DeletedAt != nil {
+		return apierror.ErrCommentNotFound
+	}
+
+	if comment.AuthorID == nil || *comment.AuthorID != user.UserID {
+		return apierror.ErrCommentAuthorRequired
+	}
+
+	if !mentionsInCompany(repos(c), req.Mentions, companyID) {
+		return apierror.ErrMentionedUserNotInCompany
+	}
+
+	mentions := req.Mentions
+	if mentions == nil {
+		mentions = []uuid.UUID{}
+	}
+
+	before := auditSnapshot(repos(c), "report_comments", commentUUID)
+
+	_, err = tenantDB(c).Exec(`
+		UPDATE report_comments
+		SET body = $1, mentions = $2, edited_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`, req.Body, models.Array[uuid.UUID](mentions), commentUUID)
+	if err != nil {
+		logging.From(c).Error("Error updating report comment", "error", err)
+		return apierror.ErrInternal
+	}
+
+	updated, err := findReportComment(tenantDB(c), reportUUID, commentUUID)
+	if err != nil {
+		logging.From(c).Error("Error querying updated report comment", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "update", "report_comments", commentUUID, before, auditSnapshot(repos(c), "report_comments", commentUUID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    updated,
+	})
+}
+
+// DeleteReportComment remove um comentário do próprio usuário, preservando as respostas da thread
+func DeleteReportComment(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	reportUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+	commentUUID, err := uuid.Parse(c.Params("commentId"))
+	if err != nil {
+		return apierror.InvalidID("commentId")
+	}
+
+	if _, err := reportCompanyForCommenter(tenantDB(c), user, reportUUID); err != nil {
+		return apierror.ErrReportNotFound
+	}
+
+	comment, err := findReportComment(tenantDB(c), reportUUID, commentUUID)
+	if err != nil || comment.DeletedAt != nil {
+		return apierror.ErrCommentNotFound
+	}
+
+	if comment.AuthorID == nil || *comment.AuthorID != user.UserID {
+		return apierror.ErrCommentAuthorRequired
+	}
+
+	before := auditSnapshot(repos(c), "report_comments", commentUUID)
+
+	_, err = tenantDB(c).Exec(`
+		UPDATE report_comments
+		SET body = '', mentions = $1, deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, models.Array[uuid.UUID]{}, commentUUID)
+	if err != nil {
+		logging.From(c).Error("Error deleting report comment", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "delete", "report_comments", commentUUID, before, auditSnapshot(repos(c), "report_comments", commentUUID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "messages.comment_deleted"),
+	})
+}
+
+// MarkReportCommentsRead marca os comentários do relatório como lidos pelo usuário
+func MarkReportCommentsRead(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	reportUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	if _, err := reportCompanyForCommenter(tenantDB(c), user, reportUUID); err != nil {
+		return apierror.ErrReportNotFound
+	}
+
+	_, err = tenantDB(c).Exec(`
+		INSERT INTO report_comment_reads (report_id, user_id, last_read_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (report_id, user_id) DO UPDATE SET last_read_at = CURRENT_TIMESTAMP
+	`, reportUUID, user.UserID)
+	if err != nil {
+		logging.From(c).Error("Error marking report comments as read", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "messages.comments_marked_read"),
+	})
+}
+
+// GetUnreadReportComments retorna, por relatório, a quantidade de comentários não lidos pelo usuário
+func GetUnreadReportComments(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	query := `
+		SELECT c.report_id,
+		       COUNT(*) AS unread_count,
+		       COUNT(*) FILTER (WHERE ` + arrayContains("c.mentions", "$1") + `) AS mention_count,
+		       MAX(c.created_at) AS last_comment_at
+		FROM report_comments c
+		INNER JOIN performance_reports pr ON pr.id = c.report_id
+		INNER JOIN developers d ON pr.developer_id = d.id
+		LEFT JOIN report_comment_reads r ON r.report_id = c.report_id AND r.user_id = $1
+		WHERE c.deleted_at IS NULL
+		  AND (c.author_id IS NULL OR c.author_id <> $1)
+		  AND (r.last_read_at IS NULL OR c.created_at > r.last_read_at)
+	`
+	args := []interface{}{user.UserID}
+
+	if user.Role != "admin" {
+		if user.CompanyID == nil {
+			return apierror.ErrCompanyMembershipRequired
+		}
+		query += " AND d.company_id = $2"
+		args = append(args, *user.CompanyID)
+
+		if user.Role != "manager" {
+			query += " AND d.user_id = $1"
+		}
+	}
+
+	query += " GROUP BY c.report_id ORDER BY last_comment_at DESC"
+
+	unread := []models.ReportCommentUnread{}
+	if err := tenantDB(c).Select(&unread, query, args...); err != nil {
+		logging.From(c).Error("Error querying unread report comments", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    unread,
+	})
+}
@@ -0,0 +1,520 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+)
+
+const skillColumns = `id, company_id, name, category, description, created_at, updated_at`
+
+const developerSkillColumns = `ds.developer_id, ds.skill_id, ds.company_id, ds.self_level, ds.validated_level,
+	ds.validated_by, ds.validated_at, ds.updated_at, s.name AS skill_name, s.category AS skill_category`
+
+const skillAssessmentColumns = `id, developer_id, skill_id, company_id, level, source, assessed_by, note, created_at`
+
+// skillLevelExpression retorna o nível considerado nas buscas: apenas o validado ou o validado com fallback no autodeclarado
+func skillLevelExpression(validatedOnly bool) string {
+	if validatedOnly {
+		return "ds.validated_level"
+	}
+	return "COALESCE(ds.validated_level, ds.self_level)"
+}
+
+// findSkillForUser busca uma competência garantindo que pertence à empresa do usuário
+func findSkillForUser(db database.Querier, user *middleware.JWTClaims, skillID uuid.UUID) (*models.Skill, error) {
+	var skill models.Skill
+	if err := db.Get(&skill, "SELECT "+skillColumns+" FROM skills WHERE id = $1", skillID); err != nil {
+		return nil, err
+	}
+
+	if user.Role != "admin" && (user.CompanyID == nil || *user.CompanyID != skill.CompanyID) {
+		return nil, sql.ErrNoRows
+	}
+
+	return &skill, nil
+}
+
+// developerLinkedToUser verifica se o desenvolvedor está vinculado ao usuário
+func developerLinkedToUser(db database.Querier, developerID, userID uuid.UUID) bool {
+	var linked bool
+	err := db.Get(&linked, "SELECT EXISTS(SELECT 1 FROM developers WHERE id = $1 AND user_id = $2)", developerID, userID)
+	return err == nil && linked
+}
+
+// GetSkills retorna o catálogo de competências da empresa
+func GetSkills(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	companyID, err := companyScopeFilter(c, user)
+	if err != nil {
+		return err
+	}
+
+	query := "SELECT " + skillColumns + " FROM skills"
+	args := []interface{}{}
+	if companyID != nil {
+		query += " WHERE company_id = $1"
+		args = append(args, *companyID)
+	}
+	query += " ORDER BY category ASC, name ASC"
+
+	skills := []models.Skill{}
+	if err := tenantDB(c).Select(&skills, query, args...); err != nil {
+		logging.From(c).Error("Error querying skills", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    skills,
+	})
+}
+
+// CreateSkill adiciona uma competência ao catálogo da empresa
+func CreateSkill(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	var req models.CreateSkillRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	// Determinar a empresa da competência
+	var companyID *uuid.UUID
+	if user.Role == "admin" && req.CompanyID != nil {
+		companyID = req.CompanyID
+	} else if user.CompanyID != nil {
+		companyID = user.CompanyID
+	} else {
+		return apierror.ErrCompanyMembershipRequired
+	}
+
+	var skill models.Skill
+	err := tenantDB(c).Get(&skill, `
+		INSERT INTO skills (company_id, name, category, description)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+skillColumns,
+		*companyID, strings.TrimSpace(req.Name), req.Category, req.Description,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return apierror.ErrSkillExists
+		}
+		logging.From(c).Error("Error creating skill", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "create", "skills", skill.ID, nil, auditSnapshot(repos(c), "skills", skill.ID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    skill,
+	})
+}
+
+// UpdateSkill atualiza uma competência do catálogo
+func UpdateSkill(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	skillUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	var req models.UpdateSkillRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	if _, err := findSkillForUser(tenantDB(c), user, skillUUID); err != nil {
+		return apierror.ErrSkillNotFound
+	}
+
+	setParts := []string{}
+	args := []interface{}{}
+	argIndex := 1
+
+	if req.Name != nil {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, strings.TrimSpace(*req.Name))
+		argIndex++
+	}
+	if req.Category != nil {
+		setParts = append(setParts, fmt.Sprintf("category = $%d", argIndex))
+		args = append(args, *req.Category)
+		argIndex++
+	}
+	if req.Description != nil {
+		setParts = append(setParts, fmt.Sprintf("description = $%d", argIndex))
+		args = append(args, *req.Description)
+		argIndex++
+	}
+
+	if len(setParts) == 0 {
+		return apierror.ErrNothingToUpdate
+	}
+
+	query := fmt.Sprintf("UPDATE skills SET %s WHERE id = $%d RETURNING %s", strings.Join(setParts, ", "), argIndex, skillColumns)
+	args = append(args, skillUUID)
+
+	before := auditSnapshot(repos(c), "skills", skillUUID)
+
+	var skill models.Skill
+	if err := tenantDB(c).Get(&skill, query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return apierror.ErrSkillExists
+		}
+		logging.From(c).Error("Error updating skill", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "update", "skills", skillUUID, before, auditSnapshot(repos(c), "skills", skillUUID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    skill,
+	})
+}
+
+// DeleteSkill remove uma competência do catálogo junto com as avaliações associadas
+func DeleteSkill(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	skillUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apierror.InvalidID("id")
+	}
+
+	if _, err := findSkillForUser(tenantDB(c), user, skillUUID); err != nil {
+		return apierror.ErrSkillNotFound
+	}
+
+	before := auditSnapshot(repos(c), "skills", skillUUID)
+
+	if _, err := tenantDB(c).Exec("DELETE FROM skills WHERE id = $1", skillUUID); err != nil {
+		logging.From(c).Error("Error deleting skill", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "delete", "skills", skillUUID, before, nil)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "messages.skill_deleted"),
+	})
+}
+
+// GetDeveloperSkills retorna a matriz de competências atual de um desenvolvedor
+func GetDeveloperSkills(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+
+	if _, err := developerCompanyForUser(repos(c), user, developerUUID); err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	skills := []models.DeveloperSkill{}
+	err = tenantDB(c).Select(&skills, `
+		SELECT `+developerSkillColumns+`, `+skillLevelExpression(false)+` AS effective_level
+		FROM developer_skills ds
+		INNER JOIN skills s ON s.id = ds.skill_id
+		WHERE ds.developer_id = $1
+		ORDER BY s.category ASC, s.name ASC
+	`, developerUUID)
+	if err != nil {
+		logging.From(c).Error("Error querying developer skills", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    skills,
+	})
+}
+
+// CreateSkillAssessment registra o nível de um desenvolvedor em uma competência.
+// Gestores e admins registram avaliações validadas; o próprio desenvolvedor registra autoavaliações.
+func CreateSkillAssessment(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+
+	var req models.CreateSkillAssessmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	companyID, err := developerCompanyForUser(repos(c), user, developerUUID)
+	if err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	source := "manager"
+	if user.Role != "admin" && user.Role != "manager" {
+		if !developerLinkedToUser(tenantDB(c), developerUUID, user.UserID) {
+			return apierror.ErrSelfAssessmentForbidden
+		}
+		source = "self"
+	}
+
+	skill, err := findSkillForUser(tenantDB(c), user, req.SkillID)
+	if err != nil || companyID == nil || skill.CompanyID != *companyID {
+		return apierror.ErrSkillNotInCompany
+	}
+
+	tx, err := database.Begin(tenantDB(c))
+	if err != nil {
+		logging.From(c).Error("Error starting transaction", "error", err)
+		return apierror.ErrInternal
+	}
+	defer tx.Rollback()
+
+	var assessment models.DeveloperSkillAssessment
+	err = tx.Get(&assessment, `
+		INSERT INTO developer_skill_assessments (developer_id, skill_id, company_id, level, source, assessed_by, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+skillAssessmentColumns,
+		developerUUID, skill.ID, companyID, req.Level, source, user.UserID, req.Note,
+	)
+	if err != nil {
+		logging.From(c).Error("Error creating skill assessment", "error", err)
+		return apierror.ErrInternal
+	}
+
+	upsert := `
+		INSERT INTO developer_skills (developer_id, skill_id, company_id, self_level)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (developer_id, skill_id) DO UPDATE SET self_level = EXCLUDED.self_level
+	`
+	upsertArgs := []interface{}{developerUUID, skill.ID, companyID, req.Level}
+	if source == "manager" {
+		upsert = `
+			INSERT INTO developer_skills (developer_id, skill_id, company_id, validated_level, validated_by, validated_at)
+			VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+			ON CONFLICT (developer_id, skill_id) DO UPDATE
+			SET validated_level = EXCLUDED.validated_level,
+			    validated_by = EXCLUDED.validated_by,
+			    validated_at = EXCLUDED.validated_at
+		`
+		upsertArgs = append(upsertArgs, user.UserID)
+	}
+	if _, err := tx.Exec(upsert, upsertArgs...); err != nil {
+		logging.From(c).Error("Error updating developer skill", "error", err)
+		return apierror.ErrInternal
+	}
+
+	if err := tx.Commit(); err != nil {
+		logging.From(c).Error("Error committing skill assessment", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "create", "developer_skill_assessments", assessment.ID, nil, auditSnapshot(repos(c), "developer_skill_assessments", assessment.ID))
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    assessment,
+	})
+}
+
+// GetSkillAssessmentHistory retorna o histórico de avaliações de um desenvolvedor em uma competência
+func GetSkillAssessmentHistory(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+	skillUUID, err := uuid.Parse(c.Params("skillId"))
+	if err != nil {
+		return apierror.InvalidID("skillId")
+	}
+
+	if _, err := developerCompanyForUser(repos(c), user, developerUUID); err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	history := []models.DeveloperSkillAssessment{}
+	err = tenantDB(c).Select(&history, `
+		SELECT `+skillAssessmentColumns+`
+		FROM developer_skill_assessments
+		WHERE developer_id = $1 AND skill_id = $2
+		ORDER BY created_at DESC
+	`, developerUUID, skillUUID)
+	if err != nil {
+		logging.From(c).Error("Error querying skill assessment history", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    history,
+	})
+}
+
+// SearchDevelopersBySkill busca desenvolvedores com nível mínimo em uma competência
+// (?skillId ou ?skill=nome, ?minLevel=1..5, ?validatedOnly=true)
+func SearchDevelopersBySkill(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	companyID, err := companyScopeFilter(c, user)
+	if err != nil {
+		return err
+	}
+
+	minLevel, err := strconv.Atoi(c.Query("minLevel", "1"))
+	if err != nil || minLevel < 1 || minLevel > 5 {
+		return apierror.InvalidField("minLevel", "range", "1-5")
+	}
+	validatedOnly := c.Query("validatedOnly") == "true"
+	levelExpr := skillLevelExpression(validatedOnly)
+
+	query := `
+		SELECT ` + developerSkillColumns + `, d.name AS developer_name, ` + levelExpr + ` AS effective_level
+		FROM developer_skills ds
+		INNER JOIN skills s ON s.id = ds.skill_id
+		INNER JOIN developers d ON d.id = ds.developer_id
+		WHERE d.archived_at IS NULL AND ` + levelExpr + ` >= $1`
+	args := []interface{}{minLevel}
+
+	if value := c.Query("skillId"); value != "" {
+		skillUUID, err := uuid.Parse(value)
+		if err != nil {
+			return apierror.InvalidID("skillId")
+		}
+		args = append(args, skillUUID)
+		query += fmt.Sprintf(" AND ds.skill_id = $%d", len(args))
+	} else if name := strings.TrimSpace(c.Query("skill")); name != "" {
+		args = append(args, strings.ToLower(name))
+		query += fmt.Sprintf(" AND LOWER(s.name) = $%d", len(args))
+	} else {
+		return apierror.Required("skillId")
+	}
+
+	if companyID != nil {
+		args = append(args, *companyID)
+		query += fmt.Sprintf(" AND s.company_id = $%d", len(args))
+	}
+	query += " ORDER BY effective_level DESC, d.name ASC"
+
+	results := []models.DeveloperSkill{}
+	if err := tenantDB(c).Select(&results, query, args...); err != nil {
+		logging.From(c).Error("Error searching developers by skill", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    results,
+	})
+}
+
+// GetTeamSkillCoverage retorna a cobertura de cada competência do catálogo no time e as lacunas
+// (?minLevel=3, ?includeSubteams=true, ?validatedOnly=true)
+func GetTeamSkillCoverage(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	teamUUID, err := uuid.Parse(c.Params("teamId"))
+	if err != nil {
+		return apierror.InvalidID("teamId")
+	}
+
+	minLevel, err := strconv.Atoi(c.Query("minLevel", "3"))
+	if err != nil || minLevel < 1 || minLevel > 5 {
+		return apierror.InvalidField("minLevel", "range", "1-5")
+	}
+
+	companyID, err := teamCompanyForUser(tenantDB(c), user, teamUUID)
+	if err != nil || companyID == nil {
+		return apierror.ErrTeamNotFound
+	}
+
+	teamIDs := []uuid.UUID{teamUUID}
+	if c.Query("includeSubteams") == "true" {
+		teamIDs, err = teamSubtreeIDs(tenantDB(c), teamUUID)
+		if err != nil {
+			logging.From(c).Error("Error querying team subtree", "error", err)
+			return apierror.ErrInternal
+		}
+	}
+
+	teamList, teamArgs := inList(1, teamIDs)
+
+	var developerCount int
+	err = tenantDB(c).Get(&developerCount,
+		"SELECT COUNT(*) FROM developers WHERE team_id IN "+teamList+" AND archived_at IS NULL",
+		teamArgs...,
+	)
+	if err != nil {
+		logging.From(c).Error("Error counting team developers", "error", err)
+		return apierror.ErrInternal
+	}
+
+	teamList, teamArgs = inList(3, teamIDs)
+	coverageArgs := append([]interface{}{*companyID, minLevel}, teamArgs...)
+
+	coverage := []models.TeamSkillCoverage{}
+	err = tenantDB(c).Select(&coverage, `
+		SELECT s.id AS skill_id, s.name AS skill_name, s.category AS skill_category,
+		       COUNT(*) FILTER (WHERE m.level >= $2) AS qualified_count,
+		       COUNT(m.level) AS assessed_count,
+		       COALESCE(ROUND(CAST(AVG(m.level) AS NUMERIC), 2), 0) AS average_level,
+		       COALESCE(MAX(m.level), 0) AS max_level,
+		       COUNT(*) FILTER (WHERE m.level >= $2) = 0 AS is_gap
+		FROM skills s
+		LEFT JOIN (
+			SELECT ds.skill_id, `+skillLevelExpression(c.Query("validatedOnly") == "true")+` AS level
+			FROM developer_skills ds
+			INNER JOIN developers d ON d.id = ds.developer_id
+			WHERE d.team_id IN `+teamList+` AND d.archived_at IS NULL
+		) m ON m.skill_id = s.id
+		WHERE s.company_id = $1
+		GROUP BY s.id, s.name, s.category
+		ORDER BY s.category ASC, s.name ASC
+	`, coverageArgs...)
+	if err != nil {
+		logging.From(c).Error("Error querying team skill coverage", "error", err)
+		return apierror.ErrInternal
+	}
+
+	gaps := []models.TeamSkillCoverage{}
+	for _, skill := range coverage {
+		if skill.IsGap {
+			gaps = append(gaps, skill)
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"developerCount": developerCount,
+			"minLevel":       minLevel,
+			"coverage":       coverage,
+			"gaps":           gaps,
+		},
+	})
+}
@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+)
+
+const teamTreeColumns = `id, name, COALESCE(description, '') AS description, COALESCE(color, '') AS color,
+	company_id, parent_id, kind, created_at, updated_at`
+
+// loadTeamsForUser retorna os times visíveis ao usuário (todos para admins)
+func loadTeamsForUser(db database.Querier, user *middleware.JWTClaims) ([]models.Team, error) {
+	teams := []models.Team{}
+	if user.Role == "admin" {
+		err := db.Select(&teams, "SELECT "+teamTreeColumns+" FROM teams ORDER BY name ASC")
+		return teams, err
+	}
+	if user.CompanyID == nil {
+		return teams, nil
+	}
+	err := db.Select(&teams, "SELECT "+teamTreeColumns+" FROM teams WHERE company_id = $1 ORDER BY name ASC", *user.CompanyID)
+	return teams, err
+}
+
+// buildTeamForest monta a árvore de times a partir da lista plana.
+// Com rootID nulo retorna todas as unidades sem pai.
+func buildTeamForest(teams []models.Team, rootID *uuid.UUID) []models.Team {
+	known := make(map[uuid.UUID]bool, len(teams))
+	for _, team := range teams {
+		known[team.ID] = true
+	}
+
+	children := make(map[uuid.UUID][]models.Team)
+	roots := []models.Team{}
+	for _, team := range teams {
+		if rootID != nil {
+			if team.ID == *rootID {
+				roots = append(roots, team)
+			} else if team.ParentID != nil {
+				children[*team.ParentID] = append(children[*team.ParentID], team)
+			}
+			continue
+		}
+		// Pais fora da visão do usuário são tratados como raiz
+		if team.ParentID == nil || !known[*team.ParentID] {
+			roots = append(roots, team)
+		} else {
+			children[*team.ParentID] = append(children[*team.ParentID], team)
+		}
+	}
+
+	var attach func(team models.Team) models.Team
+	attach = func(team models.Team) models.Team {
+		for _, child := range children[team.ID] {
+			team.Children = append(team.Children, attach(child))
+		}
+		return team
+	}
+
+	for i := range roots {
+		roots[i] = attach(roots[i])
+	}
+	return roots
+}
+
+// teamSubtreeIDs retorna o time informado e todos os seus descendentes
+func teamSubtreeIDs(db database.Querier, teamID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	rows, err := db.Query(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM teams WHERE id = $1
+			UNION
+			SELECT t.id FROM teams t INNER JOIN subtree s ON t.parent_id = s.id
+		)
+		SELECT id FROM subtree
+	`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetTeamTree retorna a hierarquia completa de unidades da empresa
+func GetTeamTree(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+
+	teams, err := loadTeamsForUser(tenantDB(c), user)
+	if err != nil {
+		logging.From(c).Error("Error querying team tree", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    buildTeamForest(teams, nil),
+	})
+}
+
+// GetTeamSubtree retorna um time com todas as suas subunidades
+func GetTeamSubtree(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	teamUUID, err := uuid.Parse(c.Params("teamId"))
+	if err != nil {
+		return apierror.InvalidID("teamId")
+	}
+
+	teams, err := loadTeamsForUser(tenantDB(c), user)
+	if err != nil {
+		logging.From(c).Error("Error querying team subtree", "error", err)
+		return apierror.ErrInternal
+	}
+
+	forest := buildTeamForest(teams, &teamUUID)
+	if len(forest) == 0 {
+		return apierror.ErrTeamNotFound
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    forest[0],
+	})
+}
+
+// MoveTeam move um time (e toda a sua subárvore) para outro pai, ou para a raiz com parentId nulo
+func MoveTeam(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	teamUUID, err := uuid.Parse(c.Params("teamId"))
+	if err != nil {
+		return apierror.InvalidID("teamId")
+	}
+
+	var req models.MoveTeamRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.ErrInvalidBody
+	}
+
+	companyID, err := repos(c).Teams().CompanyOf(teamUUID)
+	if err == nil && user.Role != "admin" && (user.CompanyID == nil || companyID == nil || *user.CompanyID != *companyID) {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		return apierror.ErrTeamNotFound
+	}
+
+	if req.ParentID != nil {
+		parentCompanyID, err := repos(c).Teams().CompanyOf(*req.ParentID)
+		if err != nil || parentCompanyID == nil || companyID == nil || *parentCompanyID != *companyID {
+			return apierror.ErrParentTeamNotInCompany
+		}
+
+		subtree, err := teamSubtreeIDs(tenantDB(c), teamUUID)
+		if err != nil {
+			logging.From(c).Error("Error querying team subtree", "error", err)
+			return apierror.ErrInternal
+		}
+		for _, id := range subtree {
+			if id == *req.ParentID {
+				return apierror.ErrTeamHierarchyCycle
+			}
+		}
+	}
+
+	before := auditSnapshot(repos(c), "teams", teamUUID)
+
+	var team models.Team
+	err = tenantDB(c).Get(&team, "UPDATE teams SET parent_id = $1 WHERE id = $2 RETURNING "+teamTreeColumns, req.ParentID, teamUUID)
+	if err != nil {
+		logging.From(c).Error("Error moving team", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "move", "teams", teamUUID, before, auditSnapshot(repos(c), "teams", teamUUID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    team,
+	})
+}
+
+// teamDeveloperCounts conta os desenvolvedores ativos de cada time da lista (teamIDs vem de inList)
+func teamDeveloperCounts(db database.Querier, teamIDs string, args []interface{}) (map[uuid.UUID]int, error) {
+	rows, err := db.Query(`
+		SELECT team_id, COUNT(*)
+		FROM developers
+		WHERE team_id IN `+teamIDs+` AND archived_at IS NULL
+		GROUP BY team_id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var id uuid.UUID
+		var count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+		counts[id] = count
+	}
+	return counts, rows.Err()
+}
+
+// teamReportStats retorna a quantidade de relatórios e a soma das notas de cada time da lista,
+// opcionalmente restritas ao mês. Relatórios são atribuídos ao time registrado no momento da criação.
+func teamReportStats(db database.Querier, teamIDs string, args []interface{}, month string) (map[uuid.UUID]int, map[uuid.UUID]float64, error) {
+	query := `
+		SELECT team_id, COUNT(*), COALESCE(SUM(weighted_average_score), 0)
+		FROM performance_reports
+		WHERE team_id IN ` + teamIDs + `
+	`
+	if month != "" {
+		query += fmt.Sprintf(" AND month = $%d", len(args)+1)
+		args = append(args, month)
+	}
+	query += " GROUP BY team_id"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int)
+	sums := make(map[uuid.UUID]float64)
+	for rows.Next() {
+		var id uuid.UUID
+		var count int
+		var sum float64
+		if err := rows.Scan(&id, &count, &sum); err != nil {
+			return nil, nil, err
+		}
+		counts[id] = count
+		sums[id] = sum
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return counts, sums, nil
+}
+
+// GetTeamStats retorna estatísticas de performance de um time, agregando todas as subunidades
+func GetTeamStats(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	teamUUID, err := uuid.Parse(c.Params("teamId"))
+	if err != nil {
+		return apierror.InvalidID("teamId")
+	}
+
+	teams, err := loadTeamsForUser(tenantDB(c), user)
+	if err != nil {
+		logging.From(c).Error("Error querying teams for stats", "error", err)
+		return apierror.ErrInternal
+	}
+
+	forest := buildTeamForest(teams, &teamUUID)
+	if len(forest) == 0 {
+		return apierror.ErrTeamNotFound
+	}
+
+	ids, err := teamSubtreeIDs(tenantDB(c), teamUUID)
+	if err != nil {
+		logging.From(c).Error("Error querying team subtree", "error", err)
+		return apierror.ErrInternal
+	}
+
+	teamIDs, teamArgs := inList(1, ids)
+
+	developerCounts, err := teamDeveloperCounts(tenantDB(c), teamIDs, teamArgs)
+	if err != nil {
+		logging.From(c).Error("Error counting team developers", "error", err)
+		return apierror.ErrInternal
+	}
+
+	reportCounts, scoreSums, err := teamReportStats(tenantDB(c), teamIDs, teamArgs, c.Query("month"))
+	if err != nil {
+		logging.From(c).Error("Error aggregating team reports", "error", err)
+		return apierror.ErrInternal
+	}
+
+	settings := loadCompanySettings(repos(c), forest[0].CompanyID)
+
+	// rollup devolve as estatísticas do nó e a soma das notas da subárvore
+	var rollup func(team models.Team) (models.TeamStats, float64)
+	rollup = func(team models.Team) (models.TeamStats, float64) {
+		stats := models.TeamStats{
+			TeamID:         team.ID,
+			Name:           team.Name,
+			Kind:           team.Kind,
+			DeveloperCount: developerCounts[team.ID],
+			ReportCount:    reportCounts[team.ID],
+			AverageScore:   averageScore(settings, scoreSums[team.ID], reportCounts[team.ID]),
+			Children:       []models.TeamStats{},
+		}
+		stats.Rollup.DeveloperCount = stats.DeveloperCount
+		stats.Rollup.ReportCount = stats.ReportCount
+		sum := scoreSums[team.ID]
+
+		for _, child := range team.Children {
+			childStats, childSum := rollup(child)
+			stats.Rollup.DeveloperCount += childStats.Rollup.DeveloperCount
+			stats.Rollup.ReportCount += childStats.Rollup.ReportCount
+			sum += childSum
+			stats.Children = append(stats.Children, childStats)
+		}
+
+		stats.Rollup.AverageScore = averageScore(settings, sum, stats.Rollup.ReportCount)
+		return stats, sum
+	}
+
+	stats, _ := rollup(forest[0])
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    stats,
+	})
+}
+
+func averageScore(settings models.CompanySettings, sum float64, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return roundScore(settings, sum/float64(count))
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+)
+
+// teamCompanyForUser retorna a empresa de um time, inclusive de times já excluídos
+// (a partir do histórico), garantindo que pertence à empresa do usuário
+func teamCompanyForUser(db database.Querier, user *middleware.JWTClaims, teamID uuid.UUID) (*uuid.UUID, error) {
+	var companyID *uuid.UUID
+	err := db.QueryRow(`
+		SELECT company_id FROM teams WHERE id = $1
+		UNION ALL
+		SELECT company_id FROM team_memberships WHERE team_id = $1
+		LIMIT 1
+	`, teamID).Scan(&companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != "admin" {
+		if user.CompanyID == nil || companyID == nil || *user.CompanyID != *companyID {
+			return nil, sql.ErrNoRows
+		}
+	}
+
+	return companyID, nil
+}
+
+// GetDeveloperTeamHistory retorna os períodos em que o desenvolvedor participou de cada time
+func GetDeveloperTeamHistory(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	developerUUID, err := uuid.Parse(c.Params("developerId"))
+	if err != nil {
+		return apierror.InvalidID("developerId")
+	}
+
+	if _, err := developerCompanyForUser(repos(c), user, developerUUID); err != nil {
+		return apierror.ErrDeveloperAccessDenied
+	}
+
+	memberships := []models.TeamMembership{}
+	err = tenantDB(c).Select(&memberships, `
+		SELECT tm.id, tm.developer_id, tm.team_id, COALESCE(t.name, tm.team_name) AS team_name,
+		       tm.company_id, tm.joined_at, tm.left_at
+		FROM team_memberships tm
+		LEFT JOIN teams t ON t.id = tm.team_id
+		WHERE tm.developer_id = $1
+		ORDER BY tm.joined_at ASC
+	`, developerUUID)
+	if err != nil {
+		logging.From(c).Error("Error querying team history", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    memberships,
+	})
+}
+
+// GetTeamRoster retorna os integrantes de um time em uma data (?date=YYYY-MM-DD, padrão hoje)
+func GetTeamRoster(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
+	teamUUID, err := uuid.Parse(c.Params("teamId"))
+	if err != nil {
+		return apierror.InvalidID("teamId")
+	}
+
+	date := c.Query("date", time.Now().Format("2006-01-02"))
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return apierror.InvalidField("date", "date", "")
+	}
+	// A data informada inclui o dia inteiro
+	asOf := parsed.AddDate(0, 0, 1)
+
+	if _, err := teamCompanyForUser(tenantDB(c), user, teamUUID); err != nil {
+		return apierror.ErrTeamNotFound
+	}
+
+	roster := []models.TeamMembership{}
+	err = tenantDB(c).Select(&roster, `
+		SELECT tm.id, tm.developer_id, d.name AS developer_name, tm.team_id,
+		       COALESCE(t.name, tm.team_name) AS team_name, tm.company_id, tm.joined_at, tm.left_at
+		FROM team_memberships tm
+		INNER JOIN developers d ON d.id = tm.developer_id
+		LEFT JOIN teams t ON t.id = tm.team_id
+		WHERE tm.team_id = $1
+		  AND tm.joined_at < $2
+		  AND (tm.left_at IS NULL OR tm.left_at >= $2)
+		ORDER BY d.name ASC
+	`, teamUUID, asOf)
+	if err != nil {
+		logging.From(c).Error("Error querying team roster", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    roster,
+		"date":    date,
+	})
+}
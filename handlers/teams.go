@@ -1,77 +1,34 @@
 package handlers
 
 import (
-	"database/sql"
-	"fmt"
-	"log"
-
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
-	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
 	"tivix-performance-tracker-backend/middleware"
 	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
 )
 
 // GetAllTeams retorna todos os times
 func GetAllTeams(c *fiber.Ctx) error {
 	user := c.Locals("user").(*middleware.JWTClaims)
-	
-	var query string
-	var args []interface{}
-
-	if user.Role == "admin" {
-		// Admins podem ver todos os times
-		query = `
-			SELECT id, name, description, color, company_id, created_at, updated_at 
-			FROM teams 
-			ORDER BY created_at DESC
-		`
-	} else {
-		// Managers e usuários só podem ver times da sua empresa
+
+	// Admins podem ver todos os times; managers e usuários só os da sua empresa
+	var companyID *uuid.UUID
+	if user.Role != "admin" {
 		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   true,
-				"message": "Usuário deve estar associado a uma empresa",
-			})
+			return apierror.ErrCompanyMembershipRequired
 		}
-		
-		query = `
-			SELECT id, name, description, color, company_id, created_at, updated_at 
-			FROM teams 
-			WHERE company_id = $1
-			ORDER BY created_at DESC
-		`
-		args = append(args, *user.CompanyID)
+		companyID = user.CompanyID
 	}
 
-	rows, err := database.DB.Query(query, args...)
+	teams, err := repos(c).Teams().List(companyID)
 	if err != nil {
-		log.Printf("Error querying teams: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar times",
-		})
-	}
-	defer rows.Close()
-
-	var teams []models.Team
-	for rows.Next() {
-		var team models.Team
-		err := rows.Scan(
-			&team.ID,
-			&team.Name,
-			&team.Description,
-			&team.Color,
-			&team.CompanyID,
-			&team.CreatedAt,
-			&team.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning team: %v", err)
-			continue
-		}
-		teams = append(teams, team)
+		logging.From(c).Error("Error querying teams", "error", err)
+		return apierror.ErrInternal
 	}
 
 	return c.JSON(fiber.Map{
@@ -86,50 +43,22 @@ func GetTeamByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	teamUUID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID inválido",
-		})
+		return apierror.InvalidID("id")
 	}
 
-	query := `
-		SELECT id, name, description, color, company_id, created_at, updated_at 
-		FROM teams 
-		WHERE id = $1
-	`
-
-	var team models.Team
-	err = database.DB.QueryRow(query, teamUUID).Scan(
-		&team.ID,
-		&team.Name,
-		&team.Description,
-		&team.Color,
-		&team.CompanyID,
-		&team.CreatedAt,
-		&team.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return c.Status(404).JSON(fiber.Map{
-			"error":   true,
-			"message": "Time não encontrado",
-		})
+	team, err := repos(c).Teams().FindByID(teamUUID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrTeamNotFound
 	}
 	if err != nil {
-		log.Printf("Error querying team: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao buscar time",
-		})
+		logging.From(c).Error("Error querying team", "error", err)
+		return apierror.ErrInternal
 	}
 
 	// Verificar se o usuário tem permissão para ver este time
 	if user.Role != "admin" {
 		if user.CompanyID == nil || team.CompanyID == nil || *user.CompanyID != *team.CompanyID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   true,
-				"message": "Sem permissão para acessar este time",
-			})
+			return apierror.ErrTeamAccessDenied
 		}
 	}
 
@@ -145,21 +74,19 @@ func CreateTeam(c *fiber.Ctx) error {
 	
 	var req models.CreateTeamRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
 	if req.Name == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Nome é obrigatório",
-		})
+		return apierror.Required("name")
 	}
 
-	if req.Color == "" {
-		req.Color = "blue"
+	if req.Kind == "" {
+		req.Kind = "team"
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		return apierror.Validation(err)
 	}
 
 	// Determinar a empresa do time
@@ -171,37 +98,38 @@ func CreateTeam(c *fiber.Ctx) error {
 		// Managers e usuários criam times na sua própria empresa
 		companyID = user.CompanyID
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error":   true,
-			"message": "Usuário deve estar associado a uma empresa",
-		})
+		return apierror.ErrCompanyMembershipRequired
 	}
 
-	query := `
-		INSERT INTO teams (name, description, color, company_id)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, name, description, color, company_id, created_at, updated_at
-	`
-
-	var team models.Team
-	err := database.DB.QueryRow(query, req.Name, req.Description, req.Color, companyID).Scan(
-		&team.ID,
-		&team.Name,
-		&team.Description,
-		&team.Color,
-		&team.CompanyID,
-		&team.CreatedAt,
-		&team.UpdatedAt,
-	)
+	// Sem cor informada, usa a próxima cor da paleta da empresa
+	if req.Color == "" {
+		req.Color = nextTeamColor(tenantDB(c), loadCompanySettings(repos(c), companyID), companyID)
+	}
 
-	if err != nil {
-		log.Printf("Error creating team: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao criar time",
-		})
+	// Verificar se o time pai pertence à mesma empresa (se fornecido)
+	if req.ParentID != nil {
+		parentCompanyID, err := repos(c).Teams().CompanyOf(*req.ParentID)
+		if err != nil || parentCompanyID == nil || companyID == nil || *parentCompanyID != *companyID {
+			return apierror.ErrParentTeamNotInCompany
+		}
+	}
+
+	team := models.Team{
+		Name:        req.Name,
+		Description: req.Description,
+		Color:       req.Color,
+		CompanyID:   companyID,
+		ParentID:    req.ParentID,
+		Kind:        req.Kind,
 	}
 
+	if err := repos(c).Teams().Create(&team); err != nil {
+		logging.From(c).Error("Error creating team", "error", err)
+		return apierror.ErrInternal
+	}
+
+	recordAudit(c, "create", "teams", team.ID, nil, auditSnapshot(repos(c), "teams", team.ID))
+
 	return c.Status(201).JSON(fiber.Map{
 		"success": true,
 		"data":    team,
@@ -210,90 +138,60 @@ func CreateTeam(c *fiber.Ctx) error {
 
 // UpdateTeam atualiza um time existente
 func UpdateTeam(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
 	id := c.Params("id")
 	teamUUID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID inválido",
-		})
+		return apierror.InvalidID("id")
 	}
 
 	var req models.UpdateTeamRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Dados inválidos",
-		})
+		return apierror.ErrInvalidBody
 	}
 
-	// Verificar se o time existe
-	var exists bool
-	err = database.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM teams WHERE id = $1)", teamUUID).Scan(&exists)
-	if err != nil || !exists {
-		return c.Status(404).JSON(fiber.Map{
-			"error":   true,
-			"message": "Time não encontrado",
-		})
+	// Verificar se o time existe e pertence à empresa do usuário
+	team, err := repos(c).Teams().FindByID(teamUUID)
+	if err == nil && user.Role != "admin" && (user.CompanyID == nil || team.CompanyID == nil || *user.CompanyID != *team.CompanyID) {
+		err = repository.ErrNotFound
+	}
+	if err == repository.ErrNotFound {
+		return apierror.ErrTeamNotFound
+	}
+	if err != nil {
+		logging.From(c).Error("Error querying team", "error", err)
+		return apierror.ErrInternal
 	}
 
-	// Construir query dinâmica
-	setParts := []string{}
-	args := []interface{}{}
-	argIndex := 1
+	if req.Name == nil && req.Description == nil && req.Color == nil && req.Kind == nil {
+		return apierror.ErrNothingToUpdate
+	}
 
 	if req.Name != nil {
-		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
-		args = append(args, *req.Name)
-		argIndex++
+		team.Name = *req.Name
 	}
 	if req.Description != nil {
-		setParts = append(setParts, fmt.Sprintf("description = $%d", argIndex))
-		args = append(args, *req.Description)
-		argIndex++
+		team.Description = *req.Description
 	}
 	if req.Color != nil {
-		setParts = append(setParts, fmt.Sprintf("color = $%d", argIndex))
-		args = append(args, *req.Color)
-		argIndex++
-	}
-
-	if len(setParts) == 0 {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "Nenhum campo para atualizar",
-		})
+		team.Color = *req.Color
 	}
-
-	query := "UPDATE teams SET "
-	for i, part := range setParts {
-		if i > 0 {
-			query += ", "
+	if req.Kind != nil {
+		if err := validate.Var(*req.Kind, "oneof=department team squad"); err != nil {
+			return apierror.InvalidField("kind", "oneof", "department team squad")
 		}
-		query += part
+		team.Kind = *req.Kind
 	}
-	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, name, description, color, created_at, updated_at", argIndex)
-
-	args = append(args, teamUUID)
 
-	var team models.Team
-	err = database.DB.QueryRow(query, args...).Scan(
-		&team.ID,
-		&team.Name,
-		&team.Description,
-		&team.Color,
-		&team.CreatedAt,
-		&team.UpdatedAt,
-	)
+	before := auditSnapshot(repos(c), "teams", teamUUID)
 
-	if err != nil {
-		log.Printf("Error updating team: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao atualizar time",
-		})
+	if err := repos(c).Teams().Update(team); err != nil {
+		logging.From(c).Error("Error updating team", "error", err)
+		return apierror.ErrInternal
 	}
 
+	recordAudit(c, "update", "teams", teamUUID, before, auditSnapshot(repos(c), "teams", teamUUID))
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    team,
@@ -302,45 +200,38 @@ func UpdateTeam(c *fiber.Ctx) error {
 
 // DeleteTeam exclui um time
 func DeleteTeam(c *fiber.Ctx) error {
+	user := c.Locals("user").(*middleware.JWTClaims)
 	id := c.Params("id")
 	teamUUID, err := uuid.Parse(id)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error":   true,
-			"message": "ID inválido",
-		})
+		return apierror.InvalidID("id")
 	}
 
-	// Primeiro, remove a associação dos desenvolvedores com o time
-	_, err = database.DB.Exec("UPDATE developers SET team_id = NULL WHERE team_id = $1", teamUUID)
+	// Verificar se o time existe e pertence à empresa do usuário
+	companyID, err := repos(c).Teams().CompanyOf(teamUUID)
+	if err == nil && user.Role != "admin" && (user.CompanyID == nil || companyID == nil || *user.CompanyID != *companyID) {
+		err = repository.ErrNotFound
+	}
 	if err != nil {
-		log.Printf("Error removing team associations: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao remover associações do time",
-		})
+		return apierror.ErrTeamNotFound
 	}
 
-	// Agora exclui o time
-	result, err := database.DB.Exec("DELETE FROM teams WHERE id = $1", teamUUID)
+	before := auditSnapshot(repos(c), "teams", teamUUID)
+
+	// Subunidades passam a pertencer ao pai do time excluído e os desenvolvedores ficam sem time
+	err = repos(c).Teams().Delete(teamUUID)
+	if err == repository.ErrNotFound {
+		return apierror.ErrTeamNotFound
+	}
 	if err != nil {
-		log.Printf("Error deleting team: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erro ao excluir time",
-		})
+		logging.From(c).Error("Error deleting team", "error", err)
+		return apierror.ErrInternal
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return c.Status(404).JSON(fiber.Map{
-			"error":   true,
-			"message": "Time não encontrado",
-		})
-	}
+	recordAudit(c, "delete", "teams", teamUUID, before, nil)
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Time excluído com sucesso",
+		"message": i18n.T(c, "messages.team_deleted"),
 	})
 }
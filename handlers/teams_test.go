@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+)
+
+// seedTeam cria um time na empresa, opcionalmente subordinado a parentID
+func seedTeam(t *testing.T, store repository.Store, companyID uuid.UUID, parentID *uuid.UUID) models.Team {
+	t.Helper()
+
+	team := models.Team{Name: uuid.NewString(), Color: "blue", CompanyID: &companyID, ParentID: parentID, Kind: "team"}
+	if err := store.Teams().Create(&team); err != nil {
+		t.Fatal(err)
+	}
+	return team
+}
+
+func TestUpdateTeam(t *testing.T) {
+	provider := repository.NewMemoryProvider()
+	store := provider.Store(nil)
+
+	alpha, beta := uuid.New(), uuid.New()
+	team := seedTeam(t, store, alpha, nil)
+	manager := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &alpha}
+	outsider := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &beta}
+
+	cases := []struct {
+		name   string
+		claims *middleware.JWTClaims
+		body   map[string]interface{}
+		status int
+		code   string
+	}{
+		{"time de outra empresa", outsider, map[string]interface{}{"name": "Plataforma"}, fiber.StatusNotFound, "TEAM_NOT_FOUND"},
+		{"sem campos", manager, map[string]interface{}{}, fiber.StatusBadRequest, "NOTHING_TO_UPDATE"},
+		{"tipo inválido", manager, map[string]interface{}{"kind": "tribe"}, fiber.StatusBadRequest, "VALIDATION_FAILED"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, raw := serve(t, provider, tc.claims, "PUT", "/teams/:id", "/teams/"+team.ID.String(), tc.body, UpdateTeam)
+			if code := errorCode(t, raw); status != tc.status || code != tc.code {
+				t.Fatalf("obtido %d %s; esperado %d %s", status, code, tc.status, tc.code)
+			}
+		})
+	}
+
+	t.Run("atualiza os campos informados", func(t *testing.T) {
+		body := map[string]interface{}{"name": "Plataforma", "kind": "squad"}
+		status, raw := serve(t, provider, manager, "PUT", "/teams/:id", "/teams/"+team.ID.String(), body, UpdateTeam)
+		if status != fiber.StatusOK {
+			t.Fatalf("status %d\n%s", status, raw)
+		}
+
+		var resp struct {
+			Data models.Team `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			t.Fatal(err)
+		}
+		stored, err := store.Teams().FindByID(team.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, got := range []models.Team{resp.Data, *stored} {
+			if got.Name != "Plataforma" || got.Kind != "squad" || got.Color != team.Color {
+				t.Errorf("time %+v; esperado nome Plataforma, tipo squad e cor %s", got, team.Color)
+			}
+		}
+	})
+}
+
+func TestDeleteTeamReparentsChildrenAndDetachesDevelopers(t *testing.T) {
+	provider := repository.NewMemoryProvider()
+	store := provider.Store(nil)
+
+	alpha, beta := uuid.New(), uuid.New()
+	department := seedTeam(t, store, alpha, nil)
+	team := seedTeam(t, store, alpha, &department.ID)
+	squad := seedTeam(t, store, alpha, &team.ID)
+
+	developer := models.Developer{Name: "Dev", Role: "Engineer", CompanyID: &alpha, TeamID: &team.ID}
+	if err := store.Developers().Create(&developer); err != nil {
+		t.Fatal(err)
+	}
+
+	outsider := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &beta}
+	status, raw := serve(t, provider, outsider, "DELETE", "/teams/:id", "/teams/"+team.ID.String(), nil, DeleteTeam)
+	if code := errorCode(t, raw); status != fiber.StatusNotFound || code != "TEAM_NOT_FOUND" {
+		t.Fatalf("outra empresa: obtido %d %s; esperado 404 TEAM_NOT_FOUND", status, code)
+	}
+
+	manager := &middleware.JWTClaims{UserID: uuid.New(), Role: "manager", CompanyID: &alpha}
+	status, raw = serve(t, provider, manager, "DELETE", "/teams/:id", "/teams/"+team.ID.String(), nil, DeleteTeam)
+	if status != fiber.StatusOK {
+		t.Fatalf("status %d\n%s", status, raw)
+	}
+
+	if _, err := store.Teams().FindByID(team.ID); err != repository.ErrNotFound {
+		t.Errorf("time excluído ainda encontrado: %v", err)
+	}
+	child, err := store.Teams().FindByID(squad.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.ParentID == nil || *child.ParentID != department.ID {
+		t.Errorf("subunidade com pai %v; esperado %s", child.ParentID, department.ID)
+	}
+	detached, err := store.Developers().FindByID(developer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detached.TeamID != nil {
+		t.Errorf("desenvolvedor ainda vinculado ao time %s", *detached.TeamID)
+	}
+}
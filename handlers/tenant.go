@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/repository"
+	"tivix-performance-tracker-backend/services"
+)
+
+// tenantDB retorna a transação da requisição: sujeita às políticas de RLS da empresa do usuário
+// (TenantScopeMiddleware) ou, nas rotas de sistema, sem as políticas (SystemScopeMiddleware)
+func tenantDB(c *fiber.Ctx) database.Querier {
+	return middleware.TenantDB(c)
+}
+
+// repos retorna os repositórios sobre a transação da requisição (tenantDB)
+func repos(c *fiber.Ctx) repository.Store {
+	return reposOn(c, tenantDB(c))
+}
+
+// reposOn retorna os repositórios da requisição executando em q (por exemplo, uma transação aninhada)
+func reposOn(c *fiber.Ctx, q database.Querier) repository.Store {
+	return services.From(c).Repositories.Store(q)
+}
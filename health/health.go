@@ -0,0 +1,119 @@
+// Package health implementa as sondas de liveness e readiness usadas pelo orquestrador
+package health
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/logging"
+)
+
+// MigrationStatus informa a versão mais recente aplicada e quantas migrações estão pendentes
+type MigrationStatus func(ctx context.Context) (version int64, pending int, err error)
+
+// Checker responde às sondas com base no banco e no estado de encerramento da aplicação
+type Checker struct {
+	DB         *sql.DB
+	Migrations MigrationStatus
+	// Timeout limita cada verificação do readiness
+	Timeout time.Duration
+
+	started  time.Time
+	draining atomic.Bool
+}
+
+// New cria o verificador das sondas sobre db
+func New(db *sql.DB, migrations MigrationStatus, timeout time.Duration) *Checker {
+	return &Checker{DB: db, Migrations: migrations, Timeout: timeout, started: time.Now()}
+}
+
+// Drain marca a aplicação como em encerramento: o readiness passa a falhar para que o
+// balanceador pare de enviar tráfego enquanto as requisições em andamento terminam
+func (h *Checker) Drain() {
+	h.draining.Store(true)
+}
+
+// Check é o resultado de uma verificação do readiness
+type Check struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs,omitempty"`
+	Version   int64   `json:"version,omitempty"`
+	Pending   int     `json:"pending,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+// Livez indica apenas que o processo está respondendo; não consulta dependências, para que
+// uma queda do banco não provoque reinícios em cascata
+func (h *Checker) Livez(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"status": statusOK,
+		"uptime": time.Since(h.started).Round(time.Second).String(),
+	})
+}
+
+// Readyz verifica a conexão com o banco e se todas as migrações foram aplicadas; responde
+// 503 se alguma falhar ou se a aplicação estiver encerrando
+func (h *Checker) Readyz(c *fiber.Ctx) error {
+	checks := map[string]Check{"database": h.checkDatabase(c)}
+	if checks["database"].Status == statusOK {
+		checks["migrations"] = h.checkMigrations(c)
+	} else {
+		checks["migrations"] = Check{Status: statusUnavailable, Error: "banco indisponível"}
+	}
+
+	status := statusOK
+	for _, check := range checks {
+		if check.Status != statusOK {
+			status = statusUnavailable
+		}
+	}
+	if h.draining.Load() {
+		status = statusUnavailable
+		checks["shutdown"] = Check{Status: statusUnavailable, Error: "aplicação em encerramento"}
+	}
+
+	code := fiber.StatusOK
+	if status != statusOK {
+		code = fiber.StatusServiceUnavailable
+	}
+	return c.Status(code).JSON(fiber.Map{
+		"status": status,
+		"checks": checks,
+	})
+}
+
+func (h *Checker) checkDatabase(c *fiber.Ctx) Check {
+	ctx, cancel := context.WithTimeout(c.UserContext(), h.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := h.DB.PingContext(ctx); err != nil {
+		logging.From(c).Warn("Readiness: database unavailable", "error", err)
+		return Check{Status: statusUnavailable, Error: "falha ao conectar ao banco"}
+	}
+	return Check{Status: statusOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+}
+
+func (h *Checker) checkMigrations(c *fiber.Ctx) Check {
+	ctx, cancel := context.WithTimeout(c.UserContext(), h.Timeout)
+	defer cancel()
+
+	version, pending, err := h.Migrations(ctx)
+	if err != nil {
+		logging.From(c).Warn("Readiness: failed to read migrations", "error", err)
+		return Check{Status: statusUnavailable, Error: "falha ao consultar migrações"}
+	}
+	if pending > 0 {
+		return Check{Status: statusUnavailable, Version: version, Pending: pending, Error: "há migrações pendentes"}
+	}
+	return Check{Status: statusOK, Version: version}
+}
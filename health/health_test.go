@@ -0,0 +1,71 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/health"
+)
+
+type response struct {
+	Status string                  `json:"status"`
+	Checks map[string]health.Check `json:"checks"`
+}
+
+func probe(t *testing.T, app *fiber.App, path string) (int, response) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestProbes(t *testing.T) {
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "health.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending := 0
+	checker := health.New(db.DB, func(context.Context) (int64, int, error) { return 20251018120000, pending, nil }, time.Second)
+
+	app := fiber.New()
+	app.Get("/livez", checker.Livez)
+	app.Get("/readyz", checker.Readyz)
+
+	if code, body := probe(t, app, "/readyz"); code != fiber.StatusOK || body.Checks["migrations"].Version != 20251018120000 {
+		t.Fatalf("readyz com banco atualizado: %d %+v", code, body)
+	}
+
+	pending = 2
+	if code, body := probe(t, app, "/readyz"); code != fiber.StatusServiceUnavailable || body.Checks["migrations"].Pending != 2 {
+		t.Errorf("readyz com migrações pendentes: %d %+v", code, body)
+	}
+
+	pending = 0
+	checker.Drain()
+	if code, body := probe(t, app, "/readyz"); code != fiber.StatusServiceUnavailable || body.Checks["shutdown"].Status != "unavailable" {
+		t.Errorf("readyz em encerramento: %d %+v", code, body)
+	}
+
+	db.Close()
+	if code, body := probe(t, app, "/readyz"); code != fiber.StatusServiceUnavailable || body.Checks["database"].Status != "unavailable" {
+		t.Errorf("readyz sem banco: %d %+v", code, body)
+	}
+	if code, body := probe(t, app, "/livez"); code != fiber.StatusOK || body.Status != "ok" {
+		t.Errorf("livez não deveria depender do banco: %d %+v", code, body)
+	}
+}
@@ -0,0 +1,173 @@
+// Package i18n traduz as mensagens exibidas pela API (erros, regras de validação e
+// mensagens de sucesso) para os idiomas suportados. Os catálogos ficam em locales/*.json,
+// um por idioma, com as chaves agrupadas por seção ("errors.REPORT_NOT_FOUND",
+// "rules.required", "messages.user_created").
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Idiomas com catálogo
+const (
+	PortugueseBR = "pt-BR"
+	English      = "en"
+	Spanish      = "es"
+
+	// Default é o idioma usado quando o cliente não pede nenhum idioma suportado
+	Default = PortugueseBR
+)
+
+// Supported lista os idiomas com catálogo
+var Supported = []string{PortugueseBR, English, Spanish}
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]string {
+	loaded := make(map[string]map[string]string, len(Supported))
+	for _, locale := range Supported {
+		data, err := localeFiles.ReadFile("locales/" + locale + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: catálogo %s ausente: %v", locale, err))
+		}
+
+		var sections map[string]map[string]string
+		if err := json.Unmarshal(data, &sections); err != nil {
+			panic(fmt.Sprintf("i18n: catálogo %s inválido: %v", locale, err))
+		}
+
+		messages := make(map[string]string)
+		for section, entries := range sections {
+			for key, message := range entries {
+				messages[section+"."+key] = message
+			}
+		}
+		loaded[locale] = messages
+	}
+	return loaded
+}
+
+// Match retorna o idioma suportado correspondente à tag BCP 47 (en-US → en, pt → pt-BR,
+// es-419 → es) ou "" quando não há catálogo para ela
+func Match(tag string) string {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	base, _, _ := strings.Cut(tag, "-")
+	switch strings.ToLower(base) {
+	case "pt":
+		return PortugueseBR
+	case "en":
+		return English
+	case "es":
+		return Spanish
+	}
+	return ""
+}
+
+// Negotiate escolhe o idioma suportado de maior peso (q) no cabeçalho Accept-Language; em
+// caso de empate vale a ordem do cabeçalho. Retorna "" quando nenhum idioma é suportado.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		locale string
+		weight float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale := Match(tag)
+		if locale == "" {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{locale: locale, weight: weight})
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+	return candidates[0].locale
+}
+
+// Has indica se o catálogo do idioma tem a chave
+func Has(locale, key string) bool {
+	_, ok := catalogs[locale][key]
+	return ok
+}
+
+// Keys retorna as chaves do catálogo do idioma, em ordem alfabética
+func Keys(locale string) []string {
+	keys := make([]string, 0, len(catalogs[locale]))
+	for key := range catalogs[locale] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Message retorna a mensagem da chave no idioma, recorrendo ao idioma padrão e, por fim, à
+// própria chave. Com args, a mensagem é um formato de fmt; durações são escritas no idioma
+// ("15 minutos", "1 hour").
+func Message(locale, key string, args ...any) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+
+	formatted := make([]any, len(args))
+	for i, arg := range args {
+		if d, ok := arg.(time.Duration); ok {
+			arg = FormatDuration(locale, d)
+		}
+		formatted[i] = arg
+	}
+	return fmt.Sprintf(message, formatted...)
+}
+
+// FormatDuration escreve a duração na maior unidade exata ("15 minutos", "1 hour", "90 segundos")
+func FormatDuration(locale string, d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return countUnit(locale, int(d/time.Hour), "hour")
+	case d >= time.Minute && d%time.Minute == 0:
+		return countUnit(locale, int(d/time.Minute), "minute")
+	default:
+		return countUnit(locale, int(d.Round(time.Second)/time.Second), "second")
+	}
+}
+
+func countUnit(locale string, n int, unit string) string {
+	form := "other"
+	if n == 1 {
+		form = "one"
+	}
+	return fmt.Sprintf("%d %s", n, Message(locale, "units."+unit+"."+form))
+}
@@ -0,0 +1,68 @@
+package i18n_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tivix-performance-tracker-backend/i18n"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	reference := i18n.Keys(i18n.Default)
+	for _, locale := range i18n.Supported {
+		for _, key := range reference {
+			if !i18n.Has(locale, key) {
+				t.Errorf("%s: chave %s ausente", locale, key)
+			}
+		}
+		if keys := i18n.Keys(locale); len(keys) != len(reference) {
+			t.Errorf("%s: %d chaves, %s tem %d", locale, len(keys), i18n.Default, len(reference))
+		}
+		for _, key := range i18n.Keys(locale) {
+			if strings.Count(i18n.Message(locale, key), "%s") != strings.Count(i18n.Message(i18n.Default, key), "%s") {
+				t.Errorf("%s: %s tem argumentos diferentes de %s", locale, key, i18n.Default)
+			}
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":                          "",
+		"fr-FR":                     "",
+		"*":                         "",
+		"en":                        i18n.English,
+		"en-US,en;q=0.9":            i18n.English,
+		"pt":                        i18n.PortugueseBR,
+		"pt_PT":                     i18n.PortugueseBR,
+		"es-419":                    i18n.Spanish,
+		"fr-FR, es;q=0.5, en;q=0.7": i18n.English,
+		"de, es;q=0.8, pt-BR;q=0.8": i18n.Spanish,
+		"en;q=0, es;q=0.1":          i18n.Spanish,
+		"en;q=abc, pt-BR;q=0.2":     i18n.PortugueseBR,
+	}
+	for header, expected := range cases {
+		if got := i18n.Negotiate(header); got != expected {
+			t.Errorf("Negotiate(%q) = %q; esperado %q", header, got, expected)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	if got := i18n.Message(i18n.English, "errors.LOGIN_RATE_LIMITED", 15*time.Minute); got != "Too many login attempts. Please try again in 15 minutes." {
+		t.Errorf("duração em inglês: %q", got)
+	}
+	if got := i18n.Message(i18n.Spanish, "errors.LOGIN_RATE_LIMITED", time.Hour); got != "Demasiados intentos de inicio de sesión. Inténtelo de nuevo en 1 hora." {
+		t.Errorf("duração em espanhol: %q", got)
+	}
+	if got := i18n.FormatDuration(i18n.PortugueseBR, 90*time.Second); got != "90 segundos" {
+		t.Errorf("duração em segundos: %q", got)
+	}
+	if got := i18n.Message("fr", "errors.INTERNAL_ERROR"); got != "Erro interno do servidor" {
+		t.Errorf("idioma sem catálogo deveria usar o padrão: %q", got)
+	}
+	if got := i18n.Message(i18n.English, "errors.UNKNOWN"); got != "errors.UNKNOWN" {
+		t.Errorf("chave inexistente deveria ser devolvida: %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+package i18n
+
+import "github.com/gofiber/fiber/v2"
+
+const (
+	localeKey         = "locale"
+	fallbackLocaleKey = "fallbackLocale"
+)
+
+// Middleware negocia o idioma da requisição pelo cabeçalho Accept-Language e informa na
+// resposta o idioma usado (Content-Language)
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if locale := Negotiate(c.Get(fiber.HeaderAcceptLanguage)); locale != "" {
+			c.Locals(localeKey, locale)
+		}
+
+		err := c.Next()
+
+		c.Vary(fiber.HeaderAcceptLanguage)
+		c.Set(fiber.HeaderContentLanguage, Locale(c))
+		return err
+	}
+}
+
+// Requested indica se o cliente pediu um idioma suportado no Accept-Language
+func Requested(c *fiber.Ctx) bool {
+	_, ok := c.Locals(localeKey).(string)
+	return ok
+}
+
+// SetFallback define o idioma usado quando o cliente não pede nenhum idioma suportado (por
+// exemplo, o idioma configurado para a empresa). Tags sem catálogo são ignoradas.
+func SetFallback(c *fiber.Ctx, tag string) {
+	if locale := Match(tag); locale != "" {
+		c.Locals(fallbackLocaleKey, locale)
+	}
+}
+
+// Locale retorna o idioma da requisição: o pedido no Accept-Language, o definido por
+// SetFallback ou o idioma padrão
+func Locale(c *fiber.Ctx) string {
+	if locale, ok := c.Locals(localeKey).(string); ok {
+		return locale
+	}
+	if locale, ok := c.Locals(fallbackLocaleKey).(string); ok {
+		return locale
+	}
+	return Default
+}
+
+// T retorna a mensagem da chave no idioma da requisição
+func T(c *fiber.Ctx, key string, args ...any) string {
+	return Message(Locale(c), key, args...)
+}
@@ -0,0 +1,138 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var lines []map[string]any
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		line := map[string]any{}
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("linha de log não é JSON: %q", raw)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestRedact(t *testing.T) {
+	cases := map[string]string{
+		"duplicate key (email)=(maria.silva@tivix.com.br)": "duplicate key (email)=(m***@tivix.com.br)",
+		"Authorization: Bearer abc.def":                    "Authorization: Bearer [REDACTED]",
+		"token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig":   "token [REDACTED]",
+		`{"password":"hunter2","name":"Ana"}`:              `{"password":"[REDACTED]","name":"Ana"}`,
+		"senha=hunter2&x=1":                                "senha=[REDACTED]&x=1",
+		"nothing to hide":                                  "nothing to hide",
+	}
+
+	for input, expected := range cases {
+		if got := Redact(input); got != expected {
+			t.Errorf("Redact(%q) = %q, esperado %q", input, got, expected)
+		}
+	}
+}
+
+func TestLoggerRedactsAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("login de ana@tivix.com.br",
+		"password", "hunter2",
+		"refresh_token", "abc",
+		"email", "ana@tivix.com.br",
+		"error", errors.New("pq: usuário joao@tivix.com.br já existe"),
+		"count", 3,
+	)
+
+	line := decodeLines(t, &buf)[0]
+	expected := map[string]any{
+		"msg":           "login de a***@tivix.com.br",
+		"password":      redacted,
+		"refresh_token": redacted,
+		"email":         "a***@tivix.com.br",
+		"error":         "pq: usuário j***@tivix.com.br já existe",
+		"count":         float64(3),
+	}
+	for key, value := range expected {
+		if line[key] != value {
+			t.Errorf("%s = %v, esperado %v", key, line[key], value)
+		}
+	}
+}
+
+func TestNewRejectsInvalidSettings(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "verbose", FormatJSON); err == nil {
+		t.Error("nível inválido foi aceito")
+	}
+	if _, err := New(&bytes.Buffer{}, "info", "xml"); err == nil {
+		t.Error("formato inválido foi aceito")
+	}
+}
+
+func TestMiddlewareRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&buf, "debug", FormatJSON)
+
+	app := fiber.New()
+	app.Use(Middleware(logger))
+	app.Get("/ping", func(c *fiber.Ctx) error {
+		With(c, "user_id", "u-1", "company_id", "c-1")
+		From(c).Debug("handler")
+		return c.SendString(RequestID(c))
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusNotFound, "não encontrado")
+	})
+
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Header.Get(RequestIDHeader); got != "req-123" {
+		t.Errorf("X-Request-ID recebido não foi propagado: %q", got)
+	}
+
+	lines := decodeLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("esperava a linha do handler e a de acesso, obteve %d", len(lines))
+	}
+	for _, line := range lines {
+		if line["request_id"] != "req-123" || line["user_id"] != "u-1" || line["company_id"] != "c-1" {
+			t.Errorf("linha sem o contexto da requisição: %v", line)
+		}
+	}
+	if access := lines[1]; access["msg"] != "request" || access["status"] != float64(200) || access["path"] != "/ping" {
+		t.Errorf("linha de acesso inesperada: %v", access)
+	}
+
+	buf.Reset()
+	req = httptest.NewRequest("GET", "/fail", nil)
+	req.Header.Set(RequestIDHeader, "inválido com espaços")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated := resp.Header.Get(RequestIDHeader)
+	if generated == "" || generated == "inválido com espaços" {
+		t.Errorf("ID inválido deveria ser substituído, obteve %q", generated)
+	}
+	access := decodeLines(t, &buf)[0]
+	if access["status"] != float64(404) || access["level"] != "WARN" || access["request_id"] != generated {
+		t.Errorf("linha de acesso do erro inesperada: %v", access)
+	}
+}
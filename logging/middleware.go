@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader identifica a requisição entre o cliente, o proxy e os logs
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDKey = "requestId"
+
+// validRequestID limita os IDs recebidos de clientes, evitando valores enormes ou que
+// quebrem o formato do log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// Middleware atribui um ID à requisição (reaproveitando um X-Request-ID válido recebido),
+// devolve-o no cabeçalho da resposta, disponibiliza o logger da requisição via From e
+// registra uma linha de acesso ao final. Substitui o logger de texto do Fiber.
+func Middleware(logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		requestID := c.Get(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Set(RequestIDHeader, requestID)
+		c.Locals(requestIDKey, requestID)
+		c.Locals(loggerKey, logger.With("request_id", requestID))
+
+		if err := c.Next(); err != nil {
+			// Como o logger do Fiber, resolve o erro aqui para registrar o status final
+			if handlerErr := c.App().Config().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		From(c).LogAttrs(c.UserContext(), level, "request",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("ip", c.IP()),
+			slog.Int("bytes", len(c.Response().Body())),
+			slog.String("user_agent", c.Get(fiber.HeaderUserAgent)),
+		)
+		return nil
+	}
+}
+
+// RequestID retorna o ID atribuído à requisição pelo Middleware
+func RequestID(c *fiber.Ctx) string {
+	requestID, _ := c.Locals(requestIDKey).(string)
+	return requestID
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys são trechos de nomes de atributos cujo valor nunca é registrado
+var sensitiveKeys = []string{"password", "senha", "token", "secret", "authorization", "cookie", "install_key", "api_key"}
+
+var (
+	emailPattern  = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*@([A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)\S+`)
+	secretPattern = regexp.MustCompile(`(?i)((?:password|senha|token|secret)"?\s*[:=]\s*"?)[^\s",}&]+`)
+)
+
+// Redact mascara e-mails, tokens e senhas encontrados em texto livre, como mensagens de
+// erro do banco que repetem os valores da query
+func Redact(value string) string {
+	value = jwtPattern.ReplaceAllString(value, redacted)
+	value = bearerPattern.ReplaceAllString(value, "${1}"+redacted)
+	value = secretPattern.ReplaceAllString(value, "${1}"+redacted)
+	return emailPattern.ReplaceAllString(value, "${1}***@${2}")
+}
+
+// redactAttr é o ReplaceAttr dos handlers: descarta valores de chaves sensíveis e aplica
+// Redact a textos e erros, inclusive à mensagem
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return slog.String(attr.Key, redacted)
+		}
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, Redact(attr.Value.String()))
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			return slog.String(attr.Key, Redact(err.Error()))
+		}
+	}
+	return attr
+}
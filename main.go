@@ -1,94 +1,120 @@
 package main
 
 import (
-	"log"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/joho/godotenv"
 
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/config"
 	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/health"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
+	"tivix-performance-tracker-backend/metrics"
 	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/repository"
 	"tivix-performance-tracker-backend/routes"
+	"tivix-performance-tracker-backend/services"
+	"tivix-performance-tracker-backend/tracing"
 )
 
 func main() {
 	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: .env file not found: %v", err)
+		slog.Warn(".env file not found", "error", err)
 	}
 
-	database.Connect()
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
 
-	database.Migrate()
+	if len(os.Args) > 1 && os.Args[1] == "--health-check" {
+		os.Exit(healthCheck(cfg))
+	}
 
-	app := fiber.New(fiber.Config{
-		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			message := "Erro interno do servidor"
-
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-				if code >= 400 && code < 500 {
-					message = e.Message
-				}
-			}
+	logger, err := logging.New(os.Stdout, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
+	logging.Setup(logger)
 
-			log.Printf("Error %d: %v", code, err)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg)
+	if err != nil {
+		slog.Error("Failed to configure tracing", "error", err)
+		os.Exit(1)
+	}
 
-			return ctx.Status(code).JSON(fiber.Map{
-				"error":   true,
-				"message": message,
-			})
-		},
-		DisableStartupMessage: os.Getenv("ENVIRONMENT") == "production",
-		ServerHeader:          "TivixAPI",
-		AppName:               "Tivix Performance Tracker API",
-	})
+	database.Connect(cfg)
 
-	var allowedOrigins []string
+	database.Migrate(cfg)
 
-	if os.Getenv("ENVIRONMENT") == "development" {
-		allowedOrigins = []string{
-			"http://localhost:3000",
-			"http://localhost:5173",
-			"http://127.0.0.1:5173",
-		}
-	} else if os.Getenv("ENVIRONMENT") == "production" {
-		allowedOrigins = []string{
-			"https://performancetracker.tivix.com.br",
-			"https://performance.valiantgroup.com.br",
-		}
-	} else {
-		corsOrigin := os.Getenv("CORS_ORIGIN")
-		if corsOrigin != "" {
-			allowedOrigins = []string{corsOrigin}
-		} else {
-			allowedOrigins = []string{"http://localhost:5173"}
+	metrics.RegisterDatabase(database.DB.DB, cfg.DBName, database.Migrations().Summary, func(ctx context.Context) (*sql.Tx, error) {
+		tx, err := database.BeginSystem(ctx)
+		if err != nil {
+			return nil, err
 		}
-	}
+		return tx.Tx, nil
+	})
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler:          apierror.Handler,
+		DisableStartupMessage: cfg.IsProduction(),
+		ServerHeader:          "TivixAPI",
+		AppName:               "Tivix Performance Tracker API",
+		BodyLimit:             cfg.BodyLimit,
+		ReadTimeout:           cfg.ReadTimeout,
+		WriteTimeout:          cfg.WriteTimeout,
+		IdleTimeout:           cfg.IdleTimeout,
+	})
 
 	var finalOrigins []string
 	seen := make(map[string]bool)
-	for _, origin := range allowedOrigins {
+	for _, origin := range cfg.CORSOrigins {
 		if origin != "" && !seen[origin] {
 			finalOrigins = append(finalOrigins, origin)
 			seen[origin] = true
 		}
 	}
 
-	log.Printf("🔧 CORS Origins permitidas: %v", finalOrigins)
+	slog.Info("CORS configurado", "origins", finalOrigins)
+
+	app.Use(metrics.Middleware())
+	app.Use(logging.Middleware(logger))
+	app.Use(tracing.Middleware())
+
+	// As sondas ficam antes do CORS e do rate limiting, para que o orquestrador nunca seja limitado
+	checker := health.New(database.DB.DB, database.Migrations().Summary, cfg.HealthCheckTimeout)
+	app.Get("/livez", checker.Livez)
+	app.Get("/readyz", checker.Readyz)
+	app.Get("/health", checker.Readyz)
+
+	app.Use(middleware.RequestTimeout(cfg.RequestTimeout))
+
+	// Idioma das mensagens pelo Accept-Language; nas rotas de empresa, o idioma configurado
+	// para a empresa vale quando o cliente não pede um idioma suportado
+	app.Use(i18n.Middleware())
 
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     strings.Join(finalOrigins, ","),
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS,PATCH",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Requested-With,X-CSRF-Token",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Requested-With,X-CSRF-Token,X-Request-ID",
 		AllowCredentials: true,
-		ExposeHeaders:    "Content-Length,Content-Range",
+		ExposeHeaders:    "Content-Length,Content-Range,X-Request-ID",
 		MaxAge:           86400,
 	}))
 
@@ -98,7 +124,7 @@ func main() {
 			if origin == allowedOrigin {
 				c.Set("Access-Control-Allow-Origin", origin)
 				c.Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS,PATCH")
-				c.Set("Access-Control-Allow-Headers", "Origin,Content-Type,Accept,Authorization,X-Requested-With,X-CSRF-Token")
+				c.Set("Access-Control-Allow-Headers", "Origin,Content-Type,Accept,Authorization,X-Requested-With,X-CSRF-Token,X-Request-ID")
 				c.Set("Access-Control-Allow-Credentials", "true")
 				c.Set("Access-Control-Max-Age", "86400")
 				break
@@ -107,52 +133,123 @@ func main() {
 		return c.SendStatus(fiber.StatusOK)
 	})
 
-	app.Use(logger.New())
-
-	app.Use(middleware.InputSizeLimit(10 * 1024 * 1024))
+	app.Use(middleware.InputSizeLimit(cfg.BodyLimit))
 
 	app.Use(limiter.New(limiter.Config{
-		Max:        100,
-		Expiration: 1 * time.Minute,
+		Max:        cfg.RateLimitMax,
+		Expiration: cfg.RateLimitWindow,
 		KeyGenerator: func(c *fiber.Ctx) string {
 			return c.IP()
 		},
 		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-				"error":   true,
-				"message": "Muitas requisições. Tente novamente em alguns instantes.",
-			})
+			return apierror.ErrRateLimited
 		},
 	}))
 
 	app.Use("/api/v1/auth/login", limiter.New(limiter.Config{
-		Max:        5,
-		Expiration: 15 * time.Minute,
+		Max:        cfg.LoginRateLimitMax,
+		Expiration: cfg.LoginRateLimitWindow,
 		KeyGenerator: func(c *fiber.Ctx) string {
 			return c.IP()
 		},
 		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-				"error":   true,
-				"message": "Muitas tentativas de login. Tente novamente em 15 minutos.",
-			})
+			return apierror.ErrLoginRateLimited.WithArgs(cfg.LoginRateLimitWindow)
 		},
 	}))
 
+	app.Use(services.New(repository.PostgresProvider{}, cfg).Middleware())
+
 	routes.SetupRoutes(app)
 
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"status":  "ok",
-			"message": "Tivix Performance Tracker API is running",
-		})
-	})
+	metricsApp := serveMetrics(app, cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("Server starting", "port", cfg.Port, "environment", cfg.Environment)
+		serverErr <- app.Listen(cfg.Address())
+	}()
+
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		slog.Info("Shutdown signal received, draining requests", "timeout", cfg.ShutdownTimeout)
+	case err := <-serverErr:
+		slog.Error("Server stopped", "error", err)
+		exitCode = 1
 	}
+	stop()
+
+	shutdown(app, metricsApp, checker, shutdownTracing, cfg.ShutdownTimeout)
+	os.Exit(exitCode)
+}
 
-	log.Printf("🚀 Server starting on port %s", port)
-	log.Fatal(app.Listen(":" + port))
+// shutdown encerra a aplicação em ordem: o readiness passa a falhar, o servidor deixa de
+// aceitar conexões e espera as requisições em andamento (até timeout), os spans pendentes
+// são enviados e o pool de conexões é fechado
+func shutdown(app, metricsApp *fiber.App, checker *health.Checker, shutdownTracing func(context.Context) error, timeout time.Duration) {
+	checker.Drain()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := app.ShutdownWithContext(ctx); err != nil {
+		slog.Error("Failed to drain requests", "error", err)
+	}
+	if metricsApp != nil {
+		if err := metricsApp.ShutdownWithContext(ctx); err != nil {
+			slog.Error("Failed to stop metrics listener", "error", err)
+		}
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+	if err := database.DB.Close(); err != nil {
+		slog.Error("Failed to close database pool", "error", err)
+	}
+
+	slog.Info("Server stopped")
+}
+
+// healthCheck consulta /readyz da instância local e retorna o código de saída, para o
+// HEALTHCHECK do Docker (a imagem distroless não tem curl)
+func healthCheck(cfg *config.Config) int {
+	client := http.Client{Timeout: cfg.HealthCheckTimeout + time.Second}
+	resp, err := client.Get("http://" + cfg.LocalAddress() + "/readyz")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "readyz: %s\n", resp.Status)
+		return 1
+	}
+	return 0
+}
+
+// serveMetrics expõe /metrics em um listener separado (METRICS_ADDR), retornado para o
+// encerramento, ou no principal com METRICS_TOKEN. Os gauges de negócio trazem dados por
+// empresa, então o listener principal nunca serve as métricas sem token, em nenhum ambiente
+func serveMetrics(app *fiber.App, cfg *config.Config) *fiber.App {
+	switch {
+	case cfg.MetricsAddr != "":
+		metricsApp := fiber.New(fiber.Config{DisableStartupMessage: true})
+		metricsApp.Get("/metrics", metrics.Handler(cfg.MetricsToken))
+		go func() {
+			if err := metricsApp.Listen(cfg.MetricsAddr); err != nil {
+				slog.Error("Metrics listener stopped", "addr", cfg.MetricsAddr, "error", err)
+			}
+		}()
+		slog.Info("Metrics listener starting", "addr", cfg.MetricsAddr)
+		return metricsApp
+	case cfg.MetricsToken != "":
+		app.Get("/metrics", metrics.Handler(cfg.MetricsToken))
+	default:
+		slog.Warn("Metrics disabled: set METRICS_TOKEN or METRICS_ADDR to expose /metrics")
+	}
+	return nil
 }
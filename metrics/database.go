@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// reportMonths é quantos meses de referência (incluindo o atual) entram em tivix_performance_reports
+const reportMonths = 12
+
+// scrapeTimeout limita as consultas feitas a cada coleta
+const scrapeTimeout = 5 * time.Second
+
+// MigrationStatus informa a versão mais recente aplicada e quantas migrações estão pendentes
+type MigrationStatus func(ctx context.Context) (version int64, pending int, err error)
+
+// BeginSystem abre a transação das consultas de negócio; os indicadores somam todas as
+// empresas, então a transação deve ignorar as políticas de RLS (database.BeginSystem)
+type BeginSystem func(ctx context.Context) (*sql.Tx, error)
+
+// RegisterDatabase registra as estatísticas do pool de conexões, a situação das migrações
+// e os indicadores de negócio calculados a partir do banco
+func RegisterDatabase(db *sql.DB, name string, migrations MigrationStatus, begin BeginSystem) {
+	Registry.MustRegister(
+		collectors.NewDBStatsCollector(db, name),
+		&databaseCollector{begin: begin, migrations: migrations},
+	)
+}
+
+var (
+	migrationVersionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "schema", "migration_version"),
+		"Versão da migração mais recente aplicada ao banco.",
+		nil, nil,
+	)
+	migrationPendingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "schema", "migrations_pending"),
+		"Migrações embutidas na aplicação ainda não aplicadas.",
+		nil, nil,
+	)
+	reportsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "performance_reports"),
+		"Relatórios de desempenho por empresa e mês de referência (últimos 12 meses).",
+		[]string{"company_id", "month"}, nil,
+	)
+	developersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "active_developers"),
+		"Desenvolvedores ativos por empresa.",
+		[]string{"company_id"}, nil,
+	)
+	scrapeErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "metrics_scrape_error"),
+		"1 se alguma consulta da última coleta falhou.",
+		nil, nil,
+	)
+)
+
+// databaseCollector consulta o banco a cada coleta, para que os valores reflitam o estado
+// atual sem depender de atualizações espalhadas pelos handlers
+type databaseCollector struct {
+	begin      BeginSystem
+	migrations MigrationStatus
+}
+
+func (d *databaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- migrationVersionDesc
+	ch <- migrationPendingDesc
+	ch <- reportsDesc
+	ch <- developersDesc
+	ch <- scrapeErrorsDesc
+}
+
+func (d *databaseCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	failed := 0.0
+
+	if version, pending, err := d.migrations(ctx); err != nil {
+		slog.Warn("Error collecting migration metrics", "error", err)
+		failed = 1
+	} else {
+		ch <- prometheus.MustNewConstMetric(migrationVersionDesc, prometheus.GaugeValue, float64(version))
+		ch <- prometheus.MustNewConstMetric(migrationPendingDesc, prometheus.GaugeValue, float64(pending))
+	}
+
+	if err := d.collectBusiness(ctx, ch); err != nil {
+		failed = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(scrapeErrorsDesc, prometheus.GaugeValue, failed)
+}
+
+// collectBusiness emite os indicadores de negócio, consultados em uma transação de sistema;
+// retorna erro se alguma consulta falhou
+func (d *databaseCollector) collectBusiness(ctx context.Context, ch chan<- prometheus.Metric) error {
+	tx, err := d.begin(ctx)
+	if err != nil {
+		slog.Warn("Error starting metrics transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var failed error
+
+	since := time.Now().UTC().AddDate(0, -(reportMonths - 1), 0).Format("2006-01")
+	err = collectRows(ctx, tx, ch, reportsDesc, `
+		SELECT d.company_id, pr.month, COUNT(*)
+		FROM performance_reports pr
+		JOIN developers d ON d.id = pr.developer_id
+		WHERE d.company_id IS NOT NULL AND pr.month >= $1
+		GROUP BY d.company_id, pr.month
+	`, since)
+	if err != nil {
+		slog.Warn("Error collecting report metrics", "error", err)
+		failed = err
+	}
+
+	err = collectRows(ctx, tx, ch, developersDesc, `
+		SELECT company_id, COUNT(*)
+		FROM developers
+		WHERE company_id IS NOT NULL AND archived_at IS NULL
+		GROUP BY company_id
+	`)
+	if err != nil {
+		slog.Warn("Error collecting developer metrics", "error", err)
+		failed = err
+	}
+
+	return failed
+}
+
+// collectRows emite um gauge por linha; as colunas são os rótulos de desc seguidos do valor
+func collectRows(ctx context.Context, tx *sql.Tx, ch chan<- prometheus.Metric, desc *prometheus.Desc, query string, args ...interface{}) error {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		labels := make([]string, len(columns)-1)
+		var value float64
+
+		targets := make([]interface{}, len(columns))
+		for i := range labels {
+			targets[i] = &labels[i]
+		}
+		targets[len(labels)] = &value
+
+		if err := rows.Scan(targets...); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,122 @@
+// Package metrics expõe as métricas da aplicação no formato do Prometheus: requisições HTTP,
+// pool de conexões, migrações e indicadores de negócio
+package metrics
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"tivix-performance-tracker-backend/apierror"
+)
+
+const namespace = "tivix"
+
+// Registry reúne todas as métricas da aplicação; não usa o registry global do client para
+// que apenas o que é registrado aqui seja exposto
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Requisições HTTP atendidas, por método, rota e status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latência das requisições HTTP, por método e rota.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method", "route"})
+
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Requisições HTTP em andamento.",
+	})
+
+	loginAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_login_attempts_total",
+		Help:      "Tentativas de login, por resultado (success, invalid_credentials, inactive_user, error).",
+	}, []string{"result"})
+)
+
+// Resultados de login contabilizados por ObserveLogin
+const (
+	LoginSuccess            = "success"
+	LoginInvalidCredentials = "invalid_credentials"
+	LoginInactiveUser       = "inactive_user"
+	LoginError              = "error"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		httpInFlight,
+		loginAttempts,
+	)
+
+	for _, result := range []string{LoginSuccess, LoginInvalidCredentials, LoginInactiveUser, LoginError} {
+		loginAttempts.WithLabelValues(result)
+	}
+}
+
+// ObserveLogin contabiliza uma tentativa de login com o resultado informado
+func ObserveLogin(result string) {
+	loginAttempts.WithLabelValues(result).Inc()
+}
+
+// Middleware registra contagem e latência de cada requisição. O rótulo de rota é o padrão
+// registrado no Fiber (ex.: /api/v1/developers/:id), nunca o caminho com IDs, para manter a
+// cardinalidade baixa; requisições barradas por um middleware (401, 429) ficam com o prefixo
+// em que ele foi registrado. Deve ser registrado antes do middleware de logging, que resolve os
+// erros dos handlers e define o status final.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		httpInFlight.Inc()
+		defer httpInFlight.Dec()
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = apierror.Status(err)
+		}
+
+		route := c.Route().Path
+		httpRequests.WithLabelValues(c.Method(), route, strconv.Itoa(status)).Inc()
+		httpDuration.WithLabelValues(c.Method(), route).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// Handler serve as métricas no formato texto do Prometheus. Com token não vazio, exige o
+// cabeçalho Authorization: Bearer <token>.
+func Handler(token string) fiber.Handler {
+	handler := adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+
+	return func(c *fiber.Ctx) error {
+		if token != "" {
+			expected := []byte("Bearer " + token)
+			if subtle.ConstantTimeCompare([]byte(c.Get(fiber.HeaderAuthorization)), expected) != 1 {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   true,
+					"message": "Token de métricas inválido",
+				})
+			}
+		}
+		return handler(c)
+	}
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/migrations"
+)
+
+func TestMiddlewareUsesRouteTemplate(t *testing.T) {
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/developers/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusConflict, "conflito")
+	})
+
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/developers/:id", "204"))
+	for _, path := range []string{"/developers/1", "/developers/2", "/fail"} {
+		if _, err := app.Test(httptest.NewRequest("GET", path, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/developers/:id", "204")) - before; got != 2 {
+		t.Errorf("esperava 2 requisições na rota /developers/:id, obteve %v", got)
+	}
+	if got := testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/fail", "409")); got != 1 {
+		t.Errorf("o status do erro do handler deveria ser contabilizado, obteve %v", got)
+	}
+}
+
+func TestHandlerRequiresToken(t *testing.T) {
+	app := fiber.New()
+	app.Get("/metrics", Handler("segredo"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("sem token: status %d", resp.StatusCode)
+	}
+
+	ObserveLogin(LoginSuccess)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer segredo")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != fiber.StatusOK || !strings.Contains(string(body), `tivix_auth_login_attempts_total{result="success"}`) {
+		t.Errorf("com token: status %d, corpo:\n%s", resp.StatusCode, body)
+	}
+}
+
+func TestDatabaseCollector(t *testing.T) {
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "metrics.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	manager := migrations.NewMigrationManager(db.DB)
+	manager.Dialect = db.DriverName()
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatal(err)
+	}
+
+	month := time.Now().UTC().Format("2006-01")
+	db.MustExec(`INSERT INTO companies (id, name) VALUES ('c1', 'Tivix')`)
+	db.MustExec(`INSERT INTO developers (id, name, role, company_id) VALUES ('d1', 'Ana', 'Dev', 'c1'), ('d2', 'Bia', 'Dev', 'c1')`)
+	db.MustExec(`INSERT INTO performance_reports (developer_id, month, question_scores, category_scores, weighted_average_score)
+		VALUES ('d1', $1, '{}', '{}', 4), ('d2', $1, '{}', '{}', 3), ('d1', '2001-01', '{}', '{}', 3)`, month)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&databaseCollector{
+		begin:      func(ctx context.Context) (*sql.Tx, error) { return db.BeginTx(ctx, nil) },
+		migrations: manager.Summary,
+	})
+
+	expected := `
+# HELP tivix_active_developers Desenvolvedores ativos por empresa.
+# TYPE tivix_active_developers gauge
+tivix_active_developers{company_id="c1"} 2
+# HELP tivix_metrics_scrape_error 1 se alguma consulta da última coleta falhou.
+# TYPE tivix_metrics_scrape_error gauge
+tivix_metrics_scrape_error 0
+# HELP tivix_performance_reports Relatórios de desempenho por empresa e mês de referência (últimos 12 meses).
+# TYPE tivix_performance_reports gauge
+tivix_performance_reports{company_id="c1",month="` + month + `"} 2
+# HELP tivix_schema_migration_version Versão da migração mais recente aplicada ao banco.
+# TYPE tivix_schema_migration_version gauge
+tivix_schema_migration_version 2
+# HELP tivix_schema_migrations_pending Migrações embutidas na aplicação ainda não aplicadas.
+# TYPE tivix_schema_migrations_pending gauge
+tivix_schema_migrations_pending 0
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
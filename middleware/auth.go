@@ -9,11 +9,21 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
+	"tivix-performance-tracker-backend/apierror"
 	"tivix-performance-tracker-backend/config"
+	"tivix-performance-tracker-backend/logging"
 	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/services"
+	"tivix-performance-tracker-backend/tracing"
 )
 
+// errJWTSecretMissing evita assinar ou aceitar tokens com chave vazia quando a configuração
+// não foi injetada
+var errJWTSecretMissing = errors.New("segredo JWT não configurado")
+
 type JWTClaims struct {
 	UserID              uuid.UUID  `json:"userId"`
 	Email               string     `json:"email"`
@@ -24,8 +34,11 @@ type JWTClaims struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateJWT(user models.User) (string, error) {
-	cfg := config.LoadConfig()
+// GenerateJWT emite o token de acesso do usuário, válido por cfg.AccessTokenTTL
+func GenerateJWT(cfg *config.Config, user models.User) (string, error) {
+	if cfg.JWTSecret == "" {
+		return "", errJWTSecretMissing
+	}
 
 	claims := JWTClaims{
 		UserID:              user.ID,
@@ -35,7 +48,7 @@ func GenerateJWT(user models.User) (string, error) {
 		IsActive:            user.IsActive,
 		NeedsPasswordChange: user.NeedsPasswordChange,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)), // Reduzido para 15 minutos
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "tivix-performance-tracker",
 		},
@@ -45,8 +58,10 @@ func GenerateJWT(user models.User) (string, error) {
 	return token.SignedString([]byte(cfg.JWTSecret))
 }
 
-func ValidateJWT(tokenString string) (*JWTClaims, error) {
-	cfg := config.LoadConfig()
+func ValidateJWT(cfg *config.Config, tokenString string) (*JWTClaims, error) {
+	if cfg.JWTSecret == "" {
+		return nil, errJWTSecretMissing
+	}
 
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -70,37 +85,39 @@ func AuthMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error":   true,
-				"message": "Token de autorização não fornecido",
-			})
+			return apierror.ErrMissingToken
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error":   true,
-				"message": "Formato de token inválido. Use 'Bearer <token>'",
-			})
+			return apierror.ErrInvalidAuthHeader
 		}
 
-		claims, err := ValidateJWT(tokenString)
+		_, span := tracing.Tracer().Start(c.UserContext(), "auth.validate_jwt")
+		claims, err := ValidateJWT(services.From(c).Config, tokenString)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error":   true,
-				"message": "Token inválido ou expirado",
-			})
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "token inválido")
+		} else {
+			span.SetAttributes(attribute.String("enduser.id", claims.UserID.String()), attribute.String("enduser.role", claims.Role))
+		}
+		span.End()
+		if err != nil {
+			return apierror.ErrInvalidToken
 		}
 
 		if !claims.IsActive {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   true,
-				"message": "Usuário inativo",
-			})
+			return apierror.ErrUserInactive
 		}
 
 		c.Locals("user", claims)
 
+		attrs := []any{"user_id", claims.UserID, "role", claims.Role}
+		if claims.CompanyID != nil {
+			attrs = append(attrs, "company_id", *claims.CompanyID)
+		}
+		logging.With(c, attrs...)
+
 		return c.Next()
 	}
 }
@@ -109,10 +126,7 @@ func ManagerOrAdminMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		user := c.Locals("user").(*JWTClaims)
 		if user.Role != "admin" && user.Role != "manager" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Acesso negado. Apenas administradores e gerentes têm permissão",
-			})
+			return apierror.ErrManagerOrAdminRequired
 		}
 		return c.Next()
 	}
@@ -122,10 +136,7 @@ func AdminOnlyMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		user := c.Locals("user").(*JWTClaims)
 		if user.Role != "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Acesso negado. Apenas administradores têm permissão",
-			})
+			return apierror.ErrAdminRequired
 		}
 		return c.Next()
 	}
@@ -150,11 +161,7 @@ func CheckPasswordChangeMiddleware() fiber.Handler {
 
 		user := c.Locals("user").(*JWTClaims)
 		if user.NeedsPasswordChange {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":                 "error",
-				"message":                "Você deve definir uma nova senha antes de continuar",
-				"requiresPasswordChange": true,
-			})
+			return apierror.ErrPasswordChangeRequired
 		}
 
 		return c.Next()
@@ -170,10 +177,7 @@ func CompanyAccessMiddleware() fiber.Handler {
 		}
 
 		if user.CompanyID == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Usuário deve estar associado a uma empresa",
-			})
+			return apierror.ErrCompanyMembershipRequired
 		}
 
 		return c.Next()
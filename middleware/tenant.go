@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/i18n"
+	"tivix-performance-tracker-backend/logging"
+)
+
+const tenantTxKey = "tenantTx"
+
+// TenantScopeMiddleware executa a requisição em uma transação com o contexto de RLS
+// da empresa do usuário. A transação é confirmada apenas em respostas de sucesso.
+func TenantScopeMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := c.Locals("user").(*JWTClaims)
+
+		readOnly := c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead
+		tx, err := database.BeginTenant(c.UserContext(), user.CompanyID, user.UserID, user.Role == "admin", readOnly)
+		if err != nil {
+			logging.From(c).Error("Error starting tenant transaction", "error", err)
+			return apierror.ErrInternal
+		}
+
+		c.Locals(tenantTxKey, tx)
+		if !i18n.Requested(c) && user.CompanyID != nil {
+			applyCompanyLocale(c, *user.CompanyID)
+		}
+
+		return runInTransaction(c, tx)
+	}
+}
+
+// SystemScopeMiddleware executa a requisição em uma transação de sistema, que ignora as
+// políticas de RLS. Restrito a login, inicialização e rotas exclusivas de administradores.
+func SystemScopeMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tx, err := database.BeginSystem(c.UserContext())
+		if err != nil {
+			logging.From(c).Error("Error starting system transaction", "error", err)
+			return apierror.ErrInternal
+		}
+
+		c.Locals(tenantTxKey, tx)
+		return runInTransaction(c, tx)
+	}
+}
+
+// runInTransaction executa o restante da cadeia e confirma tx apenas em respostas de sucesso
+func runInTransaction(c *fiber.Ctx, tx *sqlx.Tx) error {
+	defer tx.Rollback()
+
+	if err := c.Next(); err != nil {
+		return err
+	}
+
+	if c.Response().StatusCode() >= fiber.StatusBadRequest {
+		return nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		logging.From(c).Error("Error committing transaction", "error", err)
+		return apierror.ErrInternal
+	}
+
+	return nil
+}
+
+// applyCompanyLocale usa o idioma configurado para a empresa nas mensagens da requisição
+// quando o cliente não pediu um idioma suportado no Accept-Language
+func applyCompanyLocale(c *fiber.Ctx, companyID uuid.UUID) {
+	var locale string
+	err := TenantDB(c).Get(&locale, "SELECT locale FROM company_settings WHERE company_id = $1", companyID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logging.From(c).Warn("Error querying company locale", "error", err)
+		}
+		return
+	}
+	i18n.SetFallback(c, locale)
+}
+
+// TenantDB retorna a transação da requisição (TenantScopeMiddleware ou SystemScopeMiddleware)
+// ou, fora delas, o pool de conexões, sujeito às políticas de RLS sem nenhum contexto; cada
+// comando é registrado no trace da requisição. Sem banco conectado (testes de handlers com
+// repositórios em memória) retorna nil.
+func TenantDB(c *fiber.Ctx) database.Querier {
+	if tx, ok := c.Locals(tenantTxKey).(database.Querier); ok {
+		return database.Trace(c.UserContext(), tx)
+	}
+	if database.DB == nil {
+		return nil
+	}
+	return database.Trace(c.UserContext(), database.DB)
+}
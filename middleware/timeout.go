@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/logging"
+)
+
+// RequestTimeout limita o contexto da requisição (c.UserContext) a timeout. As queries
+// executadas por TenantDB e pelas conexões da requisição usam esse contexto e são canceladas
+// no banco quando o prazo expira. A resposta vira 504 quando o erro do handler vem do prazo
+// (envolve context.DeadlineExceeded) ou quando a requisição falhou com 5xx depois que o prazo
+// expirou: os handlers costumam responder INTERNAL_ERROR sem guardar a causa, e a falha de uma
+// query cancelada pelo prazo não deve aparecer como erro interno. Respostas de sucesso e
+// erros 4xx são mantidos, mesmo depois do prazo.
+//
+// Cancelar as queries quando o cliente desconecta está fora do escopo: o fasthttp não
+// avisa o handler quando a conexão é fechada, então é o prazo que impede uma query lenta
+// de continuar rodando depois que ninguém espera pela resposta.
+func RequestTimeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if !timedOut(c, ctx, err) {
+			return err
+		}
+
+		logging.From(c).Warn("Request timed out", "timeout", timeout.String(), "error", err)
+		return apierror.ErrRequestTimeout
+	}
+}
+
+// timedOut indica se a resposta da requisição deve ser substituída por 504
+func timedOut(c *fiber.Ctx, ctx context.Context, err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+	return responseStatus(c, err) >= fiber.StatusInternalServerError
+}
+
+// responseStatus é o status que a requisição vai responder: o do erro retornado ou, sem erro,
+// o já escrito pelo handler
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	return apierror.Status(err)
+}
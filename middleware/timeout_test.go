@@ -0,0 +1,128 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/middleware"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "timeout.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var queryErr error
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Use(middleware.RequestTimeout(50 * time.Millisecond))
+	app.Get("/fast", func(c *fiber.Ctx) error {
+		var value int
+		if err := database.Trace(c.UserContext(), db).Get(&value, "SELECT 1"); err != nil {
+			return err
+		}
+		return c.JSON(value)
+	})
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+
+		var value int
+		queryErr = database.Trace(c.UserContext(), db).Get(&value, "SELECT 1")
+		return apierror.ErrInternal.Wrap(queryErr)
+	})
+	app.Get("/query", func(c *fiber.Ctx) error {
+		// Query que só termina quando é cancelada; o handler responde INTERNAL_ERROR sem a causa,
+		// como a maioria dos handlers
+		var count int
+		err := database.Trace(c.UserContext(), db).Get(&count,
+			"WITH RECURSIVE n(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM n) SELECT COUNT(*) FROM n")
+		if err != nil {
+			return apierror.ErrInternal
+		}
+		return c.JSON(count)
+	})
+	app.Get("/responded", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.JSON(fiber.Map{"partial": true})
+	})
+	app.Get("/handled", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return apierror.ErrDeveloperNotFound
+	})
+	app.Get("/internal", func(c *fiber.Ctx) error {
+		return apierror.ErrInternal
+	})
+
+	cases := []struct {
+		path   string
+		status int
+	}{
+		{"/fast", fiber.StatusOK},
+		// O erro do handler vem do prazo: 504
+		{"/slow", fiber.StatusGatewayTimeout},
+		// Falha 5xx depois do prazo, sem a causa no erro: 504
+		{"/query", fiber.StatusGatewayTimeout},
+		// Respostas de sucesso escritas depois do prazo são mantidas
+		{"/responded", fiber.StatusOK},
+		// Erros 4xx seguem com o próprio status, mesmo depois do prazo
+		{"/handled", fiber.StatusNotFound},
+		// Erros internos antes do prazo seguem como 500
+		{"/internal", fiber.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		resp, err := app.Test(httptest.NewRequest("GET", tc.path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: status %d; esperado %d", tc.path, resp.StatusCode, tc.status)
+		}
+	}
+
+	if !errors.Is(queryErr, context.DeadlineExceeded) {
+		t.Errorf("a query após o prazo deveria ser cancelada, obteve %v", queryErr)
+	}
+}
+
+// Com o prazo expirado a transação da requisição não pode ser confirmada; a resposta de
+// sucesso do handler não chegou ao banco e vira 504
+func TestRequestTimeoutDuringCommit(t *testing.T) {
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "commit.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Use(middleware.RequestTimeout(50 * time.Millisecond))
+	app.Get("/late", func(c *fiber.Ctx) error {
+		c.Locals("user", &middleware.JWTClaims{UserID: uuid.New(), Role: "admin"})
+		return c.Next()
+	}, middleware.TenantScopeMiddleware(), func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.JSON(fiber.Map{"success": true})
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/late", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusGatewayTimeout {
+		t.Errorf("status %d; esperado 504", resp.StatusCode)
+	}
+}
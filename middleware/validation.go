@@ -7,12 +7,15 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/apierror"
 )
 
 var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	validate.RegisterTagNameFunc(apierror.JSONFieldName)
 
 	validate.RegisterValidation("no_html", ValidateNoHTML)
 	validate.RegisterValidation("safe_string", ValidateSafeString)
@@ -42,17 +45,11 @@ func ValidateStruct() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var body interface{}
 		if err := c.BodyParser(&body); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error":   true,
-				"message": "Dados inválidos no corpo da requisição",
-			})
+			return apierror.ErrInvalidBody
 		}
 
 		if body == nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error":   true,
-				"message": "Corpo da requisição obrigatório",
-			})
+			return apierror.ErrInvalidBody
 		}
 
 		return c.Next()
@@ -108,10 +105,7 @@ func sanitizeStruct(s interface{}) {
 func InputSizeLimit(maxSize int) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		if len(c.Body()) > maxSize {
-			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
-				"error":   true,
-				"message": "Requisição muito grande",
-			})
+			return apierror.ErrRequestTooLarge
 		}
 		return c.Next()
 	}
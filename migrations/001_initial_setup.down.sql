@@ -0,0 +1,7 @@
+-- ============================================
+-- Migração 001 (rollback): Configuração Inicial PostgreSQL
+-- ============================================
+-- Descrição: Remove a extensão uuid-ossp
+-- ============================================
+
+DROP EXTENSION IF EXISTS "uuid-ossp";
@@ -0,0 +1,11 @@
+-- ============================================
+-- Migração 002 (rollback): Criação das Tabelas Principais
+-- ============================================
+-- Descrição: Remove as tabelas principais do sistema
+-- ============================================
+
+DROP TABLE IF EXISTS performance_reports;
+DROP TABLE IF EXISTS developers;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS companies;
@@ -0,0 +1,19 @@
+-- ============================================
+-- Migração 003 (rollback): Criação de Índices para Performance
+-- ============================================
+-- Descrição: Remove os índices das tabelas principais
+-- ============================================
+
+DROP INDEX IF EXISTS idx_performance_reports_developer_month;
+DROP INDEX IF EXISTS idx_performance_reports_month;
+DROP INDEX IF EXISTS idx_performance_reports_developer_id;
+DROP INDEX IF EXISTS idx_developers_archived_at;
+DROP INDEX IF EXISTS idx_developers_company_id;
+DROP INDEX IF EXISTS idx_developers_team_id;
+DROP INDEX IF EXISTS idx_teams_company_id;
+DROP INDEX IF EXISTS idx_users_company_id;
+DROP INDEX IF EXISTS idx_users_is_active;
+DROP INDEX IF EXISTS idx_users_role;
+DROP INDEX IF EXISTS idx_users_email;
+DROP INDEX IF EXISTS idx_companies_is_active;
+DROP INDEX IF EXISTS idx_companies_name;
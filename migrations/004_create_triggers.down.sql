@@ -0,0 +1,13 @@
+-- ============================================
+-- Migração 004 (rollback): Configuração de Triggers para Timestamps
+-- ============================================
+-- Descrição: Remove os triggers de updated_at e a função compartilhada
+-- ============================================
+
+DROP TRIGGER IF EXISTS update_performance_reports_updated_at ON performance_reports;
+DROP TRIGGER IF EXISTS update_developers_updated_at ON developers;
+DROP TRIGGER IF EXISTS update_teams_updated_at ON teams;
+DROP TRIGGER IF EXISTS update_users_updated_at ON users;
+DROP TRIGGER IF EXISTS update_companies_updated_at ON companies;
+
+DROP FUNCTION IF EXISTS update_updated_at_column();
@@ -0,0 +1,8 @@
+-- ============================================
+-- Migração 005 (rollback): Implementação do Sistema Multitenant
+-- ============================================
+-- Descrição: Nada a desfazer
+-- ============================================
+
+-- As colunas company_id já fazem parte das tabelas criadas na migração 002 e os índices
+-- são os mesmos da migração 003; removê-los aqui deixaria o schema abaixo da 004 inconsistente.
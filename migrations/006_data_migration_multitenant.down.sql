@@ -0,0 +1,8 @@
+-- ============================================
+-- Migração 006 (rollback): Migração de Dados para Multitenancy
+-- ============================================
+-- Descrição: Nada a desfazer
+-- ============================================
+
+-- Migração apenas de dados: a empresa padrão e os vínculos de company_id são mantidos,
+-- pois não há como distinguir os registros migrados dos criados depois.
@@ -0,0 +1,8 @@
+-- ============================================
+-- Migração 007 (rollback): Planos de Desenvolvimento Individual (Metas)
+-- ============================================
+-- Descrição: Remove as tabelas de metas e de atualizações de progresso
+-- ============================================
+
+DROP TABLE IF EXISTS goal_progress_updates;
+DROP TABLE IF EXISTS goals;
@@ -0,0 +1,51 @@
+-- ============================================
+-- Migração 007: Planos de Desenvolvimento Individual (Metas)
+-- ============================================
+-- Descrição: Cria as tabelas de metas por desenvolvedor e de atualizações de progresso
+-- Data: 2025-08-20
+-- Versão: v1.2.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+-- Tabela de metas dos desenvolvedores
+CREATE TABLE IF NOT EXISTS goals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    developer_id UUID NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    company_id UUID REFERENCES companies(id) ON DELETE CASCADE,
+    report_id UUID REFERENCES performance_reports(id) ON DELETE SET NULL,
+    owner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    categories TEXT[] NOT NULL DEFAULT '{}',
+    due_date DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'in_progress', 'completed', 'cancelled')),
+    progress INTEGER NOT NULL DEFAULT 0 CHECK (progress >= 0 AND progress <= 100),
+    completed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Histórico de atualizações de progresso das metas
+CREATE TABLE IF NOT EXISTS goal_progress_updates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    goal_id UUID NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    progress INTEGER NOT NULL CHECK (progress >= 0 AND progress <= 100),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('open', 'in_progress', 'completed', 'cancelled')),
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Índices
+CREATE INDEX IF NOT EXISTS idx_goals_developer_id ON goals(developer_id);
+CREATE INDEX IF NOT EXISTS idx_goals_company_id ON goals(company_id);
+CREATE INDEX IF NOT EXISTS idx_goals_report_id ON goals(report_id);
+CREATE INDEX IF NOT EXISTS idx_goals_status ON goals(status);
+CREATE INDEX IF NOT EXISTS idx_goal_progress_updates_goal_id ON goal_progress_updates(goal_id);
+
+-- Trigger para updated_at
+DROP TRIGGER IF EXISTS update_goals_updated_at ON goals;
+CREATE TRIGGER update_goals_updated_at
+    BEFORE UPDATE ON goals
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+-- ============================================
+-- Migração 008 (rollback): Reuniões 1:1
+-- ============================================
+-- Descrição: Remove as tabelas de reuniões 1:1 e o vínculo entre desenvolvedores e usuários
+-- ============================================
+
+DROP TABLE IF EXISTS one_on_one_action_items;
+DROP TABLE IF EXISTS one_on_ones;
+
+ALTER TABLE developers DROP COLUMN IF EXISTS user_id;
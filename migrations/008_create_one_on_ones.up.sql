@@ -0,0 +1,65 @@
+-- ============================================
+-- Migração 008: Reuniões 1:1
+-- ============================================
+-- Descrição: Vincula desenvolvedores a usuários e cria as tabelas de reuniões 1:1 e itens de ação
+-- Data: 2025-08-22
+-- Versão: v1.2.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+-- Usuário do sistema vinculado ao desenvolvedor (permite compartilhar conteúdo com ele)
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='developers' AND column_name='user_id') THEN
+        ALTER TABLE developers ADD COLUMN user_id UUID REFERENCES users(id) ON DELETE SET NULL;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_developers_user_id ON developers(user_id);
+
+-- Tabela de reuniões 1:1
+CREATE TABLE IF NOT EXISTS one_on_ones (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    developer_id UUID NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    company_id UUID REFERENCES companies(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    meeting_date DATE NOT NULL,
+    agenda TEXT[] NOT NULL DEFAULT '{}',
+    notes TEXT,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'shared')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Itens de ação definidos nas reuniões 1:1
+CREATE TABLE IF NOT EXISTS one_on_one_action_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    one_on_one_id UUID NOT NULL REFERENCES one_on_ones(id) ON DELETE CASCADE,
+    description TEXT NOT NULL,
+    owner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    due_date DATE,
+    completed_at TIMESTAMP NULL,
+    completed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Índices
+CREATE INDEX IF NOT EXISTS idx_one_on_ones_developer_id ON one_on_ones(developer_id);
+CREATE INDEX IF NOT EXISTS idx_one_on_ones_company_id ON one_on_ones(company_id);
+CREATE INDEX IF NOT EXISTS idx_one_on_ones_meeting_date ON one_on_ones(meeting_date);
+CREATE INDEX IF NOT EXISTS idx_one_on_one_action_items_one_on_one_id ON one_on_one_action_items(one_on_one_id);
+CREATE INDEX IF NOT EXISTS idx_one_on_one_action_items_owner_id ON one_on_one_action_items(owner_id);
+
+-- Triggers para updated_at
+DROP TRIGGER IF EXISTS update_one_on_ones_updated_at ON one_on_ones;
+CREATE TRIGGER update_one_on_ones_updated_at
+    BEFORE UPDATE ON one_on_ones
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_one_on_one_action_items_updated_at ON one_on_one_action_items;
+CREATE TRIGGER update_one_on_one_action_items_updated_at
+    BEFORE UPDATE ON one_on_one_action_items
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,8 @@
+-- ============================================
+-- Migração 009 (rollback): Comentários em Relatórios de Performance
+-- ============================================
+-- Descrição: Remove as tabelas de comentários e de controle de leitura
+-- ============================================
+
+DROP TABLE IF EXISTS report_comment_reads;
+DROP TABLE IF EXISTS report_comments;
@@ -0,0 +1,44 @@
+-- ============================================
+-- Migração 009: Comentários em Relatórios de Performance
+-- ============================================
+-- Descrição: Cria as tabelas de comentários encadeados e de controle de leitura por usuário
+-- Data: 2025-08-25
+-- Versão: v1.2.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+-- Comentários (com respostas via parent_id)
+CREATE TABLE IF NOT EXISTS report_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    report_id UUID NOT NULL REFERENCES performance_reports(id) ON DELETE CASCADE,
+    company_id UUID REFERENCES companies(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    parent_id UUID REFERENCES report_comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    mentions UUID[] NOT NULL DEFAULT '{}',
+    edited_at TIMESTAMP NULL,
+    deleted_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Última leitura dos comentários de cada relatório por usuário
+CREATE TABLE IF NOT EXISTS report_comment_reads (
+    report_id UUID NOT NULL REFERENCES performance_reports(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (report_id, user_id)
+);
+
+-- Índices
+CREATE INDEX IF NOT EXISTS idx_report_comments_report_id ON report_comments(report_id);
+CREATE INDEX IF NOT EXISTS idx_report_comments_company_id ON report_comments(company_id);
+CREATE INDEX IF NOT EXISTS idx_report_comments_parent_id ON report_comments(parent_id);
+CREATE INDEX IF NOT EXISTS idx_report_comments_mentions ON report_comments USING GIN (mentions);
+
+-- Trigger para updated_at
+DROP TRIGGER IF EXISTS update_report_comments_updated_at ON report_comments;
+CREATE TRIGGER update_report_comments_updated_at
+    BEFORE UPDATE ON report_comments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
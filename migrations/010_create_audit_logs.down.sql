@@ -0,0 +1,13 @@
+-- ============================================
+-- Migração 010 (rollback): Log de Auditoria
+-- ============================================
+-- Descrição: Remove o log de auditoria e as colunas de autoria
+-- ============================================
+
+DROP TABLE IF EXISTS audit_logs;
+DROP FUNCTION IF EXISTS prevent_audit_log_changes();
+
+ALTER TABLE performance_reports DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE performance_reports DROP COLUMN IF EXISTS created_by;
+ALTER TABLE developers DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE developers DROP COLUMN IF EXISTS created_by;
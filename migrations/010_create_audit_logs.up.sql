@@ -0,0 +1,66 @@
+-- ============================================
+-- Migração 010: Log de Auditoria
+-- ============================================
+-- Descrição: Cria o log de auditoria somente-inserção e adiciona autoria em desenvolvedores e relatórios
+-- Data: 2025-08-28
+-- Versão: v1.3.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+-- Log de auditoria de todas as operações de escrita.
+-- actor_id e company_id não possuem chave estrangeira para que o histórico
+-- sobreviva à exclusão de usuários e empresas.
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID,
+    actor_email VARCHAR(255),
+    actor_role VARCHAR(50),
+    company_id UUID,
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(100) NOT NULL,
+    entity_id UUID,
+    before_data JSONB,
+    after_data JSONB,
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    method VARCHAR(10),
+    path TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_company_id ON audit_logs(company_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at);
+
+-- Impede alterações e exclusões no log de auditoria
+CREATE OR REPLACE FUNCTION prevent_audit_log_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs é somente inserção';
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS prevent_audit_logs_update_delete ON audit_logs;
+CREATE TRIGGER prevent_audit_logs_update_delete
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_audit_log_changes();
+
+-- Autoria de desenvolvedores e relatórios
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='developers' AND column_name='created_by') THEN
+        ALTER TABLE developers ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='developers' AND column_name='updated_by') THEN
+        ALTER TABLE developers ADD COLUMN updated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='performance_reports' AND column_name='created_by') THEN
+        ALTER TABLE performance_reports ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='performance_reports' AND column_name='updated_by') THEN
+        ALTER TABLE performance_reports ADD COLUMN updated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+    END IF;
+END $$;
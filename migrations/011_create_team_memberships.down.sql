@@ -0,0 +1,12 @@
+-- ============================================
+-- Migração 011 (rollback): Histórico de Times dos Desenvolvedores
+-- ============================================
+-- Descrição: Remove o histórico de times e o time dos relatórios
+-- ============================================
+
+DROP TRIGGER IF EXISTS track_developers_team_membership ON developers;
+DROP FUNCTION IF EXISTS track_developer_team_membership();
+
+ALTER TABLE performance_reports DROP COLUMN IF EXISTS team_id;
+
+DROP TABLE IF EXISTS team_memberships;
@@ -0,0 +1,85 @@
+-- ============================================
+-- Migração 011: Histórico de Times dos Desenvolvedores
+-- ============================================
+-- Descrição: Registra os períodos de participação em times e o time de cada relatório
+-- Data: 2025-09-01
+-- Versão: v1.3.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+-- Períodos de participação de desenvolvedores em times.
+-- team_id não possui chave estrangeira para que o histórico sobreviva
+-- à exclusão do time; team_name guarda o nome no momento da entrada.
+CREATE TABLE IF NOT EXISTS team_memberships (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    developer_id UUID NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    team_id UUID NOT NULL,
+    team_name VARCHAR(255) NOT NULL DEFAULT '',
+    company_id UUID REFERENCES companies(id) ON DELETE CASCADE,
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    left_at TIMESTAMP NULL,
+    CHECK (left_at IS NULL OR left_at >= joined_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_memberships_developer_id ON team_memberships(developer_id);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_team_id ON team_memberships(team_id);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_company_id ON team_memberships(company_id);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_period ON team_memberships(team_id, joined_at, left_at);
+
+-- Um desenvolvedor participa de no máximo um time por vez
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_memberships_open
+    ON team_memberships(developer_id) WHERE left_at IS NULL;
+
+-- Mantém o histórico sempre que developers.team_id muda (criação, edição ou exclusão do time)
+CREATE OR REPLACE FUNCTION track_developer_team_membership()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.team_id IS NOT DISTINCT FROM OLD.team_id THEN
+        RETURN NEW;
+    END IF;
+
+    UPDATE team_memberships
+    SET left_at = CURRENT_TIMESTAMP
+    WHERE developer_id = NEW.id AND left_at IS NULL;
+
+    IF NEW.team_id IS NOT NULL THEN
+        INSERT INTO team_memberships (developer_id, team_id, team_name, company_id, joined_at)
+        SELECT NEW.id, NEW.team_id, t.name, NEW.company_id, CURRENT_TIMESTAMP
+        FROM teams t
+        WHERE t.id = NEW.team_id;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS track_developers_team_membership ON developers;
+CREATE TRIGGER track_developers_team_membership
+    AFTER INSERT OR UPDATE OF team_id ON developers
+    FOR EACH ROW
+    EXECUTE FUNCTION track_developer_team_membership();
+
+-- Time do desenvolvedor no momento da criação do relatório.
+-- Sem chave estrangeira pelo mesmo motivo de team_memberships.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='performance_reports' AND column_name='team_id') THEN
+        ALTER TABLE performance_reports ADD COLUMN team_id UUID;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_performance_reports_team_id ON performance_reports(team_id);
+
+-- Carga inicial: o time atual é a melhor informação disponível para os dados existentes
+INSERT INTO team_memberships (developer_id, team_id, team_name, company_id, joined_at)
+SELECT d.id, d.team_id, t.name, d.company_id, COALESCE(d.created_at, CURRENT_TIMESTAMP)
+FROM developers d
+INNER JOIN teams t ON t.id = d.team_id
+WHERE NOT EXISTS (
+    SELECT 1 FROM team_memberships tm WHERE tm.developer_id = d.id
+);
+
+UPDATE performance_reports pr
+SET team_id = d.team_id
+FROM developers d
+WHERE pr.developer_id = d.id AND pr.team_id IS NULL;
@@ -0,0 +1,11 @@
+-- ============================================
+-- Migração 012 (rollback): Hierarquia de Times
+-- ============================================
+-- Descrição: Remove a hierarquia de unidades dos times
+-- ============================================
+
+DROP TRIGGER IF EXISTS validate_teams_hierarchy ON teams;
+DROP FUNCTION IF EXISTS validate_team_hierarchy();
+
+ALTER TABLE teams DROP COLUMN IF EXISTS kind;
+ALTER TABLE teams DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,61 @@
+-- ============================================
+-- Migração 012: Hierarquia de Times
+-- ============================================
+-- Descrição: Adiciona hierarquia de unidades (departamentos → times → squads) aos times
+-- Data: 2025-09-03
+-- Versão: v1.3.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='teams' AND column_name='parent_id') THEN
+        ALTER TABLE teams ADD COLUMN parent_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='teams' AND column_name='kind') THEN
+        ALTER TABLE teams ADD COLUMN kind VARCHAR(20) NOT NULL DEFAULT 'team'
+            CHECK (kind IN ('department', 'team', 'squad'));
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_teams_parent_id ON teams(parent_id);
+
+-- Impede ciclos e unidades pai de outra empresa
+CREATE OR REPLACE FUNCTION validate_team_hierarchy()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    IF NEW.parent_id = NEW.id THEN
+        RAISE EXCEPTION 'um time não pode ser pai de si mesmo';
+    END IF;
+
+    IF NOT EXISTS (
+        SELECT 1 FROM teams
+        WHERE id = NEW.parent_id AND company_id IS NOT DISTINCT FROM NEW.company_id
+    ) THEN
+        RAISE EXCEPTION 'o time pai deve pertencer à mesma empresa';
+    END IF;
+
+    IF EXISTS (
+        WITH RECURSIVE ancestors AS (
+            SELECT id, parent_id FROM teams WHERE id = NEW.parent_id
+            UNION
+            SELECT t.id, t.parent_id FROM teams t INNER JOIN ancestors a ON t.id = a.parent_id
+        )
+        SELECT 1 FROM ancestors WHERE id = NEW.id
+    ) THEN
+        RAISE EXCEPTION 'a hierarquia de times não pode conter ciclos';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS validate_teams_hierarchy ON teams;
+CREATE TRIGGER validate_teams_hierarchy
+    BEFORE INSERT OR UPDATE OF parent_id, company_id ON teams
+    FOR EACH ROW
+    EXECUTE FUNCTION validate_team_hierarchy();
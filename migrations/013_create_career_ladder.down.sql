@@ -0,0 +1,12 @@
+-- ============================================
+-- Migração 013 (rollback): Trilha de Carreira
+-- ============================================
+-- Descrição: Remove as trilhas e níveis de carreira e o histórico de promoções
+-- ============================================
+
+DROP TABLE IF EXISTS developer_level_changes;
+
+ALTER TABLE developers DROP COLUMN IF EXISTS level_id;
+
+DROP TABLE IF EXISTS career_levels;
+DROP TABLE IF EXISTS career_tracks;
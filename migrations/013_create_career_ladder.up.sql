@@ -0,0 +1,76 @@
+-- ============================================
+-- Migração 013: Trilha de Carreira
+-- ============================================
+-- Descrição: Cria trilhas e níveis de carreira por empresa, nível dos desenvolvedores e histórico de promoções
+-- Data: 2025-09-08
+-- Versão: v1.3.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+-- Trilhas de carreira (ex.: Engenharia, Gestão)
+CREATE TABLE IF NOT EXISTS career_tracks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (company_id, name)
+);
+
+-- Níveis de senioridade de cada trilha, ordenados por rank
+CREATE TABLE IF NOT EXISTS career_levels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    track_id UUID NOT NULL REFERENCES career_tracks(id) ON DELETE CASCADE,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    rank INTEGER NOT NULL CHECK (rank >= 1),
+    expectations TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (track_id, rank),
+    UNIQUE (track_id, code)
+);
+
+-- Nível atual do desenvolvedor
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='developers' AND column_name='level_id') THEN
+        ALTER TABLE developers ADD COLUMN level_id UUID REFERENCES career_levels(id) ON DELETE SET NULL;
+    END IF;
+END $$;
+
+-- Histórico de mudanças de nível (promoções, rebaixamentos e movimentações laterais)
+CREATE TABLE IF NOT EXISTS developer_level_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    developer_id UUID NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    company_id UUID REFERENCES companies(id) ON DELETE CASCADE,
+    from_level_id UUID REFERENCES career_levels(id) ON DELETE SET NULL,
+    to_level_id UUID REFERENCES career_levels(id) ON DELETE SET NULL,
+    change_type VARCHAR(20) NOT NULL CHECK (change_type IN ('initial', 'promotion', 'demotion', 'lateral')),
+    effective_date DATE NOT NULL,
+    justification TEXT NOT NULL DEFAULT '',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_career_tracks_company_id ON career_tracks(company_id);
+CREATE INDEX IF NOT EXISTS idx_career_levels_track_id ON career_levels(track_id);
+CREATE INDEX IF NOT EXISTS idx_career_levels_company_id ON career_levels(company_id);
+CREATE INDEX IF NOT EXISTS idx_developers_level_id ON developers(level_id);
+CREATE INDEX IF NOT EXISTS idx_developer_level_changes_developer_id ON developer_level_changes(developer_id);
+CREATE INDEX IF NOT EXISTS idx_developer_level_changes_company_id ON developer_level_changes(company_id);
+CREATE INDEX IF NOT EXISTS idx_developer_level_changes_effective_date ON developer_level_changes(effective_date);
+
+DROP TRIGGER IF EXISTS update_career_tracks_updated_at ON career_tracks;
+CREATE TRIGGER update_career_tracks_updated_at
+    BEFORE UPDATE ON career_tracks
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_career_levels_updated_at ON career_levels;
+CREATE TRIGGER update_career_levels_updated_at
+    BEFORE UPDATE ON career_levels
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- ============================================
+-- Migração 014 (rollback): Matriz de Competências
+-- ============================================
+-- Descrição: Remove o catálogo de competências e a proficiência dos desenvolvedores
+-- ============================================
+
+DROP TABLE IF EXISTS developer_skills;
+DROP TABLE IF EXISTS developer_skill_assessments;
+DROP TABLE IF EXISTS skills;
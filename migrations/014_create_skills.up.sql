@@ -0,0 +1,65 @@
+-- ============================================
+-- Migração 014: Matriz de Competências
+-- ============================================
+-- Descrição: Cria o catálogo de competências por empresa e a proficiência dos desenvolvedores com histórico
+-- Data: 2025-09-12
+-- Versão: v1.3.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+-- Catálogo de competências da empresa (ex.: Kubernetes, Go, Comunicação)
+CREATE TABLE IF NOT EXISTS skills (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skills_company_name ON skills(company_id, LOWER(name));
+
+-- Histórico de avaliações de proficiência (1 a 5), autodeclaradas ou validadas pelo gestor
+CREATE TABLE IF NOT EXISTS developer_skill_assessments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    developer_id UUID NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    skill_id UUID NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    company_id UUID REFERENCES companies(id) ON DELETE CASCADE,
+    level INTEGER NOT NULL CHECK (level BETWEEN 1 AND 5),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('self', 'manager')),
+    assessed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Proficiência atual de cada desenvolvedor (última avaliação de cada origem)
+CREATE TABLE IF NOT EXISTS developer_skills (
+    developer_id UUID NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    skill_id UUID NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    company_id UUID REFERENCES companies(id) ON DELETE CASCADE,
+    self_level INTEGER CHECK (self_level BETWEEN 1 AND 5),
+    validated_level INTEGER CHECK (validated_level BETWEEN 1 AND 5),
+    validated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    validated_at TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (developer_id, skill_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_skills_company_id ON skills(company_id);
+CREATE INDEX IF NOT EXISTS idx_developer_skill_assessments_developer_skill ON developer_skill_assessments(developer_id, skill_id);
+CREATE INDEX IF NOT EXISTS idx_developer_skill_assessments_company_id ON developer_skill_assessments(company_id);
+CREATE INDEX IF NOT EXISTS idx_developer_skills_skill_level ON developer_skills(skill_id, validated_level, self_level);
+CREATE INDEX IF NOT EXISTS idx_developer_skills_company_id ON developer_skills(company_id);
+
+DROP TRIGGER IF EXISTS update_skills_updated_at ON skills;
+CREATE TRIGGER update_skills_updated_at
+    BEFORE UPDATE ON skills
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_developer_skills_updated_at ON developer_skills;
+CREATE TRIGGER update_developer_skills_updated_at
+    BEFORE UPDATE ON developer_skills
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
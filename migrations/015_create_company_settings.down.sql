@@ -0,0 +1,11 @@
+-- ============================================
+-- Migração 015 (rollback): Configurações da Empresa
+-- ============================================
+-- Descrição: Remove as configurações por empresa e restaura a precisão original das notas
+-- ============================================
+
+DROP TABLE IF EXISTS company_settings;
+
+-- Falha (e desfaz o rollback) se houver notas fora de DECIMAL(4,2), em vez de truncá-las
+ALTER TABLE performance_reports ALTER COLUMN weighted_average_score TYPE DECIMAL(4,2);
+ALTER TABLE developers ALTER COLUMN latest_performance_score TYPE DECIMAL(4,2);
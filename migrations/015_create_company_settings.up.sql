@@ -0,0 +1,40 @@
+-- ============================================
+-- Migração 015: Configurações da Empresa
+-- ============================================
+-- Descrição: Cria as configurações por empresa (escala de notas, idioma, fuso horário, ano fiscal e paleta de cores)
+-- Data: 2025-09-16
+-- Versão: v1.3.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS company_settings (
+    company_id UUID PRIMARY KEY REFERENCES companies(id) ON DELETE CASCADE,
+    score_min DECIMAL(7,3) NOT NULL DEFAULT 0,
+    score_max DECIMAL(7,3) NOT NULL DEFAULT 10,
+    score_step DECIMAL(7,3) NOT NULL DEFAULT 0.01,
+    decimal_places INTEGER NOT NULL DEFAULT 2 CHECK (decimal_places BETWEEN 0 AND 3),
+    locale VARCHAR(20) NOT NULL DEFAULT 'pt-BR',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'America/Sao_Paulo',
+    fiscal_year_start_month INTEGER NOT NULL DEFAULT 1 CHECK (fiscal_year_start_month BETWEEN 1 AND 12),
+    team_color_palette TEXT[] NOT NULL DEFAULT '{blue,green,purple,orange,red,teal}',
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (score_max > score_min),
+    CHECK (score_step > 0)
+);
+
+DROP TRIGGER IF EXISTS update_company_settings_updated_at ON company_settings;
+CREATE TRIGGER update_company_settings_updated_at
+    BEFORE UPDATE ON company_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Escalas configuráveis exigem mais dígitos que DECIMAL(4,2)
+ALTER TABLE performance_reports ALTER COLUMN weighted_average_score TYPE DECIMAL(7,3);
+ALTER TABLE developers ALTER COLUMN latest_performance_score TYPE DECIMAL(7,3);
+
+-- Empresas existentes recebem as configurações padrão (escala 0-10, equivalente ao comportamento anterior)
+INSERT INTO company_settings (company_id)
+SELECT id FROM companies
+ON CONFLICT (company_id) DO NOTHING;
@@ -0,0 +1,26 @@
+-- ============================================
+-- Migração 016 (rollback): Isolamento de Empresas com RLS
+-- ============================================
+-- Descrição: Desativa row-level security e remove as políticas e funções de contexto
+-- ============================================
+
+DO $$
+DECLARE
+    tbl TEXT;
+BEGIN
+    FOREACH tbl IN ARRAY ARRAY[
+        'companies', 'users', 'performance_reports', 'goal_progress_updates',
+        'one_on_one_action_items', 'report_comment_reads',
+        'teams', 'developers', 'goals', 'one_on_ones', 'report_comments', 'audit_logs',
+        'team_memberships', 'career_tracks', 'career_levels', 'developer_level_changes',
+        'skills', 'developer_skill_assessments', 'developer_skills', 'company_settings'
+    ] LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', tbl);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', tbl);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', tbl);
+    END LOOP;
+END $$;
+
+DROP FUNCTION IF EXISTS app_rls_bypass();
+DROP FUNCTION IF EXISTS app_current_user_id();
+DROP FUNCTION IF EXISTS app_current_company_id();
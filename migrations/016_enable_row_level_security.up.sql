@@ -0,0 +1,123 @@
+-- ============================================
+-- Migração 016: Isolamento de Empresas com RLS
+-- ============================================
+-- Descrição: Ativa row-level security nas tabelas multi-empresa, filtrando pela variável app.company_id da transação
+-- Data: 2025-09-19
+-- Versão: v1.3.0
+-- Autor: Sistema Tivix Performance Tracker
+-- ============================================
+
+-- Variáveis definidas por transação pela API (set_config(..., true)):
+--   app.company_id  - empresa do usuário autenticado
+--   app.user_id     - usuário autenticado (permite ler o próprio cadastro)
+--   app.bypass_rls  - 'on' para administradores e para o contexto de sistema
+-- Sem as variáveis nenhuma linha das tabelas protegidas fica visível.
+-- Observação: superusuários e roles com BYPASSRLS ignoram as políticas; a API deve conectar com um role comum.
+
+CREATE OR REPLACE FUNCTION app_current_company_id()
+RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.company_id', true), '')::UUID;
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION app_current_user_id()
+RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.user_id', true), '')::UUID;
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION app_rls_bypass()
+RETURNS BOOLEAN AS $$
+    SELECT COALESCE(current_setting('app.bypass_rls', true), '') = 'on';
+$$ LANGUAGE sql STABLE;
+
+-- Tabelas com coluna company_id
+DO $$
+DECLARE
+    tbl TEXT;
+BEGIN
+    FOREACH tbl IN ARRAY ARRAY[
+        'teams', 'developers', 'goals', 'one_on_ones', 'report_comments', 'audit_logs',
+        'team_memberships', 'career_tracks', 'career_levels', 'developer_level_changes',
+        'skills', 'developer_skill_assessments', 'developer_skills', 'company_settings'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', tbl);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', tbl);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', tbl);
+        EXECUTE format(
+            'CREATE POLICY tenant_isolation ON %I
+                USING (app_rls_bypass() OR company_id = app_current_company_id())
+                WITH CHECK (app_rls_bypass() OR company_id = app_current_company_id())',
+            tbl
+        );
+    END LOOP;
+END $$;
+
+-- Empresas: apenas a própria
+ALTER TABLE companies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE companies FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON companies;
+CREATE POLICY tenant_isolation ON companies
+    USING (app_rls_bypass() OR id = app_current_company_id())
+    WITH CHECK (app_rls_bypass() OR id = app_current_company_id());
+
+-- Usuários: os da empresa e o próprio cadastro (usuários sem empresa)
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON users;
+CREATE POLICY tenant_isolation ON users
+    USING (app_rls_bypass() OR company_id = app_current_company_id() OR id = app_current_user_id())
+    WITH CHECK (app_rls_bypass() OR company_id = app_current_company_id() OR id = app_current_user_id());
+
+-- Tabelas sem company_id herdam a empresa do registro pai
+ALTER TABLE performance_reports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE performance_reports FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON performance_reports;
+CREATE POLICY tenant_isolation ON performance_reports
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM developers d
+        WHERE d.id = performance_reports.developer_id AND d.company_id = app_current_company_id()
+    ))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM developers d
+        WHERE d.id = performance_reports.developer_id AND d.company_id = app_current_company_id()
+    ));
+
+ALTER TABLE goal_progress_updates ENABLE ROW LEVEL SECURITY;
+ALTER TABLE goal_progress_updates FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON goal_progress_updates;
+CREATE POLICY tenant_isolation ON goal_progress_updates
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM goals g
+        WHERE g.id = goal_progress_updates.goal_id AND g.company_id = app_current_company_id()
+    ))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM goals g
+        WHERE g.id = goal_progress_updates.goal_id AND g.company_id = app_current_company_id()
+    ));
+
+ALTER TABLE one_on_one_action_items ENABLE ROW LEVEL SECURITY;
+ALTER TABLE one_on_one_action_items FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON one_on_one_action_items;
+CREATE POLICY tenant_isolation ON one_on_one_action_items
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM one_on_ones o
+        WHERE o.id = one_on_one_action_items.one_on_one_id AND o.company_id = app_current_company_id()
+    ))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM one_on_ones o
+        WHERE o.id = one_on_one_action_items.one_on_one_id AND o.company_id = app_current_company_id()
+    ));
+
+ALTER TABLE report_comment_reads ENABLE ROW LEVEL SECURITY;
+ALTER TABLE report_comment_reads FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON report_comment_reads;
+CREATE POLICY tenant_isolation ON report_comment_reads
+    USING (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM performance_reports pr
+        INNER JOIN developers d ON d.id = pr.developer_id
+        WHERE pr.id = report_comment_reads.report_id AND d.company_id = app_current_company_id()
+    ))
+    WITH CHECK (app_rls_bypass() OR EXISTS (
+        SELECT 1 FROM performance_reports pr
+        INNER JOIN developers d ON d.id = pr.developer_id
+        WHERE pr.id = report_comment_reads.report_id AND d.company_id = app_current_company_id()
+    ));
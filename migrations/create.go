@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var nonIdentifier = regexp.MustCompile(`[^a-z0-9]+`)
+
+const upTemplate = `-- ============================================
+-- Migração %[1]s: %[2]s
+-- ============================================
+-- Descrição:
+-- Data: %[3]s
+-- ============================================
+
+`
+
+const downTemplate = `-- ============================================
+-- Migração %[1]s (rollback): %[2]s
+-- ============================================
+-- Descrição: Desfaz a migração %[1]s
+-- ============================================
+
+`
+
+// CreateMigration cria em dir o par VERSÃO_nome.up.sql/.down.sql com cabeçalho, usando como
+// versão o timestamp AAAAMMDDHHMMSS de now em UTC, e retorna os caminhos criados. Como os
+// arquivos são embutidos no binário, é preciso recompilar para aplicá-los.
+func CreateMigration(dir, name string, now time.Time) (string, string, error) {
+	slug := strings.Trim(nonIdentifier.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if slug == "" {
+		return "", "", fmt.Errorf("nome de migração inválido: %q", name)
+	}
+
+	version := now.UTC().Format("20060102150405")
+	title := strings.ToUpper(slug[:1]) + strings.ReplaceAll(slug[1:], "_", " ")
+	base := filepath.Join(dir, version+"_"+slug)
+
+	upPath, downPath := base+".up.sql", base+".down.sql"
+	if err := writeNewFile(upPath, fmt.Sprintf(upTemplate, version, title, now.UTC().Format("2006-01-02"))); err != nil {
+		return "", "", err
+	}
+	if err := writeNewFile(downPath, fmt.Sprintf(downTemplate, version, title)); err != nil {
+		os.Remove(upPath)
+		return "", "", err
+	}
+
+	return upPath, downPath, nil
+}
+
+// writeNewFile grava content em path, falhando se o arquivo já existir
+func writeNewFile(path, content string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("falha ao criar %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(content); err != nil {
+		return fmt.Errorf("falha ao gravar %s: %w", path, err)
+	}
+	return nil
+}
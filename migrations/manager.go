@@ -1,28 +1,80 @@
 package migrations
 
 import (
+	"context"
+	"crypto/sha256"
 	"database/sql"
+	"embed"
+	"encoding/hex"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"path/filepath"
+	"io/fs"
+	"log/slog"
+	"math"
+	"path"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// files contém os arquivos .sql embutidos no binário, para que as migrações não dependam
+// do diretório de trabalho
+//
+//go:embed *.sql sqlite/*.sql
+var files embed.FS
+
+// migrationLockID é a chave do advisory lock (pg_advisory_lock) que serializa as migrações:
+// "tivixmig" em ASCII
+const migrationLockID int64 = 0x74697669786d6967
+
+// fileNamePattern é a convenção NNN_nome.up.sql / NNN_nome.down.sql; a versão é sequencial
+// (001) ou, nas migrações criadas pelo comando create, um timestamp (20251018120000)
+var fileNamePattern = regexp.MustCompile(`^(\d{3}|\d{14})_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// titlePattern extrai o título do cabeçalho "-- Migração 001: Título" do arquivo .up.sql
+var titlePattern = regexp.MustCompile(`(?m)^--\s*Migração[^:\n]*:\s*(.+?)\s*$`)
+
 type Migration struct {
 	ID          string
+	Version     string
 	Description string
 	SQL         string
-	AppliedAt   *time.Time
+	DownSQL     string
+	// Checksum é o SHA-256 do arquivo .up.sql, gravado em schema_migrations ao aplicar
+	Checksum  string
+	AppliedAt *time.Time
 }
 
 type MigrationManager struct {
 	DB *sql.DB
+	// Dialect seleciona o conjunto de migrações: "postgres" (padrão) ou "sqlite"
+	Dialect string
+	// FS é a origem dos arquivos .sql; por padrão, os embutidos no binário
+	FS fs.FS
+
+	// conn é a conexão que segura o advisory lock enquanto withLock executa
+	conn *sql.Conn
+}
+
+// executor é a parte comum de *sql.DB e *sql.Conn usada pelas migrações
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// db retorna a conexão do lock dentro de withLock e o pool fora dele. Tudo o que roda sob o
+// lock usa a mesma conexão, então as migrações funcionam mesmo com DB_MAX_OPEN_CONNS=1.
+func (m *MigrationManager) db() executor {
+	if m.conn != nil {
+		return m.conn
+	}
+	return m.DB
 }
 
 func NewMigrationManager(db *sql.DB) *MigrationManager {
-	return &MigrationManager{DB: db}
+	return &MigrationManager{DB: db, FS: files}
 }
 
 func (m *MigrationManager) CreateMigrationsTable() error {
@@ -30,175 +82,702 @@ func (m *MigrationManager) CreateMigrationsTable() error {
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			id VARCHAR(255) PRIMARY KEY,
 			description TEXT NOT NULL,
+			checksum VARCHAR(64),
 			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		);
 	`
-	_, err := m.DB.Exec(query)
+	_, err := m.db().ExecContext(context.Background(), query)
 	if err != nil {
 		return fmt.Errorf("falha ao criar tabela de migrações: %w", err)
 	}
 
-	log.Println("✅ Tabela de migrações criada/verificada")
+	// Bancos criados antes dos checksums não têm a coluna
+	if _, err := m.db().ExecContext(context.Background(), "SELECT checksum FROM schema_migrations WHERE 1 = 0"); err != nil {
+		if _, err := m.db().ExecContext(context.Background(), "ALTER TABLE schema_migrations ADD COLUMN checksum VARCHAR(64)"); err != nil {
+			return fmt.Errorf("falha ao adicionar checksum à tabela de migrações: %w", err)
+		}
+	}
+
+	slog.Debug("Tabela de migrações criada/verificada")
 	return nil
 }
 
 func (m *MigrationManager) GetAppliedMigrations() (map[string]bool, error) {
-	applied := make(map[string]bool)
+	checksums, err := m.GetAppliedChecksums()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]bool, len(checksums))
+	for id := range checksums {
+		applied[id] = true
+	}
+	return applied, nil
+}
+
+// GetAppliedChecksums retorna o checksum gravado de cada migração aplicada ("" nas
+// aplicadas antes da coluna existir)
+func (m *MigrationManager) GetAppliedChecksums() (map[string]string, error) {
+	return m.appliedChecksums(context.Background())
+}
+
+func (m *MigrationManager) appliedChecksums(ctx context.Context) (map[string]string, error) {
+	checksums := make(map[string]string)
 
-	rows, err := m.DB.Query("SELECT id FROM schema_migrations")
+	rows, err := m.db().QueryContext(ctx, "SELECT id, COALESCE(checksum, '') FROM schema_migrations")
 	if err != nil {
 		return nil, fmt.Errorf("falha ao consultar migrações aplicadas: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
+		var id, checksum string
+		if err := rows.Scan(&id, &checksum); err != nil {
 			return nil, fmt.Errorf("falha ao ler migração aplicada: %w", err)
 		}
-		applied[id] = true
+		checksums[id] = checksum
 	}
 
-	return applied, nil
+	return checksums, rows.Err()
 }
 
-func (m *MigrationManager) RecordMigration(id, description string) error {
-	query := `INSERT INTO schema_migrations (id, description) VALUES ($1, $2)`
-	_, err := m.DB.Exec(query, id, description)
+func (m *MigrationManager) RecordMigration(id, description, checksum string) error {
+	query := `INSERT INTO schema_migrations (id, description, checksum) VALUES ($1, $2, $3)`
+	_, err := m.db().ExecContext(context.Background(), query, id, description, checksum)
 	if err != nil {
 		return fmt.Errorf("falha ao registrar migração %s: %w", id, err)
 	}
 	return nil
 }
 
-func (m *MigrationManager) RunMigrations() error {
-	if err := m.CreateMigrationsTable(); err != nil {
+// VerifyChecksums compara os arquivos com os checksums gravados e falha se alguma migração
+// aplicada foi editada depois. Migrações aplicadas antes da coluna existir recebem o
+// checksum atual.
+func (m *MigrationManager) VerifyChecksums(migrations []Migration) error {
+	checksums, err := m.GetAppliedChecksums()
+	if err != nil {
 		return err
 	}
 
+	var changed []string
+	for _, migration := range migrations {
+		stored, applied := checksums[migration.ID]
+		if !applied {
+			continue
+		}
+
+		if stored == "" {
+			if _, err := m.db().ExecContext(context.Background(), "UPDATE schema_migrations SET checksum = $1 WHERE id = $2",
+				migration.Checksum, migration.ID); err != nil {
+				return fmt.Errorf("falha ao gravar checksum da migração %s: %w", migration.ID, err)
+			}
+			continue
+		}
+
+		if stored != migration.Checksum {
+			changed = append(changed, migration.ID)
+		}
+	}
+
+	if len(changed) > 0 {
+		return fmt.Errorf("migrações aplicadas foram alteradas depois de executadas: %s; crie uma nova migração em vez de editar as existentes",
+			strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+// MigrationStatus é a situação de uma migração no banco, usada pelo comando de status
+type MigrationStatus struct {
+	ID          string     `json:"id"`
+	Version     string     `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+	// Changed indica que o arquivo foi editado depois de aplicado
+	Changed bool `json:"changed"`
+	// Missing indica uma migração registrada no banco sem arquivo correspondente
+	Missing bool `json:"missing"`
+}
+
+// appliedMigration é uma migração registrada em schema_migrations; Migration é nil quando o
+// arquivo não existe mais
+type appliedMigration struct {
+	ID        string
+	Version   int64
+	Migration *Migration
+}
+
+// prepare garante a tabela de controle, carrega os arquivos e confere os checksums
+func (m *MigrationManager) prepare() ([]Migration, map[string]bool, error) {
+	if err := m.CreateMigrationsTable(); err != nil {
+		return nil, nil, err
+	}
+
+	migrations, err := m.GetAllMigrations()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := m.VerifyChecksums(migrations); err != nil {
+		return nil, nil, err
+	}
+
 	applied, err := m.GetAppliedMigrations()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	migrations := m.GetAllMigrations()
+	return migrations, applied, nil
+}
 
-	sort.Slice(migrations, func(i, j int) bool {
-		return migrations[i].ID < migrations[j].ID
-	})
+func (m *MigrationManager) RunMigrations() error {
+	return m.MigrateTo("")
+}
+
+// MigrateTo aplica, em ordem, as migrações pendentes com versão até target (inclusive);
+// target vazio aplica todas
+func (m *MigrationManager) MigrateTo(target string) error {
+	limit := int64(math.MaxInt64)
+	if target != "" {
+		version, err := parseVersion(target)
+		if err != nil {
+			return err
+		}
+		limit = version
+	}
+
+	return m.withLock(func() error { return m.migrateTo(limit) })
+}
+
+func (m *MigrationManager) migrateTo(limit int64) error {
+	migrations, applied, err := m.prepare()
+	if err != nil {
+		return err
+	}
 
 	pendingCount := 0
 	for _, migration := range migrations {
-		if !applied[migration.ID] {
-			log.Printf("🔄 Executando migração %s: %s", migration.ID, migration.Description)
+		if applied[migration.ID] || versionNumber(migration.Version) > limit {
+			continue
+		}
 
-			tx, err := m.DB.Begin()
-			if err != nil {
-				return fmt.Errorf("falha ao iniciar transação para migração %s: %w", migration.ID, err)
-			}
+		if err := m.apply(migration); err != nil {
+			return err
+		}
+		pendingCount++
+	}
 
-			if _, err := tx.Exec(migration.SQL); err != nil {
-				tx.Rollback()
-				return fmt.Errorf("falha ao executar migração %s: %w", migration.ID, err)
-			}
+	if pendingCount == 0 {
+		slog.Info("Nenhuma migração pendente encontrada")
+	} else {
+		slog.Info("Migrações aplicadas com sucesso", "count", pendingCount)
+	}
 
-			if _, err := tx.Exec("INSERT INTO schema_migrations (id, description) VALUES ($1, $2)",
-				migration.ID, migration.Description); err != nil {
-				tx.Rollback()
-				return fmt.Errorf("falha ao registrar migração %s: %w", migration.ID, err)
-			}
+	return nil
+}
+
+// Rollback desfaz, da mais recente para a mais antiga, as migrações aplicadas com versão
+// maior que target ("000" desfaz todas). Cada migração roda seu .down.sql na mesma
+// transação que remove o registro em schema_migrations.
+func (m *MigrationManager) Rollback(target string) error {
+	targetVersion, err := parseVersion(target)
+	if err != nil {
+		return err
+	}
+
+	return m.withLock(func() error { return m.rollback(target, targetVersion) })
+}
+
+func (m *MigrationManager) rollback(target string, targetVersion int64) error {
+	migrations, applied, err := m.prepare()
+	if err != nil {
+		return err
+	}
+
+	var selected []appliedMigration
+	for _, entry := range appliedDescending(migrations, applied) {
+		if entry.Version > targetVersion {
+			selected = append(selected, entry)
+		}
+	}
+
+	if len(selected) == 0 {
+		slog.Info("Nenhuma migração acima da versão para desfazer", "target", target)
+		return nil
+	}
+	return m.revertAll(selected)
+}
+
+// RollbackSteps desfaz as últimas steps migrações aplicadas
+func (m *MigrationManager) RollbackSteps(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("número de passos inválido: %d", steps)
+	}
+
+	return m.withLock(func() error { return m.rollbackSteps(steps) })
+}
+
+func (m *MigrationManager) rollbackSteps(steps int) error {
+	migrations, applied, err := m.prepare()
+	if err != nil {
+		return err
+	}
 
-			if err := tx.Commit(); err != nil {
-				return fmt.Errorf("falha ao confirmar migração %s: %w", migration.ID, err)
+	selected := appliedDescending(migrations, applied)
+	if len(selected) == 0 {
+		slog.Info("Nenhuma migração aplicada para desfazer")
+		return nil
+	}
+	if len(selected) > steps {
+		selected = selected[:steps]
+	}
+	return m.revertAll(selected)
+}
+
+// Redo desfaz e reaplica a última migração aplicada
+func (m *MigrationManager) Redo() error {
+	return m.withLock(m.redo)
+}
+
+func (m *MigrationManager) redo() error {
+	migrations, applied, err := m.prepare()
+	if err != nil {
+		return err
+	}
+
+	entries := appliedDescending(migrations, applied)
+	if len(entries) == 0 {
+		return fmt.Errorf("nenhuma migração aplicada para refazer")
+	}
+
+	if err := m.revertAll(entries[:1]); err != nil {
+		return err
+	}
+	return m.apply(*entries[0].Migration)
+}
+
+// Force registra a migração da versão informada como aplicada sem executá-la, para
+// bancos em que a mudança já foi feita manualmente
+func (m *MigrationManager) Force(version string) error {
+	target, err := parseVersion(version)
+	if err != nil {
+		return err
+	}
+
+	return m.withLock(func() error { return m.force(version, target) })
+}
+
+func (m *MigrationManager) force(version string, target int64) error {
+	migrations, applied, err := m.prepare()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		if versionNumber(migration.Version) != target {
+			continue
+		}
+		if applied[migration.ID] {
+			return fmt.Errorf("migração %s já está aplicada", migration.ID)
+		}
+
+		if err := m.RecordMigration(migration.ID, migration.Description, migration.Checksum); err != nil {
+			return err
+		}
+		slog.Info("Migração marcada como aplicada sem execução", "migration", migration.ID)
+		return nil
+	}
+
+	return fmt.Errorf("nenhuma migração com a versão %s", version)
+}
+
+// Status lista todas as migrações conhecidas e as registradas no banco sem arquivo, em
+// ordem de versão. Não altera os checksums gravados.
+func (m *MigrationManager) Status() ([]MigrationStatus, error) {
+	if err := m.CreateMigrationsTable(); err != nil {
+		return nil, err
+	}
+
+	migrations, err := m.GetAllMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db().QueryContext(context.Background(), "SELECT id, description, COALESCE(checksum, ''), applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("falha ao consultar migrações aplicadas: %w", err)
+	}
+	defer rows.Close()
+
+	type record struct {
+		description string
+		checksum    string
+		appliedAt   sql.NullTime
+	}
+	records := make(map[string]record)
+	for rows.Next() {
+		var id string
+		var r record
+		if err := rows.Scan(&id, &r.description, &r.checksum, &r.appliedAt); err != nil {
+			return nil, fmt.Errorf("falha ao ler migração aplicada: %w", err)
+		}
+		records[id] = r
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{
+			ID:          migration.ID,
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+		if r, ok := records[migration.ID]; ok {
+			status.Applied = true
+			status.Changed = r.checksum != "" && r.checksum != migration.Checksum
+			if r.appliedAt.Valid {
+				status.AppliedAt = &r.appliedAt.Time
 			}
+			delete(records, migration.ID)
+		}
+		statuses = append(statuses, status)
+	}
 
-			log.Printf("✅ Migração %s aplicada com sucesso", migration.ID)
-			pendingCount++
+	for id, r := range records {
+		status := MigrationStatus{
+			ID:          id,
+			Version:     strings.SplitN(id, "_", 2)[0],
+			Description: r.description,
+			Applied:     true,
+			Missing:     true,
+		}
+		if r.appliedAt.Valid {
+			status.AppliedAt = &r.appliedAt.Time
 		}
+		statuses = append(statuses, status)
 	}
 
-	if pendingCount == 0 {
-		log.Println("ℹ️  Nenhuma migração pendente encontrada")
-	} else {
-		log.Printf("✅ %d migração(ões) aplicada(s) com sucesso", pendingCount)
+	sort.Slice(statuses, func(i, j int) bool {
+		return versionNumber(statuses[i].Version) < versionNumber(statuses[j].Version)
+	})
+	return statuses, nil
+}
+
+// Check falha se houver migrações pendentes ou aplicadas cujo arquivo foi editado, sem
+// aplicar nada; migrações registradas sem arquivo (de uma versão mais nova da aplicação)
+// geram apenas aviso
+func (m *MigrationManager) Check() error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
 	}
 
+	var pending, changed []string
+	for _, status := range statuses {
+		switch {
+		case !status.Applied:
+			pending = append(pending, status.ID)
+		case status.Missing:
+			slog.Warn("Migração aplicada no banco não existe nesta versão da aplicação", "migration", status.ID)
+		case status.Changed:
+			changed = append(changed, status.ID)
+		}
+	}
+
+	var problems []string
+	if len(pending) > 0 {
+		problems = append(problems, "pendentes: "+strings.Join(pending, ", "))
+	}
+	if len(changed) > 0 {
+		problems = append(problems, "alteradas após aplicadas: "+strings.Join(changed, ", "))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("schema desatualizado (%s)", strings.Join(problems, "; "))
+	}
 	return nil
 }
 
-func (m *MigrationManager) GetAllMigrations() []Migration {
-	// Definir as migrações com seus IDs e descrições
-	migrationConfigs := []struct {
-		ID          string
-		Description string
-		FileName    string
-	}{
-		{
-			ID:          "001_initial_setup",
-			Description: "Configuração inicial PostgreSQL",
-			FileName:    "001_initial_setup.sql",
-		},
-		{
-			ID:          "002_create_tables",
-			Description: "Criação das tabelas principais",
-			FileName:    "002_create_tables.sql",
-		},
-		{
-			ID:          "003_create_indexes",
-			Description: "Criação de índices para performance",
-			FileName:    "003_create_indexes.sql",
-		},
-		{
-			ID:          "004_create_triggers",
-			Description: "Configuração de triggers para timestamps",
-			FileName:    "004_create_triggers.sql",
-		},
-		{
-			ID:          "005_multitenant_implementation",
-			Description: "Implementação do sistema multitenant",
-			FileName:    "005_multitenant_implementation.sql",
-		},
-		{
-			ID:          "006_data_migration_multitenant",
-			Description: "Migração de dados para multitenancy",
-			FileName:    "006_data_migration_multitenant.sql",
-		},
-	}
-
-	var migrations []Migration
-	
-	for _, config := range migrationConfigs {
-		// Tentar ler o arquivo SQL
-		sqlContent, err := m.readSQLFile(config.FileName)
-		if err != nil {
-			log.Printf("⚠️  Aviso: Não foi possível ler o arquivo %s: %v", config.FileName, err)
+// Summary retorna a versão da migração mais recente aplicada e quantas migrações embutidas
+// estão pendentes, apenas lendo schema_migrations (usado nas métricas e no readiness)
+func (m *MigrationManager) Summary(ctx context.Context) (int64, int, error) {
+	migrations, err := m.GetAllMigrations()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	applied, err := m.appliedChecksums(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var version int64
+	pending := 0
+	for _, migration := range migrations {
+		if _, ok := applied[migration.ID]; !ok {
+			pending++
 			continue
 		}
+		if number := versionNumber(migration.Version); number > version {
+			version = number
+		}
+	}
+	return version, pending, nil
+}
+
+// withLock executa fn segurando o advisory lock das migrações, para que instâncias iniciadas
+// ao mesmo tempo apliquem as migrações uma de cada vez. O lock pertence a uma conexão
+// dedicada do pool, na qual fn também executa (ver db), e é liberado ao final (ou quando a
+// conexão cai). No SQLite não há advisory locks; o banco local é de um único processo.
+func (m *MigrationManager) withLock(fn func() error) error {
+	if m.Dialect == "sqlite" {
+		return fn()
+	}
+
+	ctx := context.Background()
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("falha ao obter conexão para o lock de migrações: %w", err)
+	}
+	defer conn.Close()
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", migrationLockID).Scan(&acquired); err != nil {
+		return fmt.Errorf("falha ao obter lock de migrações: %w", err)
+	}
+	if !acquired {
+		slog.Info("Outra instância está aplicando migrações; aguardando o lock")
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+			return fmt.Errorf("falha ao obter lock de migrações: %w", err)
+		}
+	}
+	defer func() {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			slog.Warn("Falha ao liberar lock de migrações", "error", err)
+		}
+	}()
+
+	m.conn = conn
+	defer func() { m.conn = nil }()
+
+	return fn()
+}
+
+// apply executa o .up.sql e registra a migração na mesma transação
+func (m *MigrationManager) apply(migration Migration) error {
+	slog.Info("Executando migração", "migration", migration.ID, "description", migration.Description)
+
+	tx, err := m.db().BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("falha ao iniciar transação para migração %s: %w", migration.ID, err)
+	}
+
+	if err := m.bypassRLS(tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("falha ao iniciar transação para migração %s: %w", migration.ID, err)
+	}
+
+	if _, err := tx.Exec(migration.SQL); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("falha ao executar migração %s: %w", migration.ID, err)
+	}
+
+	if _, err := tx.Exec("INSERT INTO schema_migrations (id, description, checksum) VALUES ($1, $2, $3)",
+		migration.ID, migration.Description, migration.Checksum); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("falha ao registrar migração %s: %w", migration.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("falha ao confirmar migração %s: %w", migration.ID, err)
+	}
+
+	slog.Info("Migração aplicada com sucesso", "migration", migration.ID)
+	return nil
+}
+
+// bypassRLS libera a transação das políticas de row-level security (migração 016): as
+// conexões do pool não têm bypass, e migrações de dados precisam alcançar todas as empresas
+func (m *MigrationManager) bypassRLS(tx *sql.Tx) error {
+	if m.Dialect == "sqlite" {
+		return nil
+	}
+	_, err := tx.Exec("SELECT set_config('app.bypass_rls', 'on', true)")
+	return err
+}
+
+// revertAll desfaz as migrações na ordem recebida, parando na primeira falha
+func (m *MigrationManager) revertAll(entries []appliedMigration) error {
+	for _, entry := range entries {
+		if entry.Migration == nil {
+			return fmt.Errorf("migração aplicada %s não existe nos arquivos; não é possível desfazê-la", entry.ID)
+		}
+		if entry.Migration.DownSQL == "" {
+			return fmt.Errorf("migração %s não tem arquivo .down.sql", entry.ID)
+		}
+	}
+
+	for _, entry := range entries {
+		if err := m.revert(*entry.Migration); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("Migrações desfeitas com sucesso", "count", len(entries))
+	return nil
+}
+
+// revert executa o .down.sql e remove o registro da migração na mesma transação
+func (m *MigrationManager) revert(migration Migration) error {
+	slog.Info("Desfazendo migração", "migration", migration.ID, "description", migration.Description)
+
+	tx, err := m.db().BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("falha ao iniciar transação para desfazer migração %s: %w", migration.ID, err)
+	}
+
+	if err := m.bypassRLS(tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("falha ao iniciar transação para desfazer migração %s: %w", migration.ID, err)
+	}
+
+	if _, err := tx.Exec(migration.DownSQL); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("falha ao desfazer migração %s: %w", migration.ID, err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM schema_migrations WHERE id = $1", migration.ID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("falha ao remover registro da migração %s: %w", migration.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("falha ao confirmar rollback da migração %s: %w", migration.ID, err)
+	}
+
+	slog.Info("Migração desfeita com sucesso", "migration", migration.ID)
+	return nil
+}
+
+// appliedDescending retorna as migrações aplicadas da versão mais recente para a mais antiga
+func appliedDescending(migrations []Migration, applied map[string]bool) []appliedMigration {
+	byID := make(map[string]*Migration, len(migrations))
+	for i := range migrations {
+		byID[migrations[i].ID] = &migrations[i]
+	}
 
-		migrations = append(migrations, Migration{
-			ID:          config.ID,
-			Description: config.Description,
-			SQL:         sqlContent,
+	entries := make([]appliedMigration, 0, len(applied))
+	for id := range applied {
+		entries = append(entries, appliedMigration{
+			ID:        id,
+			Version:   versionNumber(strings.SplitN(id, "_", 2)[0]),
+			Migration: byID[id],
 		})
 	}
 
-	return migrations
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Version > entries[j].Version
+	})
+	return entries
 }
 
-// readSQLFile lê o conteúdo de um arquivo SQL
-func (m *MigrationManager) readSQLFile(fileName string) (string, error) {
-	// Tenta diferentes caminhos para encontrar o arquivo
-	paths := []string{
-		filepath.Join("migrations", fileName),
-		filepath.Join(".", fileName),
-		fileName,
+// parseVersion valida uma versão informada pelo usuário ("001", "20251018120000")
+func parseVersion(version string) (int64, error) {
+	number, err := strconv.ParseInt(version, 10, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("versão inválida: %q", version)
+	}
+	return number, nil
+}
+
+// versionNumber converte a versão de um arquivo já validado pelo padrão de nomes
+func versionNumber(version string) int64 {
+	number, _ := strconv.ParseInt(version, 10, 64)
+	return number
+}
+
+// GetAllMigrations descobre os arquivos NNN_nome.up.sql (e os .down.sql correspondentes)
+// do dialeto configurado, em ordem numérica de versão. O ID de cada migração é NNN_nome.
+func (m *MigrationManager) GetAllMigrations() ([]Migration, error) {
+	dir := "."
+	if m.Dialect == "sqlite" {
+		dir = "sqlite"
+	}
+
+	fsys := m.FS
+	if fsys == nil {
+		fsys = files
+	}
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar migrações em %s: %w", dir, err)
 	}
 
-	for _, path := range paths {
-		if content, err := ioutil.ReadFile(path); err == nil {
-			return string(content), nil
+	byID := make(map[string]*Migration)
+	versions := make(map[int64]string)
+	downs := make(map[string]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("arquivo de migração fora do padrão NNN_nome.up.sql/.down.sql: %s", entry.Name())
+		}
+		version, id, direction := match[1], match[1]+"_"+match[2], match[3]
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler migração %s: %w", entry.Name(), err)
+		}
+
+		if direction == "down" {
+			downs[id] = string(content)
+			continue
+		}
+
+		if other, exists := versions[versionNumber(version)]; exists {
+			return nil, fmt.Errorf("versão %s duplicada nas migrações %s e %s", version, other, id)
+		}
+		versions[versionNumber(version)] = id
+
+		sum := sha256.Sum256(content)
+		byID[id] = &Migration{
+			ID:          id,
+			Version:     version,
+			Description: describe(match[2], string(content)),
+			SQL:         string(content),
+			Checksum:    hex.EncodeToString(sum[:]),
 		}
 	}
 
-	return "", fmt.Errorf("arquivo SQL não encontrado: %s", fileName)
+	for id, down := range downs {
+		migration, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("migração %s tem .down.sql sem o .up.sql correspondente", id)
+		}
+		migration.DownSQL = down
+	}
+
+	migrations := make([]Migration, 0, len(byID))
+	for _, migration := range byID {
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return versionNumber(migrations[i].Version) < versionNumber(migrations[j].Version)
+	})
+
+	return migrations, nil
+}
+
+// describe usa o título do cabeçalho do arquivo ou, na falta dele, o nome do arquivo
+func describe(name, content string) string {
+	if match := titlePattern.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	return strings.ReplaceAll(name, "_", " ")
 }
@@ -0,0 +1,483 @@
+package migrations_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/migrations"
+)
+
+func openSQLite(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "migrations.db"))
+	if err != nil {
+		t.Fatalf("abrir SQLite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func countTables(t *testing.T, db *sqlx.DB) int {
+	t.Helper()
+
+	var count int
+	if err := db.Get(&count, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migrations', 'sqlite_sequence')"); err != nil {
+		t.Fatalf("contar tabelas: %v", err)
+	}
+	return count
+}
+
+func TestEmbeddedMigrationsHaveUpAndDown(t *testing.T) {
+	for _, dialect := range []string{database.DriverPostgres, database.DriverSQLite} {
+		manager := migrations.NewMigrationManager(nil)
+		manager.Dialect = dialect
+
+		all, err := manager.GetAllMigrations()
+		if err != nil {
+			t.Fatalf("%s: carregar migrações: %v", dialect, err)
+		}
+		if len(all) == 0 {
+			t.Fatalf("%s: nenhuma migração embutida", dialect)
+		}
+
+		for i, migration := range all {
+			if i > 0 && migration.Version <= all[i-1].Version {
+				t.Errorf("%s: migrações fora de ordem: %s depois de %s", dialect, migration.ID, all[i-1].ID)
+			}
+			if migration.DownSQL == "" {
+				t.Errorf("%s: migração %s sem .down.sql", dialect, migration.ID)
+			}
+			if len(migration.Checksum) != 64 {
+				t.Errorf("%s: migração %s com checksum inválido %q", dialect, migration.ID, migration.Checksum)
+			}
+		}
+	}
+
+	manager := migrations.NewMigrationManager(nil)
+	all, _ := manager.GetAllMigrations()
+	if all[0].ID != "001_initial_setup" || all[0].Description != "Configuração Inicial PostgreSQL" {
+		t.Errorf("primeira migração inesperada: %s (%s)", all[0].ID, all[0].Description)
+	}
+}
+
+func TestRollbackAndReapplySQLite(t *testing.T) {
+	db := openSQLite(t)
+	manager := migrations.NewMigrationManager(db.DB)
+	manager.Dialect = db.DriverName()
+
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatalf("aplicar migrações: %v", err)
+	}
+	if countTables(t, db) == 0 {
+		t.Fatal("nenhuma tabela criada")
+	}
+
+	if err := manager.Rollback("000"); err != nil {
+		t.Fatalf("desfazer migrações: %v", err)
+	}
+	if n := countTables(t, db); n != 0 {
+		t.Fatalf("%d tabela(s) restante(s) após o rollback", n)
+	}
+	applied, err := manager.GetAppliedMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 0 {
+		t.Fatalf("schema_migrations ainda lista %v", applied)
+	}
+
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatalf("reaplicar migrações: %v", err)
+	}
+}
+
+func TestRollbackToTargetVersion(t *testing.T) {
+	db := openSQLite(t)
+	manager := migrations.NewMigrationManager(db.DB)
+	manager.Dialect = db.DriverName()
+	manager.FS = fstest.MapFS{
+		"sqlite/001_first.up.sql":    {Data: []byte("CREATE TABLE first (id INTEGER);")},
+		"sqlite/001_first.down.sql":  {Data: []byte("DROP TABLE first;")},
+		"sqlite/002_second.up.sql":   {Data: []byte("CREATE TABLE second (id INTEGER);")},
+		"sqlite/002_second.down.sql": {Data: []byte("DROP TABLE second;")},
+	}
+
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatalf("aplicar migrações: %v", err)
+	}
+	if err := manager.Rollback("1"); err != nil {
+		t.Fatalf("desfazer até a versão 1: %v", err)
+	}
+
+	applied, err := manager.GetAppliedMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !applied["001_first"] || applied["002_second"] {
+		t.Fatalf("migrações aplicadas após o rollback: %v", applied)
+	}
+	if n := countTables(t, db); n != 1 {
+		t.Fatalf("esperava 1 tabela após o rollback, obteve %d", n)
+	}
+
+	if err := manager.Rollback("abc"); err == nil {
+		t.Fatal("versão alvo inválida foi aceita")
+	}
+}
+
+func TestEditedMigrationIsRejected(t *testing.T) {
+	db := openSQLite(t)
+	files := fstest.MapFS{
+		"sqlite/001_first.up.sql": {Data: []byte("CREATE TABLE first (id INTEGER);")},
+	}
+	manager := migrations.NewMigrationManager(db.DB)
+	manager.Dialect = db.DriverName()
+	manager.FS = files
+
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatalf("aplicar migrações: %v", err)
+	}
+
+	files["sqlite/001_first.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE first (id INTEGER, name TEXT);")}
+	err := manager.RunMigrations()
+	if err == nil || !strings.Contains(err.Error(), "001_first") {
+		t.Fatalf("esperava erro de checksum para 001_first, obteve %v", err)
+	}
+
+	// Registros anteriores à coluna de checksum recebem o checksum atual em vez de falhar
+	if _, err := db.Exec("UPDATE schema_migrations SET checksum = NULL"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatalf("migração sem checksum gravado deveria ser aceita: %v", err)
+	}
+}
+
+func TestInvalidFileNamesAreRejected(t *testing.T) {
+	cases := map[string]fs.FS{
+		"nome fora do padrão": fstest.MapFS{"sqlite/1_first.sql": {Data: []byte("SELECT 1;")}},
+		"versão duplicada": fstest.MapFS{
+			"sqlite/001_first.up.sql":  {Data: []byte("SELECT 1;")},
+			"sqlite/001_second.up.sql": {Data: []byte("SELECT 1;")},
+		},
+		"down sem up": fstest.MapFS{"sqlite/001_first.down.sql": {Data: []byte("SELECT 1;")}},
+	}
+
+	for name, files := range cases {
+		manager := migrations.NewMigrationManager(nil)
+		manager.Dialect = database.DriverSQLite
+		manager.FS = files
+
+		if _, err := manager.GetAllMigrations(); err == nil {
+			t.Errorf("%s: esperava erro", name)
+		}
+	}
+}
+
+// twoStepFS é um conjunto mínimo com uma migração sequencial e uma criada pelo comando create
+func twoStepFS() fstest.MapFS {
+	return fstest.MapFS{
+		"sqlite/001_first.up.sql":               {Data: []byte("-- Migração 001: Primeira\nCREATE TABLE first (id INTEGER);")},
+		"sqlite/001_first.down.sql":             {Data: []byte("DROP TABLE first;")},
+		"sqlite/20251018120000_second.up.sql":   {Data: []byte("CREATE TABLE second (id INTEGER);")},
+		"sqlite/20251018120000_second.down.sql": {Data: []byte("DROP TABLE IF EXISTS second;")},
+	}
+}
+
+func TestMigrateToStepsRedoAndForce(t *testing.T) {
+	db := openSQLite(t)
+	manager := migrations.NewMigrationManager(db.DB)
+	manager.Dialect = db.DriverName()
+	manager.FS = twoStepFS()
+
+	if err := manager.MigrateTo("1"); err != nil {
+		t.Fatalf("aplicar até a versão 1: %v", err)
+	}
+	statuses, err := manager.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || !statuses[0].Applied || statuses[1].Applied {
+		t.Fatalf("situação após up -to 1: %+v", statuses)
+	}
+	if statuses[0].Description != "Primeira" || statuses[1].Description != "second" {
+		t.Errorf("descrições inesperadas: %q, %q", statuses[0].Description, statuses[1].Description)
+	}
+	if version, pending, err := manager.Summary(context.Background()); err != nil || version != 1 || pending != 1 {
+		t.Errorf("resumo após up -to 1: versão %d, pendentes %d, erro %v", version, pending, err)
+	}
+
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatal(err)
+	}
+	if version, pending, _ := manager.Summary(context.Background()); version != 20251018120000 || pending != 0 {
+		t.Errorf("resumo após up: versão %d, pendentes %d", version, pending)
+	}
+	if err := manager.Redo(); err != nil {
+		t.Fatalf("refazer a última migração: %v", err)
+	}
+	if n := countTables(t, db); n != 2 {
+		t.Fatalf("esperava 2 tabelas após o redo, obteve %d", n)
+	}
+
+	if err := manager.RollbackSteps(1); err != nil {
+		t.Fatalf("desfazer 1 passo: %v", err)
+	}
+	applied, _ := manager.GetAppliedMigrations()
+	if !applied["001_first"] || applied["20251018120000_second"] {
+		t.Fatalf("migrações aplicadas após down 1: %v", applied)
+	}
+
+	if err := manager.Force("20251018120000"); err != nil {
+		t.Fatalf("forçar versão: %v", err)
+	}
+	if n := countTables(t, db); n != 1 {
+		t.Fatalf("force não deveria executar a migração, mas há %d tabelas", n)
+	}
+	if err := manager.Force("20251018120000"); err == nil {
+		t.Fatal("forçar uma versão já aplicada deveria falhar")
+	}
+	if err := manager.Force("999"); err == nil {
+		t.Fatal("forçar uma versão inexistente deveria falhar")
+	}
+
+	if err := manager.RollbackSteps(5); err != nil {
+		t.Fatalf("desfazer mais passos que o aplicado: %v", err)
+	}
+	applied, _ = manager.GetAppliedMigrations()
+	if len(applied) != 0 {
+		t.Fatalf("ainda aplicadas: %v", applied)
+	}
+}
+
+func TestStatusReportsMissingFiles(t *testing.T) {
+	db := openSQLite(t)
+	files := twoStepFS()
+	manager := migrations.NewMigrationManager(db.DB)
+	manager.Dialect = db.DriverName()
+	manager.FS = files
+
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatal(err)
+	}
+	delete(files, "sqlite/20251018120000_second.up.sql")
+	delete(files, "sqlite/20251018120000_second.down.sql")
+
+	statuses, err := manager.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || !statuses[1].Missing || statuses[1].ID != "20251018120000_second" {
+		t.Fatalf("esperava a segunda migração sem arquivo: %+v", statuses)
+	}
+	if err := manager.RollbackSteps(1); err == nil {
+		t.Fatal("desfazer uma migração sem arquivo deveria falhar")
+	}
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 10, 18, 12, 30, 45, 0, time.UTC)
+
+	upPath, downPath, err := migrations.CreateMigration(dir, "Add Last-Login!", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(upPath) != "20251018123045_add_last_login.up.sql" || filepath.Base(downPath) != "20251018123045_add_last_login.down.sql" {
+		t.Fatalf("nomes inesperados: %s, %s", upPath, downPath)
+	}
+
+	manager := migrations.NewMigrationManager(nil)
+	manager.FS = os.DirFS(dir)
+	all, err := manager.GetAllMigrations()
+	if err != nil {
+		t.Fatalf("arquivos criados fora do padrão: %v", err)
+	}
+	if len(all) != 1 || all[0].Description != "Add last login" {
+		t.Fatalf("migração criada inesperada: %+v", all)
+	}
+
+	if _, _, err := migrations.CreateMigration(dir, "add last login", now); err == nil {
+		t.Fatal("criar sobre arquivos existentes deveria falhar")
+	}
+	if _, _, err := migrations.CreateMigration(dir, "!!!", now); err == nil {
+		t.Fatal("nome inválido deveria falhar")
+	}
+}
+
+func TestCheckDetectsPendingAndChangedMigrations(t *testing.T) {
+	db := openSQLite(t)
+	files := twoStepFS()
+	manager := migrations.NewMigrationManager(db.DB)
+	manager.Dialect = db.DriverName()
+	manager.FS = files
+
+	if err := manager.MigrateTo("1"); err != nil {
+		t.Fatal(err)
+	}
+	err := manager.Check()
+	if err == nil || !strings.Contains(err.Error(), "20251018120000_second") {
+		t.Fatalf("esperava a migração pendente no erro, obteve %v", err)
+	}
+
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Check(); err != nil {
+		t.Fatalf("schema atualizado não deveria falhar: %v", err)
+	}
+
+	files["sqlite/001_first.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE first (id INTEGER, name TEXT);")}
+	err = manager.Check()
+	if err == nil || !strings.Contains(err.Error(), "001_first") {
+		t.Fatalf("esperava a migração alterada no erro, obteve %v", err)
+	}
+}
+
+// TestSingleConnectionPoolPostgres garante que as migrações rodam na conexão que segura o
+// advisory lock: com uma única conexão no pool (DB_MAX_OPEN_CONNS=1), abrir outra para a
+// transação travaria para sempre. Requer TEST_DATABASE_URL.
+func TestSingleConnectionPoolPostgres(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL não definida")
+	}
+
+	setup, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer setup.Close()
+
+	schema := fmt.Sprintf("migrations_%d", time.Now().UnixNano())
+	if _, err := setup.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("criar schema: %v", err)
+	}
+	t.Cleanup(func() { setup.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		parsed, err := url.Parse(dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		query := parsed.Query()
+		query.Set("search_path", schema)
+		parsed.RawQuery = query.Encode()
+		dsn = parsed.String()
+	} else {
+		dsn += " search_path=" + schema
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	manager := migrations.NewMigrationManager(db)
+	manager.FS = fstest.MapFS{
+		"001_single.up.sql":   {Data: []byte("CREATE TABLE single (id INTEGER);")},
+		"001_single.down.sql": {Data: []byte("DROP TABLE single;")},
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		if err := manager.RunMigrations(); err != nil {
+			done <- fmt.Errorf("aplicar migrações: %w", err)
+			return
+		}
+		if err := manager.RollbackSteps(1); err != nil {
+			done <- fmt.Errorf("desfazer migração: %w", err)
+			return
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatal("migrações travaram com uma única conexão no pool")
+	}
+}
+
+func TestSQLiteArraysMigrateToJSON(t *testing.T) {
+	db := openSQLite(t)
+	manager := migrations.NewMigrationManager(db.DB)
+	manager.Dialect = db.DriverName()
+
+	if err := manager.MigrateTo("001"); err != nil {
+		t.Fatalf("aplicar a 001: %v", err)
+	}
+	seed := []string{
+		"INSERT INTO companies (id, name) VALUES ('c1', 'Empresa')",
+		"INSERT INTO company_settings (company_id) VALUES ('c1')",
+		"INSERT INTO career_tracks (id, company_id, name) VALUES ('t1', 'c1', 'Trilha')",
+		`INSERT INTO career_levels (id, track_id, company_id, code, name, rank, expectations)
+		 VALUES ('l1', 't1', 'c1', 'L1', 'Nível 1', 1, '{plain,"a, b","aspas \"x\" e \\ barra",""}')`,
+		"INSERT INTO career_levels (id, track_id, company_id, code, name, rank) VALUES ('l2', 't1', 'c1', 'L2', 'Nível 2', 2)",
+	}
+	for _, query := range seed {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("popular o banco: %v", err)
+		}
+	}
+
+	arrays := func() (string, string, string) {
+		var palette, first, second string
+		if err := db.Get(&palette, "SELECT team_color_palette FROM company_settings WHERE company_id = 'c1'"); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Get(&first, "SELECT expectations FROM career_levels WHERE id = 'l1'"); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Get(&second, "SELECT expectations FROM career_levels WHERE id = 'l2'"); err != nil {
+			t.Fatal(err)
+		}
+		return palette, first, second
+	}
+
+	if err := manager.RunMigrations(); err != nil {
+		t.Fatalf("aplicar a 002: %v", err)
+	}
+	palette, first, second := arrays()
+	if palette != `["blue","green","purple","orange","red","teal"]` {
+		t.Errorf("paleta convertida = %s", palette)
+	}
+	if first != `["plain","a, b","aspas \"x\" e \\ barra",""]` {
+		t.Errorf("expectativas convertidas = %s", first)
+	}
+	if second != "[]" {
+		t.Errorf("array vazio convertido = %s", second)
+	}
+
+	if err := manager.Rollback("001"); err != nil {
+		t.Fatalf("desfazer a 002: %v", err)
+	}
+	palette, first, second = arrays()
+	if palette != "{blue,green,purple,orange,red,teal}" {
+		t.Errorf("paleta restaurada = %s", palette)
+	}
+	if first != `{plain,"a, b","aspas \"x\" e \\ barra",""}` {
+		t.Errorf("expectativas restauradas = %s", first)
+	}
+	if second != "{}" {
+		t.Errorf("array vazio restaurado = %s", second)
+	}
+}
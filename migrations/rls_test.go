@@ -0,0 +1,89 @@
+package migrations_test
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Migração que ativa row-level security; as anteriores criam as tabelas que ela protege
+const rlsMigrationVersion = "016"
+
+var (
+	migrationFileName = regexp.MustCompile(`^(\d{3})_\w+(\.up)?\.sql$`)
+	createTable       = regexp.MustCompile(`(?i)CREATE TABLE (?:IF NOT EXISTS )?(\w+)`)
+	rlsLoopTables     = regexp.MustCompile(`(?s)FOREACH \w+ IN ARRAY ARRAY\[(.*?)\](.*?)END LOOP`)
+	quotedName        = regexp.MustCompile(`'(\w+)'`)
+)
+
+// TestRowLevelSecurityCoversAllTables verifica que a migração de RLS ativa, força e cria
+// política para toda tabela criada pelas migrações anteriores
+func TestRowLevelSecurityCoversAllTables(t *testing.T) {
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rls string
+	tables := map[string]bool{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil || match[1] > rlsMigrationVersion {
+			continue
+		}
+		content, err := os.ReadFile(entry.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if match[1] == rlsMigrationVersion {
+			rls = string(content)
+			continue
+		}
+		for _, table := range createTable.FindAllStringSubmatch(string(content), -1) {
+			tables[table[1]] = true
+		}
+	}
+	if rls == "" {
+		t.Fatalf("migração %s não encontrada", rlsMigrationVersion)
+	}
+	if len(tables) == 0 {
+		t.Fatal("nenhuma tabela encontrada nas migrações")
+	}
+
+	// Tabelas com company_id recebem a mesma política em um laço
+	covered := map[string]bool{}
+	for _, loop := range rlsLoopTables.FindAllStringSubmatch(rls, -1) {
+		body := loop[2]
+		if !strings.Contains(body, "ENABLE ROW LEVEL SECURITY") || !strings.Contains(body, "FORCE ROW LEVEL SECURITY") ||
+			!strings.Contains(body, "CREATE POLICY") {
+			t.Errorf("laço de RLS sem ENABLE, FORCE ou CREATE POLICY:\n%s", body)
+			continue
+		}
+		for _, name := range quotedName.FindAllStringSubmatch(loop[1], -1) {
+			covered[name[1]] = true
+		}
+	}
+
+	names := make([]string, 0, len(tables))
+	for table := range tables {
+		names = append(names, table)
+	}
+	sort.Strings(names)
+
+	for _, table := range names {
+		if covered[table] {
+			continue
+		}
+		for _, statement := range []string{
+			`ALTER TABLE ` + table + ` ENABLE ROW LEVEL SECURITY`,
+			`ALTER TABLE ` + table + ` FORCE ROW LEVEL SECURITY`,
+			`CREATE POLICY \w+ ON ` + table + `\b`,
+		} {
+			if !regexp.MustCompile(statement).MatchString(rls) {
+				t.Errorf("%s: migração %s sem %q", table, rlsMigrationVersion, statement)
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+-- Migração SQLite 001 (rollback): Esquema consolidado
+-- Descrição: Remove todas as tabelas; índices e triggers são removidos junto com elas.
+-- audit_logs sai primeiro para que as ações ON DELETE das chaves estrangeiras não
+-- esbarrem no trigger que impede alterações no log.
+
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS company_settings;
+DROP TABLE IF EXISTS developer_skills;
+DROP TABLE IF EXISTS developer_skill_assessments;
+DROP TABLE IF EXISTS skills;
+DROP TABLE IF EXISTS developer_level_changes;
+DROP TABLE IF EXISTS team_memberships;
+DROP TABLE IF EXISTS report_comment_reads;
+DROP TABLE IF EXISTS report_comments;
+DROP TABLE IF EXISTS one_on_one_action_items;
+DROP TABLE IF EXISTS one_on_ones;
+DROP TABLE IF EXISTS goal_progress_updates;
+DROP TABLE IF EXISTS goals;
+DROP TABLE IF EXISTS performance_reports;
+DROP TABLE IF EXISTS developers;
+DROP TABLE IF EXISTS career_levels;
+DROP TABLE IF EXISTS career_tracks;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS companies;
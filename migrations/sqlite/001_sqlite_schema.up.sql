@@ -0,0 +1,426 @@
+-- Migração SQLite 001: Esquema consolidado
+-- Descrição: Estado final das migrações 001 a 015 do PostgreSQL para o modo SQLite
+-- (desenvolvimento local e testes). Não há RLS: o isolamento entre empresas fica a
+-- cargo das verificações dos handlers.
+--
+-- Diferenças em relação ao PostgreSQL:
+--   * UUIDs são TEXT; uuid_generate_v4() é uma função registrada pela aplicação em Go
+--   * question_scores, category_scores, before_data e after_data são JSON em TEXT
+--   * Arrays (TEXT[]/UUID[]) são gravados como literal de array do PostgreSQL em TEXT
+--   * updated_at é atualizado por triggers AFTER UPDATE
+--   * A detecção de ciclos na hierarquia de times é feita apenas pelo handler
+
+CREATE TABLE IF NOT EXISTS companies (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL DEFAULT 'user' CHECK (role IN ('admin', 'manager', 'user')),
+    company_id TEXT REFERENCES companies(id) ON DELETE SET NULL,
+    needs_password_change BOOLEAN NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    color VARCHAR(50) DEFAULT 'blue',
+    company_id TEXT REFERENCES companies(id) ON DELETE CASCADE,
+    parent_id TEXT REFERENCES teams(id) ON DELETE SET NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'team' CHECK (kind IN ('department', 'team', 'squad')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS career_tracks (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    company_id TEXT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (company_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS career_levels (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    track_id TEXT NOT NULL REFERENCES career_tracks(id) ON DELETE CASCADE,
+    company_id TEXT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    rank INTEGER NOT NULL CHECK (rank >= 1),
+    expectations TEXT NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (track_id, rank),
+    UNIQUE (track_id, code)
+);
+
+CREATE TABLE IF NOT EXISTS developers (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    name VARCHAR(255) NOT NULL,
+    role VARCHAR(255) NOT NULL,
+    latest_performance_score DECIMAL(7,3) DEFAULT 0.00,
+    team_id TEXT REFERENCES teams(id) ON DELETE SET NULL,
+    company_id TEXT REFERENCES companies(id) ON DELETE CASCADE,
+    user_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    level_id TEXT REFERENCES career_levels(id) ON DELETE SET NULL,
+    archived_at TIMESTAMP NULL,
+    created_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    updated_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS performance_reports (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    developer_id TEXT NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    team_id TEXT,
+    month VARCHAR(7) NOT NULL, -- Formato YYYY-MM
+    question_scores TEXT NOT NULL CHECK (json_valid(question_scores)),
+    category_scores TEXT NOT NULL CHECK (json_valid(category_scores)),
+    weighted_average_score DECIMAL(7,3) NOT NULL,
+    highlights TEXT,
+    points_to_develop TEXT,
+    created_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    updated_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS goals (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    developer_id TEXT NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    company_id TEXT REFERENCES companies(id) ON DELETE CASCADE,
+    report_id TEXT REFERENCES performance_reports(id) ON DELETE SET NULL,
+    owner_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    categories TEXT NOT NULL DEFAULT '{}',
+    due_date DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'in_progress', 'completed', 'cancelled')),
+    progress INTEGER NOT NULL DEFAULT 0 CHECK (progress >= 0 AND progress <= 100),
+    completed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS goal_progress_updates (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    goal_id TEXT NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    author_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    progress INTEGER NOT NULL CHECK (progress >= 0 AND progress <= 100),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('open', 'in_progress', 'completed', 'cancelled')),
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS one_on_ones (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    developer_id TEXT NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    company_id TEXT REFERENCES companies(id) ON DELETE CASCADE,
+    author_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    meeting_date DATE NOT NULL,
+    agenda TEXT NOT NULL DEFAULT '{}',
+    notes TEXT,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'shared')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS one_on_one_action_items (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    one_on_one_id TEXT NOT NULL REFERENCES one_on_ones(id) ON DELETE CASCADE,
+    description TEXT NOT NULL,
+    owner_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    due_date DATE,
+    completed_at TIMESTAMP NULL,
+    completed_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS report_comments (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    report_id TEXT NOT NULL REFERENCES performance_reports(id) ON DELETE CASCADE,
+    company_id TEXT REFERENCES companies(id) ON DELETE CASCADE,
+    author_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    parent_id TEXT REFERENCES report_comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    mentions TEXT NOT NULL DEFAULT '{}',
+    edited_at TIMESTAMP NULL,
+    deleted_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS report_comment_reads (
+    report_id TEXT NOT NULL REFERENCES performance_reports(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (report_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    actor_id TEXT,
+    actor_email VARCHAR(255),
+    actor_role VARCHAR(50),
+    company_id TEXT,
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(100) NOT NULL,
+    entity_id TEXT,
+    before_data TEXT CHECK (before_data IS NULL OR json_valid(before_data)),
+    after_data TEXT CHECK (after_data IS NULL OR json_valid(after_data)),
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    method VARCHAR(10),
+    path TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS team_memberships (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    developer_id TEXT NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    team_id TEXT NOT NULL,
+    team_name VARCHAR(255) NOT NULL DEFAULT '',
+    company_id TEXT REFERENCES companies(id) ON DELETE CASCADE,
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    left_at TIMESTAMP NULL,
+    CHECK (left_at IS NULL OR left_at >= joined_at)
+);
+
+CREATE TABLE IF NOT EXISTS developer_level_changes (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    developer_id TEXT NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    company_id TEXT REFERENCES companies(id) ON DELETE CASCADE,
+    from_level_id TEXT REFERENCES career_levels(id) ON DELETE SET NULL,
+    to_level_id TEXT REFERENCES career_levels(id) ON DELETE SET NULL,
+    change_type VARCHAR(20) NOT NULL CHECK (change_type IN ('initial', 'promotion', 'demotion', 'lateral')),
+    effective_date DATE NOT NULL,
+    justification TEXT NOT NULL DEFAULT '',
+    created_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS skills (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    company_id TEXT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS developer_skill_assessments (
+    id TEXT PRIMARY KEY DEFAULT (uuid_generate_v4()),
+    developer_id TEXT NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    skill_id TEXT NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    company_id TEXT REFERENCES companies(id) ON DELETE CASCADE,
+    level INTEGER NOT NULL CHECK (level BETWEEN 1 AND 5),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('self', 'manager')),
+    assessed_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS developer_skills (
+    developer_id TEXT NOT NULL REFERENCES developers(id) ON DELETE CASCADE,
+    skill_id TEXT NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    company_id TEXT REFERENCES companies(id) ON DELETE CASCADE,
+    self_level INTEGER CHECK (self_level BETWEEN 1 AND 5),
+    validated_level INTEGER CHECK (validated_level BETWEEN 1 AND 5),
+    validated_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    validated_at TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (developer_id, skill_id)
+);
+
+CREATE TABLE IF NOT EXISTS company_settings (
+    company_id TEXT PRIMARY KEY REFERENCES companies(id) ON DELETE CASCADE,
+    score_min DECIMAL(7,3) NOT NULL DEFAULT 0,
+    score_max DECIMAL(7,3) NOT NULL DEFAULT 10,
+    score_step DECIMAL(7,3) NOT NULL DEFAULT 0.01,
+    decimal_places INTEGER NOT NULL DEFAULT 2 CHECK (decimal_places BETWEEN 0 AND 3),
+    locale VARCHAR(20) NOT NULL DEFAULT 'pt-BR',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'America/Sao_Paulo',
+    fiscal_year_start_month INTEGER NOT NULL DEFAULT 1 CHECK (fiscal_year_start_month BETWEEN 1 AND 12),
+    team_color_palette TEXT NOT NULL DEFAULT '{blue,green,purple,orange,red,teal}',
+    updated_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (score_max > score_min),
+    CHECK (score_step > 0)
+);
+
+-- Índices
+CREATE INDEX IF NOT EXISTS idx_companies_name ON companies(name);
+CREATE INDEX IF NOT EXISTS idx_companies_is_active ON companies(is_active);
+CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
+CREATE INDEX IF NOT EXISTS idx_users_is_active ON users(is_active);
+CREATE INDEX IF NOT EXISTS idx_users_company_id ON users(company_id);
+CREATE INDEX IF NOT EXISTS idx_teams_company_id ON teams(company_id);
+CREATE INDEX IF NOT EXISTS idx_teams_parent_id ON teams(parent_id);
+CREATE INDEX IF NOT EXISTS idx_developers_team_id ON developers(team_id);
+CREATE INDEX IF NOT EXISTS idx_developers_company_id ON developers(company_id);
+CREATE INDEX IF NOT EXISTS idx_developers_archived_at ON developers(archived_at);
+CREATE INDEX IF NOT EXISTS idx_developers_user_id ON developers(user_id);
+CREATE INDEX IF NOT EXISTS idx_developers_level_id ON developers(level_id);
+CREATE INDEX IF NOT EXISTS idx_performance_reports_developer_id ON performance_reports(developer_id);
+CREATE INDEX IF NOT EXISTS idx_performance_reports_month ON performance_reports(month);
+CREATE INDEX IF NOT EXISTS idx_performance_reports_developer_month ON performance_reports(developer_id, month);
+CREATE INDEX IF NOT EXISTS idx_performance_reports_team_id ON performance_reports(team_id);
+CREATE INDEX IF NOT EXISTS idx_goals_developer_id ON goals(developer_id);
+CREATE INDEX IF NOT EXISTS idx_goals_company_id ON goals(company_id);
+CREATE INDEX IF NOT EXISTS idx_goals_report_id ON goals(report_id);
+CREATE INDEX IF NOT EXISTS idx_goals_status ON goals(status);
+CREATE INDEX IF NOT EXISTS idx_goal_progress_updates_goal_id ON goal_progress_updates(goal_id);
+CREATE INDEX IF NOT EXISTS idx_one_on_ones_developer_id ON one_on_ones(developer_id);
+CREATE INDEX IF NOT EXISTS idx_one_on_ones_company_id ON one_on_ones(company_id);
+CREATE INDEX IF NOT EXISTS idx_one_on_ones_meeting_date ON one_on_ones(meeting_date);
+CREATE INDEX IF NOT EXISTS idx_one_on_one_action_items_one_on_one_id ON one_on_one_action_items(one_on_one_id);
+CREATE INDEX IF NOT EXISTS idx_one_on_one_action_items_owner_id ON one_on_one_action_items(owner_id);
+CREATE INDEX IF NOT EXISTS idx_report_comments_report_id ON report_comments(report_id);
+CREATE INDEX IF NOT EXISTS idx_report_comments_company_id ON report_comments(company_id);
+CREATE INDEX IF NOT EXISTS idx_report_comments_parent_id ON report_comments(parent_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_company_id ON audit_logs(company_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_developer_id ON team_memberships(developer_id);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_team_id ON team_memberships(team_id);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_company_id ON team_memberships(company_id);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_period ON team_memberships(team_id, joined_at, left_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_memberships_open ON team_memberships(developer_id) WHERE left_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_career_tracks_company_id ON career_tracks(company_id);
+CREATE INDEX IF NOT EXISTS idx_career_levels_track_id ON career_levels(track_id);
+CREATE INDEX IF NOT EXISTS idx_career_levels_company_id ON career_levels(company_id);
+CREATE INDEX IF NOT EXISTS idx_developer_level_changes_developer_id ON developer_level_changes(developer_id);
+CREATE INDEX IF NOT EXISTS idx_developer_level_changes_company_id ON developer_level_changes(company_id);
+CREATE INDEX IF NOT EXISTS idx_developer_level_changes_effective_date ON developer_level_changes(effective_date);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skills_company_name ON skills(company_id, LOWER(name));
+CREATE INDEX IF NOT EXISTS idx_skills_company_id ON skills(company_id);
+CREATE INDEX IF NOT EXISTS idx_developer_skill_assessments_developer_skill ON developer_skill_assessments(developer_id, skill_id);
+CREATE INDEX IF NOT EXISTS idx_developer_skill_assessments_company_id ON developer_skill_assessments(company_id);
+CREATE INDEX IF NOT EXISTS idx_developer_skills_skill_level ON developer_skills(skill_id, validated_level, self_level);
+CREATE INDEX IF NOT EXISTS idx_developer_skills_company_id ON developer_skills(company_id);
+
+-- updated_at (no PostgreSQL: update_updated_at_column)
+CREATE TRIGGER IF NOT EXISTS update_companies_updated_at AFTER UPDATE ON companies
+BEGIN UPDATE companies SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_users_updated_at AFTER UPDATE ON users
+BEGIN UPDATE users SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_teams_updated_at AFTER UPDATE ON teams
+BEGIN UPDATE teams SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_developers_updated_at AFTER UPDATE ON developers
+BEGIN UPDATE developers SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_performance_reports_updated_at AFTER UPDATE ON performance_reports
+BEGIN UPDATE performance_reports SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_goals_updated_at AFTER UPDATE ON goals
+BEGIN UPDATE goals SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_one_on_ones_updated_at AFTER UPDATE ON one_on_ones
+BEGIN UPDATE one_on_ones SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_one_on_one_action_items_updated_at AFTER UPDATE ON one_on_one_action_items
+BEGIN UPDATE one_on_one_action_items SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_report_comments_updated_at AFTER UPDATE ON report_comments
+BEGIN UPDATE report_comments SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_career_tracks_updated_at AFTER UPDATE ON career_tracks
+BEGIN UPDATE career_tracks SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_career_levels_updated_at AFTER UPDATE ON career_levels
+BEGIN UPDATE career_levels SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_skills_updated_at AFTER UPDATE ON skills
+BEGIN UPDATE skills SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;
+
+CREATE TRIGGER IF NOT EXISTS update_developer_skills_updated_at AFTER UPDATE ON developer_skills
+BEGIN
+    UPDATE developer_skills SET updated_at = CURRENT_TIMESTAMP
+    WHERE developer_id = NEW.developer_id AND skill_id = NEW.skill_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_company_settings_updated_at AFTER UPDATE ON company_settings
+BEGIN UPDATE company_settings SET updated_at = CURRENT_TIMESTAMP WHERE company_id = NEW.company_id; END;
+
+-- Log de auditoria somente inserção
+CREATE TRIGGER IF NOT EXISTS prevent_audit_logs_update BEFORE UPDATE ON audit_logs
+BEGIN SELECT RAISE(ABORT, 'audit_logs é somente inserção'); END;
+
+CREATE TRIGGER IF NOT EXISTS prevent_audit_logs_delete BEFORE DELETE ON audit_logs
+BEGIN SELECT RAISE(ABORT, 'audit_logs é somente inserção'); END;
+
+-- Histórico de times dos desenvolvedores
+CREATE TRIGGER IF NOT EXISTS track_developers_team_membership_insert AFTER INSERT ON developers
+WHEN NEW.team_id IS NOT NULL
+BEGIN
+    INSERT INTO team_memberships (developer_id, team_id, team_name, company_id, joined_at)
+    SELECT NEW.id, NEW.team_id, t.name, NEW.company_id, CURRENT_TIMESTAMP
+    FROM teams t
+    WHERE t.id = NEW.team_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS track_developers_team_membership_update AFTER UPDATE OF team_id ON developers
+WHEN NEW.team_id IS NOT OLD.team_id
+BEGIN
+    UPDATE team_memberships
+    SET left_at = CURRENT_TIMESTAMP
+    WHERE developer_id = NEW.id AND left_at IS NULL;
+
+    INSERT INTO team_memberships (developer_id, team_id, team_name, company_id, joined_at)
+    SELECT NEW.id, NEW.team_id, t.name, NEW.company_id, CURRENT_TIMESTAMP
+    FROM teams t
+    WHERE t.id = NEW.team_id;
+END;
+
+-- Hierarquia de times (ciclos são recusados pelo handler; o SQLite não aceita CTE em triggers)
+CREATE TRIGGER IF NOT EXISTS validate_teams_hierarchy_insert BEFORE INSERT ON teams
+WHEN NEW.parent_id IS NOT NULL
+BEGIN
+    SELECT RAISE(ABORT, 'um time não pode ser pai de si mesmo')
+    WHERE NEW.parent_id = NEW.id;
+    SELECT RAISE(ABORT, 'o time pai deve pertencer à mesma empresa')
+    WHERE NOT EXISTS (SELECT 1 FROM teams WHERE id = NEW.parent_id AND company_id IS NEW.company_id);
+END;
+
+CREATE TRIGGER IF NOT EXISTS validate_teams_hierarchy_update BEFORE UPDATE OF parent_id, company_id ON teams
+WHEN NEW.parent_id IS NOT NULL
+BEGIN
+    SELECT RAISE(ABORT, 'um time não pode ser pai de si mesmo')
+    WHERE NEW.parent_id = NEW.id;
+    SELECT RAISE(ABORT, 'o time pai deve pertencer à mesma empresa')
+    WHERE NOT EXISTS (SELECT 1 FROM teams WHERE id = NEW.parent_id AND company_id IS NEW.company_id);
+END;
+
+-- Empresa padrão (migração 006 no PostgreSQL)
+INSERT INTO companies (name, description, is_active)
+SELECT 'Tivix Technologies', 'Empresa padrão do sistema', 1
+WHERE NOT EXISTS (SELECT 1 FROM companies WHERE name = 'Tivix Technologies');
+
+INSERT INTO company_settings (company_id)
+SELECT id FROM companies
+WHERE true
+ON CONFLICT (company_id) DO NOTHING;
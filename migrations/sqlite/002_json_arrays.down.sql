@@ -0,0 +1,47 @@
+-- Migração SQLite 002 (rollback): Arrays como JSON
+-- Descrição: Volta os arrays JSON para o literal de array do PostgreSQL. Elementos vazios,
+-- com espaço, vírgula, chaves, aspas ou barra invertida ficam entre aspas, com escape.
+
+CREATE TEMP TABLE array_literals AS
+SELECT table_name, row_id, '{' || coalesce((
+    SELECT group_concat(
+        CASE
+            WHEN value = '' OR upper(value) = 'NULL' OR value GLOB '*[ ,{}"\]*'
+                THEN '"' || replace(replace(value, '\', '\\'), '"', '\"') || '"'
+            ELSE value
+        END, ',')
+    FROM (SELECT value FROM json_each(arrays.value) ORDER BY key)
+), '') || '}' AS value
+FROM (
+    SELECT 'goals' AS table_name, id AS row_id, categories AS value FROM goals WHERE categories LIKE '[%]'
+    UNION ALL
+    SELECT 'one_on_ones', id, agenda FROM one_on_ones WHERE agenda LIKE '[%]'
+    UNION ALL
+    SELECT 'report_comments', id, mentions FROM report_comments WHERE mentions LIKE '[%]'
+    UNION ALL
+    SELECT 'career_levels', id, expectations FROM career_levels WHERE expectations LIKE '[%]'
+    UNION ALL
+    SELECT 'company_settings', company_id, team_color_palette FROM company_settings WHERE team_color_palette LIKE '[%]'
+) AS arrays;
+
+UPDATE goals SET categories = (
+    SELECT value FROM array_literals WHERE table_name = 'goals' AND row_id = goals.id
+) WHERE id IN (SELECT row_id FROM array_literals WHERE table_name = 'goals');
+
+UPDATE one_on_ones SET agenda = (
+    SELECT value FROM array_literals WHERE table_name = 'one_on_ones' AND row_id = one_on_ones.id
+) WHERE id IN (SELECT row_id FROM array_literals WHERE table_name = 'one_on_ones');
+
+UPDATE report_comments SET mentions = (
+    SELECT value FROM array_literals WHERE table_name = 'report_comments' AND row_id = report_comments.id
+) WHERE id IN (SELECT row_id FROM array_literals WHERE table_name = 'report_comments');
+
+UPDATE career_levels SET expectations = (
+    SELECT value FROM array_literals WHERE table_name = 'career_levels' AND row_id = career_levels.id
+) WHERE id IN (SELECT row_id FROM array_literals WHERE table_name = 'career_levels');
+
+UPDATE company_settings SET team_color_palette = (
+    SELECT value FROM array_literals WHERE table_name = 'company_settings' AND row_id = company_settings.company_id
+) WHERE company_id IN (SELECT row_id FROM array_literals WHERE table_name = 'company_settings');
+
+DROP TABLE array_literals;
@@ -0,0 +1,81 @@
+-- Migração SQLite 002: Arrays como JSON
+-- Descrição: Converte as colunas de array, gravadas até aqui como literal de array do
+-- PostgreSQL em TEXT ('{a,"b c"}'), para arrays JSON ('["a","b c"]'), consultados com
+-- json_each. Os DEFAULT das tabelas continuam como literal: '{}' é também um objeto JSON
+-- vazio e a aplicação aceita os dois formatos na leitura, então não é preciso recriar as
+-- tabelas.
+
+CREATE TEMP TABLE array_literals (
+    table_name TEXT NOT NULL,
+    row_id TEXT NOT NULL,
+    literal TEXT NOT NULL
+);
+
+INSERT INTO array_literals
+SELECT 'goals', id, substr(categories, 2, length(categories) - 2) FROM goals WHERE categories LIKE '{%}'
+UNION ALL
+SELECT 'one_on_ones', id, substr(agenda, 2, length(agenda) - 2) FROM one_on_ones WHERE agenda LIKE '{%}'
+UNION ALL
+SELECT 'report_comments', id, substr(mentions, 2, length(mentions) - 2) FROM report_comments WHERE mentions LIKE '{%}'
+UNION ALL
+SELECT 'career_levels', id, substr(expectations, 2, length(expectations) - 2) FROM career_levels WHERE expectations LIKE '{%}'
+UNION ALL
+SELECT 'company_settings', company_id, substr(team_color_palette, 2, length(team_color_palette) - 2)
+FROM company_settings WHERE team_color_palette LIKE '{%}';
+
+-- Percorre o literal caractere a caractere: elementos entre aspas podem conter vírgulas e
+-- escapes com barra invertida; json_insert cuida do escape de cada elemento no JSON
+CREATE TEMP TABLE array_json AS
+WITH RECURSIVE parse (table_name, row_id, literal, pos, quoted, escaped, element, result) AS (
+    SELECT table_name, row_id, literal, 1, 0, 0, '', json_array() FROM array_literals
+    UNION ALL
+    SELECT table_name, row_id, literal, pos + 1,
+        CASE
+            WHEN escaped = 0 AND substr(literal, pos, 1) = '"' THEN 1 - quoted
+            ELSE quoted
+        END,
+        CASE
+            WHEN escaped = 0 AND quoted = 1 AND substr(literal, pos, 1) = '\' THEN 1
+            ELSE 0
+        END,
+        CASE
+            WHEN escaped = 1 THEN element || substr(literal, pos, 1)
+            WHEN substr(literal, pos, 1) = '"' THEN element
+            WHEN quoted = 1 AND substr(literal, pos, 1) = '\' THEN element
+            WHEN quoted = 0 AND substr(literal, pos, 1) = ',' THEN ''
+            ELSE element || substr(literal, pos, 1)
+        END,
+        CASE
+            WHEN escaped = 0 AND quoted = 0 AND substr(literal, pos, 1) = ',' THEN json_insert(result, '$[#]', element)
+            ELSE result
+        END
+    FROM parse
+    WHERE pos <= length(literal)
+)
+SELECT table_name, row_id,
+    CASE WHEN literal = '' THEN result ELSE json_insert(result, '$[#]', element) END AS value
+FROM parse
+WHERE pos = length(literal) + 1;
+
+UPDATE goals SET categories = (
+    SELECT value FROM array_json WHERE table_name = 'goals' AND row_id = goals.id
+) WHERE id IN (SELECT row_id FROM array_json WHERE table_name = 'goals');
+
+UPDATE one_on_ones SET agenda = (
+    SELECT value FROM array_json WHERE table_name = 'one_on_ones' AND row_id = one_on_ones.id
+) WHERE id IN (SELECT row_id FROM array_json WHERE table_name = 'one_on_ones');
+
+UPDATE report_comments SET mentions = (
+    SELECT value FROM array_json WHERE table_name = 'report_comments' AND row_id = report_comments.id
+) WHERE id IN (SELECT row_id FROM array_json WHERE table_name = 'report_comments');
+
+UPDATE career_levels SET expectations = (
+    SELECT value FROM array_json WHERE table_name = 'career_levels' AND row_id = career_levels.id
+) WHERE id IN (SELECT row_id FROM array_json WHERE table_name = 'career_levels');
+
+UPDATE company_settings SET team_color_palette = (
+    SELECT value FROM array_json WHERE table_name = 'company_settings' AND row_id = company_settings.company_id
+) WHERE company_id IN (SELECT row_id FROM array_json WHERE table_name = 'company_settings');
+
+DROP TABLE array_json;
+DROP TABLE array_literals;
@@ -4,16 +4,26 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
+
+	"tivix-performance-tracker-backend/database"
 )
 
 type JSONB map[string]interface{}
 
+// Value grava o JSON como texto, aceito tanto pelo jsonb do PostgreSQL quanto pela coluna TEXT do SQLite
 func (j JSONB) Value() (driver.Value, error) {
-	return json.Marshal(j)
+	b, err := json.Marshal(j)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
 }
 
 func (j *JSONB) Scan(value interface{}) error {
@@ -32,6 +42,78 @@ func (j *JSONB) Scan(value interface{}) error {
 	}
 }
 
+// Array é uma coluna de array: text[] ou uuid[] no PostgreSQL e array JSON em TEXT no SQLite,
+// onde os elementos são consultados com json_each. A leitura aceita os dois formatos, inclusive
+// o literal do PostgreSQL gravado pelos DEFAULT ('{}') das tabelas do SQLite.
+type Array[T any] []T
+
+func (a Array[T]) Value() (driver.Value, error) {
+	if !database.IsSQLite() {
+		return pq.Array([]T(a)).Value()
+	}
+	if a == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal([]T(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (a *Array[T]) Scan(value interface{}) error {
+	var text []byte
+	switch v := value.(type) {
+	case []byte:
+		text = v
+	case string:
+		text = []byte(v)
+	}
+	if len(text) > 0 && text[0] == '[' {
+		return json.Unmarshal(text, (*[]T)(a))
+	}
+	return pq.Array((*[]T)(a)).Scan(value)
+}
+
+// DBTime é um instante calculado na consulta (MAX, COALESCE...). O PostgreSQL o devolve como
+// time.Time; o SQLite, sem o tipo da coluna de origem, devolve o texto gravado.
+type DBTime struct {
+	time.Time
+}
+
+var dbTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+func (t *DBTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		t.Time = v
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	default:
+		return fmt.Errorf("cannot scan %T into DBTime", value)
+	}
+}
+
+func (t *DBTime) parse(value string) error {
+	value = strings.TrimSuffix(value, "Z")
+	for _, layout := range dbTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as DBTime", value)
+}
+
 type Company struct {
 	ID          uuid.UUID `json:"id" db:"id"`
 	Name        string    `json:"name" db:"name"`
@@ -41,14 +123,33 @@ type Company struct {
 	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at"`
 }
 
+type CompanySettings struct {
+	CompanyID            uuid.UUID     `json:"companyId" db:"company_id"`
+	ScoreMin             float64       `json:"scoreMin" db:"score_min"`
+	ScoreMax             float64       `json:"scoreMax" db:"score_max"`
+	ScoreStep            float64       `json:"scoreStep" db:"score_step"`
+	DecimalPlaces        int           `json:"decimalPlaces" db:"decimal_places"`
+	Locale               string        `json:"locale" db:"locale"`
+	Timezone             string        `json:"timezone" db:"timezone"`
+	FiscalYearStartMonth int           `json:"fiscalYearStartMonth" db:"fiscal_year_start_month"`
+	TeamColorPalette     Array[string] `json:"teamColorPalette" db:"team_color_palette"`
+	UpdatedBy            *uuid.UUID    `json:"updatedBy" db:"updated_by"`
+	CreatedAt            *time.Time    `json:"createdAt" db:"created_at"`
+	UpdatedAt            *time.Time    `json:"updatedAt" db:"updated_at"`
+}
+
 type Team struct {
 	ID          uuid.UUID  `json:"id" db:"id"`
 	Name        string     `json:"name" db:"name"`
 	Description string     `json:"description" db:"description"`
 	Color       string     `json:"color" db:"color"`
 	CompanyID   *uuid.UUID `json:"companyId" db:"company_id"`
+	ParentID    *uuid.UUID `json:"parentId" db:"parent_id"`
+	Kind        string     `json:"kind" db:"kind"` // department, team, squad
 	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updatedAt" db:"updated_at"`
+
+	Children []Team `json:"children,omitempty" db:"-"`
 }
 
 type Developer struct {
@@ -58,22 +159,267 @@ type Developer struct {
 	LatestPerformanceScore float64    `json:"latestPerformanceScore" db:"latest_performance_score"`
 	TeamID                 *uuid.UUID `json:"teamId" db:"team_id"`
 	CompanyID              *uuid.UUID `json:"companyId" db:"company_id"`
+	UserID                 *uuid.UUID `json:"userId" db:"user_id"`
+	LevelID                *uuid.UUID `json:"levelId" db:"level_id"`
 	ArchivedAt             *time.Time `json:"archivedAt" db:"archived_at"`
+	CreatedBy              *uuid.UUID `json:"createdBy" db:"created_by"`
+	UpdatedBy              *uuid.UUID `json:"updatedBy" db:"updated_by"`
 	CreatedAt              time.Time  `json:"createdAt" db:"created_at"`
 	UpdatedAt              time.Time  `json:"updatedAt" db:"updated_at"`
 }
 
 type PerformanceReport struct {
-	ID                   uuid.UUID `json:"id" db:"id"`
-	DeveloperID          uuid.UUID `json:"developerId" db:"developer_id"`
-	Month                string    `json:"month" db:"month"`
-	QuestionScores       JSONB     `json:"questionScores" db:"question_scores"`
-	CategoryScores       JSONB     `json:"categoryScores" db:"category_scores"`
-	WeightedAverageScore float64   `json:"weightedAverageScore" db:"weighted_average_score"`
-	Highlights           string    `json:"highlights" db:"highlights"`
-	PointsToDevelop      string    `json:"pointsToDevelop" db:"points_to_develop"`
-	CreatedAt            time.Time `json:"createdAt" db:"created_at"`
-	UpdatedAt            time.Time `json:"updatedAt" db:"updated_at"`
+	ID                   uuid.UUID  `json:"id" db:"id"`
+	DeveloperID          uuid.UUID  `json:"developerId" db:"developer_id"`
+	Month                string     `json:"month" db:"month"`
+	QuestionScores       JSONB      `json:"questionScores" db:"question_scores"`
+	CategoryScores       JSONB      `json:"categoryScores" db:"category_scores"`
+	WeightedAverageScore float64    `json:"weightedAverageScore" db:"weighted_average_score"`
+	Highlights           string     `json:"highlights" db:"highlights"`
+	PointsToDevelop      string     `json:"pointsToDevelop" db:"points_to_develop"`
+	TeamID               *uuid.UUID `json:"teamId" db:"team_id"`
+	CreatedBy            *uuid.UUID `json:"createdBy" db:"created_by"`
+	UpdatedBy            *uuid.UUID `json:"updatedBy" db:"updated_by"`
+	CreatedAt            time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt            time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+type Goal struct {
+	ID          uuid.UUID     `json:"id" db:"id"`
+	DeveloperID uuid.UUID     `json:"developerId" db:"developer_id"`
+	CompanyID   *uuid.UUID    `json:"companyId" db:"company_id"`
+	ReportID    *uuid.UUID    `json:"reportId" db:"report_id"`
+	OwnerID     *uuid.UUID    `json:"ownerId" db:"owner_id"`
+	Title       string        `json:"title" db:"title"`
+	Description string        `json:"description" db:"description"`
+	Categories  Array[string] `json:"categories" db:"categories"`
+	DueDate     *time.Time    `json:"dueDate" db:"due_date"`
+	Status      string        `json:"status" db:"status"` // open, in_progress, completed, cancelled
+	Progress    int           `json:"progress" db:"progress"`
+	CompletedAt *time.Time    `json:"completedAt" db:"completed_at"`
+	CreatedAt   time.Time     `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time     `json:"updatedAt" db:"updated_at"`
+
+	ProgressUpdates []GoalProgressUpdate `json:"progressUpdates,omitempty" db:"-"`
+}
+
+type GoalProgressUpdate struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	GoalID    uuid.UUID  `json:"goalId" db:"goal_id"`
+	AuthorID  *uuid.UUID `json:"authorId" db:"author_id"`
+	Progress  int        `json:"progress" db:"progress"`
+	Status    string     `json:"status" db:"status"`
+	Note      string     `json:"note" db:"note"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+}
+
+type OneOnOne struct {
+	ID          uuid.UUID     `json:"id" db:"id"`
+	DeveloperID uuid.UUID     `json:"developerId" db:"developer_id"`
+	CompanyID   *uuid.UUID    `json:"companyId" db:"company_id"`
+	AuthorID    *uuid.UUID    `json:"authorId" db:"author_id"`
+	MeetingDate time.Time     `json:"meetingDate" db:"meeting_date"`
+	Agenda      Array[string] `json:"agenda" db:"agenda"`
+	Notes       string        `json:"notes" db:"notes"`
+	Visibility  string        `json:"visibility" db:"visibility"` // private, shared
+	CreatedAt   time.Time     `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time     `json:"updatedAt" db:"updated_at"`
+
+	ActionItems []OneOnOneActionItem `json:"actionItems" db:"-"`
+}
+
+type OneOnOneActionItem struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	OneOnOneID  uuid.UUID  `json:"oneOnOneId" db:"one_on_one_id"`
+	Description string     `json:"description" db:"description"`
+	OwnerID     *uuid.UUID `json:"ownerId" db:"owner_id"`
+	DueDate     *time.Time `json:"dueDate" db:"due_date"`
+	CompletedAt *time.Time `json:"completedAt" db:"completed_at"`
+	CompletedBy *uuid.UUID `json:"completedBy" db:"completed_by"`
+	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+type ReportComment struct {
+	ID        uuid.UUID        `json:"id" db:"id"`
+	ReportID  uuid.UUID        `json:"reportId" db:"report_id"`
+	CompanyID *uuid.UUID       `json:"companyId" db:"company_id"`
+	AuthorID  *uuid.UUID       `json:"authorId" db:"author_id"`
+	ParentID  *uuid.UUID       `json:"parentId" db:"parent_id"`
+	Body      string           `json:"body" db:"body"`
+	Mentions  Array[uuid.UUID] `json:"mentions" db:"mentions"`
+	EditedAt  *time.Time       `json:"editedAt" db:"edited_at"`
+	DeletedAt *time.Time       `json:"deletedAt" db:"deleted_at"`
+	CreatedAt time.Time        `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time        `json:"updatedAt" db:"updated_at"`
+
+	AuthorName string          `json:"authorName" db:"author_name"`
+	Replies    []ReportComment `json:"replies,omitempty" db:"-"`
+}
+
+type ReportCommentUnread struct {
+	ReportID      uuid.UUID `json:"reportId" db:"report_id"`
+	UnreadCount   int       `json:"unreadCount" db:"unread_count"`
+	MentionCount  int       `json:"mentionCount" db:"mention_count"`
+	LastCommentAt *DBTime   `json:"lastCommentAt" db:"last_comment_at"`
+}
+
+type AuditLog struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	ActorID    *uuid.UUID `json:"actorId" db:"actor_id"`
+	ActorEmail string     `json:"actorEmail" db:"actor_email"`
+	ActorRole  string     `json:"actorRole" db:"actor_role"`
+	CompanyID  *uuid.UUID `json:"companyId" db:"company_id"`
+	Action     string     `json:"action" db:"action"`
+	EntityType string     `json:"entityType" db:"entity_type"`
+	EntityID   *uuid.UUID `json:"entityId" db:"entity_id"`
+	Before     JSONB      `json:"before" db:"before_data"`
+	After      JSONB      `json:"after" db:"after_data"`
+	IPAddress  string     `json:"ipAddress" db:"ip_address"`
+	UserAgent  string     `json:"userAgent" db:"user_agent"`
+	Method     string     `json:"method" db:"method"`
+	Path       string     `json:"path" db:"path"`
+	CreatedAt  time.Time  `json:"createdAt" db:"created_at"`
+}
+
+type TeamMembership struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	DeveloperID   uuid.UUID  `json:"developerId" db:"developer_id"`
+	DeveloperName string     `json:"developerName,omitempty" db:"developer_name"`
+	TeamID        uuid.UUID  `json:"teamId" db:"team_id"`
+	TeamName      string     `json:"teamName" db:"team_name"`
+	CompanyID     *uuid.UUID `json:"companyId" db:"company_id"`
+	JoinedAt      time.Time  `json:"joinedAt" db:"joined_at"`
+	LeftAt        *time.Time `json:"leftAt" db:"left_at"`
+}
+
+type CareerTrack struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	CompanyID   uuid.UUID `json:"companyId" db:"company_id"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at"`
+
+	Levels []CareerLevel `json:"levels" db:"-"`
+}
+
+type CareerLevel struct {
+	ID           uuid.UUID     `json:"id" db:"id"`
+	TrackID      uuid.UUID     `json:"trackId" db:"track_id"`
+	CompanyID    uuid.UUID     `json:"companyId" db:"company_id"`
+	Code         string        `json:"code" db:"code"`
+	Name         string        `json:"name" db:"name"`
+	Rank         int           `json:"rank" db:"rank"`
+	Expectations Array[string] `json:"expectations" db:"expectations"`
+	CreatedAt    time.Time     `json:"createdAt" db:"created_at"`
+	UpdatedAt    time.Time     `json:"updatedAt" db:"updated_at"`
+}
+
+type DeveloperLevelChange struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	DeveloperID   uuid.UUID  `json:"developerId" db:"developer_id"`
+	CompanyID     *uuid.UUID `json:"companyId" db:"company_id"`
+	FromLevelID   *uuid.UUID `json:"fromLevelId" db:"from_level_id"`
+	ToLevelID     *uuid.UUID `json:"toLevelId" db:"to_level_id"`
+	ChangeType    string     `json:"changeType" db:"change_type"` // initial, promotion, demotion, lateral
+	EffectiveDate time.Time  `json:"effectiveDate" db:"effective_date"`
+	Justification string     `json:"justification" db:"justification"`
+	CreatedBy     *uuid.UUID `json:"createdBy" db:"created_by"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+
+	FromLevelName string `json:"fromLevelName" db:"from_level_name"`
+	ToLevelName   string `json:"toLevelName" db:"to_level_name"`
+}
+
+// CareerLevelStats compara a performance dos desenvolvedores de um nível
+type CareerLevelStats struct {
+	LevelID        uuid.UUID `json:"levelId" db:"level_id"`
+	LevelCode      string    `json:"levelCode" db:"level_code"`
+	LevelName      string    `json:"levelName" db:"level_name"`
+	Rank           int       `json:"rank" db:"rank"`
+	TrackID        uuid.UUID `json:"trackId" db:"track_id"`
+	TrackName      string    `json:"trackName" db:"track_name"`
+	DeveloperCount int       `json:"developerCount" db:"developer_count"`
+	AverageScore   float64   `json:"averageScore" db:"average_score"`
+	MinScore       float64   `json:"minScore" db:"min_score"`
+	MaxScore       float64   `json:"maxScore" db:"max_score"`
+}
+
+// PromotionCandidate é um desenvolvedor cuja média recente alcança a média do nível seguinte
+type PromotionCandidate struct {
+	DeveloperID       uuid.UUID `json:"developerId"`
+	DeveloperName     string    `json:"developerName"`
+	CurrentLevelID    uuid.UUID `json:"currentLevelId"`
+	CurrentLevelName  string    `json:"currentLevelName"`
+	NextLevelID       uuid.UUID `json:"nextLevelId"`
+	NextLevelName     string    `json:"nextLevelName"`
+	RecentAverage     float64   `json:"recentAverage"`
+	NextLevelAverage  float64   `json:"nextLevelAverage"`
+	ReportsConsidered int       `json:"reportsConsidered"`
+	MonthsInLevel     int       `json:"monthsInLevel"`
+}
+
+type Skill struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	CompanyID   uuid.UUID `json:"companyId" db:"company_id"`
+	Name        string    `json:"name" db:"name"`
+	Category    string    `json:"category" db:"category"`
+	Description string    `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// DeveloperSkill é a proficiência atual; EffectiveLevel prioriza o nível validado pelo gestor
+type DeveloperSkill struct {
+	DeveloperID    uuid.UUID  `json:"developerId" db:"developer_id"`
+	SkillID        uuid.UUID  `json:"skillId" db:"skill_id"`
+	CompanyID      *uuid.UUID `json:"companyId" db:"company_id"`
+	SelfLevel      *int       `json:"selfLevel" db:"self_level"`
+	ValidatedLevel *int       `json:"validatedLevel" db:"validated_level"`
+	ValidatedBy    *uuid.UUID `json:"validatedBy" db:"validated_by"`
+	ValidatedAt    *time.Time `json:"validatedAt" db:"validated_at"`
+	UpdatedAt      time.Time  `json:"updatedAt" db:"updated_at"`
+
+	SkillName      string `json:"skillName" db:"skill_name"`
+	SkillCategory  string `json:"skillCategory" db:"skill_category"`
+	DeveloperName  string `json:"developerName,omitempty" db:"developer_name"`
+	EffectiveLevel int    `json:"effectiveLevel" db:"effective_level"`
+}
+
+type DeveloperSkillAssessment struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	DeveloperID uuid.UUID  `json:"developerId" db:"developer_id"`
+	SkillID     uuid.UUID  `json:"skillId" db:"skill_id"`
+	CompanyID   *uuid.UUID `json:"companyId" db:"company_id"`
+	Level       int        `json:"level" db:"level"`
+	Source      string     `json:"source" db:"source"` // self, manager
+	AssessedBy  *uuid.UUID `json:"assessedBy" db:"assessed_by"`
+	Note        string     `json:"note" db:"note"`
+	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// TeamSkillCoverage indica quantos integrantes do time atingem o nível mínimo de uma competência
+type TeamSkillCoverage struct {
+	SkillID        uuid.UUID `json:"skillId" db:"skill_id"`
+	SkillName      string    `json:"skillName" db:"skill_name"`
+	SkillCategory  string    `json:"skillCategory" db:"skill_category"`
+	QualifiedCount int       `json:"qualifiedCount" db:"qualified_count"`
+	AssessedCount  int       `json:"assessedCount" db:"assessed_count"`
+	AverageLevel   float64   `json:"averageLevel" db:"average_level"`
+	MaxLevel       int       `json:"maxLevel" db:"max_level"`
+	IsGap          bool      `json:"isGap" db:"is_gap"`
+}
+
+type UpdateCompanySettingsRequest struct {
+	ScoreMin             *float64 `json:"scoreMin,omitempty"`
+	ScoreMax             *float64 `json:"scoreMax,omitempty"`
+	ScoreStep            *float64 `json:"scoreStep,omitempty" validate:"omitempty,gt=0"`
+	DecimalPlaces        *int     `json:"decimalPlaces,omitempty" validate:"omitempty,min=0,max=3"`
+	Locale               *string  `json:"locale,omitempty" validate:"omitempty,bcp47_language_tag"`
+	Timezone             *string  `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	FiscalYearStartMonth *int     `json:"fiscalYearStartMonth,omitempty" validate:"omitempty,min=1,max=12"`
+	TeamColorPalette     []string `json:"teamColorPalette,omitempty" validate:"omitempty,min=1,max=24,dive,min=1,max=50,no_html"`
 }
 
 type CreateTeamRequest struct {
@@ -81,6 +427,8 @@ type CreateTeamRequest struct {
 	Description string     `json:"description"`
 	Color       string     `json:"color"`
 	CompanyID   *uuid.UUID `json:"companyId,omitempty"`
+	ParentID    *uuid.UUID `json:"parentId,omitempty"`
+	Kind        string     `json:"kind,omitempty" validate:"omitempty,oneof=department team squad"`
 }
 
 type UpdateTeamRequest struct {
@@ -88,6 +436,29 @@ type UpdateTeamRequest struct {
 	Description *string    `json:"description,omitempty"`
 	Color       *string    `json:"color,omitempty"`
 	CompanyID   *uuid.UUID `json:"companyId,omitempty"`
+	Kind        *string    `json:"kind,omitempty" validate:"omitempty,oneof=department team squad"`
+}
+
+type MoveTeamRequest struct {
+	ParentID *uuid.UUID `json:"parentId"`
+}
+
+// TeamStats agrega a performance de uma unidade; Rollup inclui todas as unidades descendentes
+type TeamStats struct {
+	TeamID         uuid.UUID      `json:"teamId"`
+	Name           string         `json:"name"`
+	Kind           string         `json:"kind"`
+	DeveloperCount int            `json:"developerCount"`
+	ReportCount    int            `json:"reportCount"`
+	AverageScore   float64        `json:"averageScore"`
+	Rollup         TeamStatsTotal `json:"rollup"`
+	Children       []TeamStats    `json:"children"`
+}
+
+type TeamStatsTotal struct {
+	DeveloperCount int     `json:"developerCount"`
+	ReportCount    int     `json:"reportCount"`
+	AverageScore   float64 `json:"averageScore"`
 }
 
 type CreateDeveloperRequest struct {
@@ -95,6 +466,7 @@ type CreateDeveloperRequest struct {
 	Role      string     `json:"role" validate:"required,min=2"`
 	TeamID    *uuid.UUID `json:"teamId,omitempty"`
 	CompanyID *uuid.UUID `json:"companyId,omitempty"`
+	UserID    *uuid.UUID `json:"userId,omitempty"`
 }
 
 type UpdateDeveloperRequest struct {
@@ -103,6 +475,7 @@ type UpdateDeveloperRequest struct {
 	LatestPerformanceScore *float64   `json:"latestPerformanceScore,omitempty"`
 	TeamID                 *uuid.UUID `json:"teamId,omitempty"`
 	CompanyID              *uuid.UUID `json:"companyId,omitempty"`
+	UserID                 *uuid.UUID `json:"userId,omitempty"`
 }
 
 type CreatePerformanceReportRequest struct {
@@ -110,9 +483,141 @@ type CreatePerformanceReportRequest struct {
 	Month                string    `json:"month" validate:"required"`
 	QuestionScores       JSONB     `json:"questionScores" validate:"required"`
 	CategoryScores       JSONB     `json:"categoryScores" validate:"required"`
-	WeightedAverageScore float64   `json:"weightedAverageScore" validate:"required,min=0,max=10"`
+	WeightedAverageScore float64   `json:"weightedAverageScore"` // faixa validada pela escala da empresa
 	Highlights           string    `json:"highlights"`
 	PointsToDevelop      string    `json:"pointsToDevelop"`
+
+	Goals []CreateGoalRequest `json:"goals,omitempty" validate:"omitempty,dive"`
+}
+
+// PerformanceStats resume as notas dos relatórios da empresa (GET /performance-reports/stats)
+type PerformanceStats struct {
+	TotalReports int     `json:"totalReports"`
+	AverageScore float64 `json:"averageScore"`
+	HighestScore float64 `json:"highestScore"`
+	LowestScore  float64 `json:"lowestScore"`
+}
+
+// CompanyPerformanceStats são as estatísticas de uma empresa no ano fiscal e na precisão configurados por ela
+type CompanyPerformanceStats struct {
+	CompanyID     uuid.UUID        `json:"companyId"`
+	CompanyName   string           `json:"companyName"`
+	Stats         PerformanceStats `json:"stats"`
+	DecimalPlaces int              `json:"decimalPlaces"`
+	PeriodFrom    string           `json:"periodFrom,omitempty"`
+	PeriodTo      string           `json:"periodTo,omitempty"`
+}
+
+type CreateCareerTrackRequest struct {
+	Name        string     `json:"name" validate:"required,min=2,max=255,no_html"`
+	Description string     `json:"description" validate:"omitempty,max=2000,no_html"`
+	CompanyID   *uuid.UUID `json:"companyId,omitempty"`
+}
+
+type UpdateCareerTrackRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=255,no_html"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=2000,no_html"`
+}
+
+type CreateCareerLevelRequest struct {
+	Code         string   `json:"code" validate:"required,min=1,max=20,no_html"`
+	Name         string   `json:"name" validate:"required,min=2,max=255,no_html"`
+	Rank         int      `json:"rank" validate:"required,min=1"`
+	Expectations []string `json:"expectations,omitempty" validate:"omitempty,dive,min=1,max=1000,no_html"`
+}
+
+type UpdateCareerLevelRequest struct {
+	Code         *string  `json:"code,omitempty" validate:"omitempty,min=1,max=20,no_html"`
+	Name         *string  `json:"name,omitempty" validate:"omitempty,min=2,max=255,no_html"`
+	Rank         *int     `json:"rank,omitempty" validate:"omitempty,min=1"`
+	Expectations []string `json:"expectations,omitempty" validate:"omitempty,dive,min=1,max=1000,no_html"`
+}
+
+type ChangeDeveloperLevelRequest struct {
+	LevelID       uuid.UUID `json:"levelId" validate:"required"`
+	EffectiveDate string    `json:"effectiveDate" validate:"required,datetime=2006-01-02"`
+	Justification string    `json:"justification" validate:"required,min=10,max=5000,no_html"`
+}
+
+type CreateSkillRequest struct {
+	Name        string     `json:"name" validate:"required,min=1,max=255,no_html"`
+	Category    string     `json:"category" validate:"omitempty,max=100,no_html"`
+	Description string     `json:"description" validate:"omitempty,max=2000,no_html"`
+	CompanyID   *uuid.UUID `json:"companyId,omitempty"`
+}
+
+type UpdateSkillRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=255,no_html"`
+	Category    *string `json:"category,omitempty" validate:"omitempty,max=100,no_html"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=2000,no_html"`
+}
+
+type CreateSkillAssessmentRequest struct {
+	SkillID uuid.UUID `json:"skillId" validate:"required"`
+	Level   int       `json:"level" validate:"required,min=1,max=5"`
+	Note    string    `json:"note" validate:"omitempty,max=2000,no_html"`
+}
+
+type CreateGoalRequest struct {
+	Title       string     `json:"title" validate:"required,min=2,max=255,no_html"`
+	Description string     `json:"description" validate:"omitempty,max=2000,no_html"`
+	OwnerID     *uuid.UUID `json:"ownerId,omitempty"`
+	ReportID    *uuid.UUID `json:"reportId,omitempty"`
+	Categories  []string   `json:"categories,omitempty" validate:"omitempty,dive,min=1,max=100"`
+	DueDate     *string    `json:"dueDate,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type UpdateGoalRequest struct {
+	Title       *string    `json:"title,omitempty" validate:"omitempty,min=2,max=255,no_html"`
+	Description *string    `json:"description,omitempty" validate:"omitempty,max=2000,no_html"`
+	OwnerID     *uuid.UUID `json:"ownerId,omitempty"`
+	Categories  []string   `json:"categories,omitempty" validate:"omitempty,dive,min=1,max=100"`
+	DueDate     *string    `json:"dueDate,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type CreateGoalProgressRequest struct {
+	Progress int    `json:"progress" validate:"min=0,max=100"`
+	Status   string `json:"status" validate:"omitempty,oneof=open in_progress completed cancelled"`
+	Note     string `json:"note" validate:"omitempty,max=2000,no_html"`
+}
+
+type CreateOneOnOneRequest struct {
+	MeetingDate string                            `json:"meetingDate" validate:"required,datetime=2006-01-02"`
+	Agenda      []string                          `json:"agenda,omitempty" validate:"omitempty,dive,min=1,max=500"`
+	Notes       string                            `json:"notes" validate:"omitempty,max=20000"`
+	Visibility  string                            `json:"visibility" validate:"omitempty,oneof=private shared"`
+	ActionItems []CreateOneOnOneActionItemRequest `json:"actionItems,omitempty" validate:"omitempty,dive"`
+}
+
+type UpdateOneOnOneRequest struct {
+	MeetingDate *string  `json:"meetingDate,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Agenda      []string `json:"agenda,omitempty" validate:"omitempty,dive,min=1,max=500"`
+	Notes       *string  `json:"notes,omitempty" validate:"omitempty,max=20000"`
+	Visibility  *string  `json:"visibility,omitempty" validate:"omitempty,oneof=private shared"`
+}
+
+type CreateOneOnOneActionItemRequest struct {
+	Description string     `json:"description" validate:"required,min=2,max=2000,no_html"`
+	OwnerID     *uuid.UUID `json:"ownerId,omitempty"`
+	DueDate     *string    `json:"dueDate,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type UpdateOneOnOneActionItemRequest struct {
+	Description *string    `json:"description,omitempty" validate:"omitempty,min=2,max=2000,no_html"`
+	OwnerID     *uuid.UUID `json:"ownerId,omitempty"`
+	DueDate     *string    `json:"dueDate,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Completed   *bool      `json:"completed,omitempty"`
+}
+
+type CreateReportCommentRequest struct {
+	Body     string      `json:"body" validate:"required,min=1,max=5000,no_html"`
+	ParentID *uuid.UUID  `json:"parentId,omitempty"`
+	Mentions []uuid.UUID `json:"mentions,omitempty" validate:"omitempty,max=20"`
+}
+
+type UpdateReportCommentRequest struct {
+	Body     string      `json:"body" validate:"required,min=1,max=5000,no_html"`
+	Mentions []uuid.UUID `json:"mentions,omitempty" validate:"omitempty,max=20"`
 }
 
 type ArchiveDeveloperRequest struct {
@@ -152,6 +657,14 @@ type RegisterRequest struct {
 	Role     string `json:"role" validate:"omitempty,oneof=admin manager user"`
 }
 
+// InitRequest cria o primeiro administrador do sistema (POST /api/v1/init/admin)
+type InitRequest struct {
+	InstallKey string `json:"installKey"`
+	Email      string `json:"email" validate:"required,email"`
+	Password   string `json:"password" validate:"required,min=6"`
+	Name       string `json:"name" validate:"required,min=2"`
+}
+
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email,no_html"`
 	Password string `json:"password" validate:"required,max=128"`
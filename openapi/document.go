@@ -0,0 +1,295 @@
+// Package openapi descreve a API em um documento OpenAPI 3.1 e o serve, junto com a
+// interface de documentação (Swagger UI embutida no binário), em /api/v1/openapi.json e
+// /api/v1/docs. Os schemas dos corpos são gerados a partir dos structs de models, incluindo
+// as regras de validação.
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/apierror"
+)
+
+// Version é a versão da API publicada no documento
+const Version = "1.0.0"
+
+// Document é a raiz do documento OpenAPI
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Tags       []Tag               `json:"tags"`
+	Security   []Requirement       `json:"security"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Requirement associa um esquema de segurança aos escopos exigidos
+type Requirement map[string][]string
+
+// PathItem agrupa as operações de um caminho por método (get, post, put, delete)
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description,omitempty"`
+	Security    *[]Requirement       `json:"security,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	Responses       map[string]*Response       `json:"responses"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+const bearerAuth = "bearerAuth"
+
+// errorResponses são as respostas de erro compartilhadas, todas no envelope de apierror
+var errorResponses = []struct {
+	name        string
+	status      int
+	description string
+}{
+	{"BadRequest", fiber.StatusBadRequest, "Requisição inválida (INVALID_BODY, VALIDATION_FAILED, INVALID_ID...)"},
+	{"Unauthorized", fiber.StatusUnauthorized, "Token ausente, inválido ou expirado"},
+	{"Forbidden", fiber.StatusForbidden, "Papel insuficiente, usuário inativo ou troca de senha pendente"},
+	{"NotFound", fiber.StatusNotFound, "Recurso inexistente ou de outra empresa"},
+	{"TooManyRequests", fiber.StatusTooManyRequests, "Limite de requisições excedido"},
+	{"InternalError", fiber.StatusInternalServerError, "Erro inesperado"},
+}
+
+// pathParam encontra os parâmetros de caminho no formato do Fiber (:id)
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// Path converte um caminho do Fiber (/teams/:id) para o formato do OpenAPI (/teams/{id})
+func Path(fiberPath string) string {
+	path := pathParam.ReplaceAllString(fiberPath, "{$1}")
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// Build monta o documento com todas as rotas de routes.SetupRoutes
+func Build() *Document {
+	g := newGenerator()
+
+	doc := &Document{
+		OpenAPI: "3.1.0",
+		Info: Info{
+			Title:   "Tivix Performance Tracker API",
+			Version: Version,
+			Description: "API de acompanhamento de performance de desenvolvedores. Os erros usam o envelope " +
+				"`{error, code, message, details, requestId}`; decida sempre pelo `code`. As mensagens " +
+				"seguem o idioma do cabeçalho Accept-Language (pt-BR, en, es).",
+		},
+		Security: []Requirement{{bearerAuth: {}}},
+		Paths:    map[string]PathItem{},
+		Components: Components{
+			Responses: map[string]*Response{},
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	errorSchema := g.named("Error", apierror.Response{})
+	g.schemas["Error"].Properties["code"].Enum = apierror.Codes()
+	for _, response := range errorResponses {
+		doc.Components.Responses[response.name] = &Response{
+			Description: response.description,
+			Content:     map[string]MediaType{fiber.MIMEApplicationJSON: {Schema: errorSchema}},
+		}
+	}
+
+	tags := map[string]bool{}
+	for _, op := range operations(g) {
+		path := Path(op.path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(op.method)] = op.build()
+
+		if !tags[op.tag] {
+			tags[op.tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: op.tag})
+		}
+	}
+
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+// operation descreve uma rota de routes.SetupRoutes
+type operation struct {
+	method  string
+	path    string // formato do Fiber, com o prefixo /api/v1
+	id      string
+	tag     string
+	summary string
+	access  access
+	query   []Parameter
+	// body é o schema do corpo da requisição (nil quando a rota não recebe corpo)
+	body *Schema
+	// status é o status de sucesso; 0 equivale a 200
+	status   int
+	response *Schema
+	// content substitui response quando a resposta não é (apenas) JSON, por tipo de conteúdo
+	content map[string]*Schema
+}
+
+// access é o controle de acesso da rota
+type access int
+
+const (
+	public access = iota
+	authenticated
+	managerOrAdmin
+	adminOnly
+)
+
+func (a access) description() string {
+	switch a {
+	case managerOrAdmin:
+		return "Requer papel manager ou admin."
+	case adminOnly:
+		return "Requer papel admin."
+	}
+	return ""
+}
+
+func (op operation) build() *Operation {
+	built := &Operation{
+		OperationID: op.id,
+		Tags:        []string{op.tag},
+		Summary:     op.summary,
+		Description: op.access.description(),
+		Responses:   map[string]*Response{},
+	}
+
+	for _, name := range pathParam.FindAllStringSubmatch(op.path, -1) {
+		built.Parameters = append(built.Parameters, Parameter{
+			Name:     name[1],
+			In:       "path",
+			Required: true,
+			Schema:   pathParamSchema(name[1]),
+		})
+	}
+	built.Parameters = append(built.Parameters, op.query...)
+
+	if op.body != nil {
+		built.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{fiber.MIMEApplicationJSON: {Schema: op.body}},
+		}
+	}
+
+	status := op.status
+	if status == 0 {
+		status = fiber.StatusOK
+	}
+	content := op.content
+	if content == nil {
+		content = map[string]*Schema{fiber.MIMEApplicationJSON: op.response}
+	}
+	success := &Response{Description: op.summary, Content: map[string]MediaType{}}
+	for contentType, schema := range content {
+		success.Content[contentType] = MediaType{Schema: schema}
+	}
+	built.Responses[fmt.Sprint(status)] = success
+
+	errors := []string{"TooManyRequests", "InternalError"}
+	if op.access == public {
+		built.Security = &[]Requirement{}
+	} else {
+		errors = append(errors, "Unauthorized", "Forbidden")
+	}
+	if len(built.Parameters) > 0 || op.body != nil {
+		errors = append(errors, "BadRequest")
+	}
+	if strings.Contains(op.path, ":") {
+		errors = append(errors, "NotFound")
+	}
+	for _, name := range errors {
+		for _, response := range errorResponses {
+			if response.name == name {
+				built.Responses[fmt.Sprint(response.status)] = &Response{Ref: "#/components/responses/" + name}
+			}
+		}
+	}
+
+	return built
+}
+
+// pathParamSchema descreve um parâmetro de caminho: meses no formato YYYY-MM, arquivos da
+// Swagger UI pelo nome, os demais UUIDs
+func pathParamSchema(name string) *Schema {
+	switch name {
+	case "month":
+		return &Schema{Type: "string", Pattern: `^\d{4}-\d{2}$`}
+	case "file":
+		return &Schema{Type: "string", Enum: []string{"swagger-ui-bundle.js", "swagger-ui.css"}}
+	}
+	return &Schema{Type: "string", Format: "uuid"}
+}
+
+// Operations lista método e caminho (formato do OpenAPI) de cada operação do documento
+func (d *Document) Operations() []string {
+	var operations []string
+	for path, item := range d.Paths {
+		for method := range item {
+			operations = append(operations, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(operations)
+	return operations
+}
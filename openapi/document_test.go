@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/models"
+)
+
+func TestSchemaFromModel(t *testing.T) {
+	g := newGenerator()
+	g.of(models.CreateGoalRequest{})
+
+	schema := g.schemas["CreateGoalRequest"]
+	if schema == nil {
+		t.Fatal("CreateGoalRequest não foi registrado como componente")
+	}
+	if !reflect.DeepEqual(schema.Required, []string{"title"}) {
+		t.Errorf("required: %v", schema.Required)
+	}
+
+	title := schema.Properties["title"]
+	if title.Type != "string" || *title.MinLength != 2 || *title.MaxLength != 255 {
+		t.Errorf("title: %+v", title)
+	}
+	if dueDate := schema.Properties["dueDate"]; dueDate.Format != "date" || !reflect.DeepEqual(dueDate.Type, []string{"string", "null"}) {
+		t.Errorf("dueDate: %+v", dueDate)
+	}
+	if owner := schema.Properties["ownerId"]; owner.Format != "uuid" {
+		t.Errorf("ownerId: %+v", owner)
+	}
+	if categories := schema.Properties["categories"]; categories.Items == nil || *categories.Items.MaxLength != 100 {
+		t.Errorf("categories: regras de dive não aplicadas aos itens: %+v", categories)
+	}
+
+	g.of(models.CreateGoalProgressRequest{})
+	status := g.schemas["CreateGoalProgressRequest"].Properties["status"]
+	if !reflect.DeepEqual(status.Enum, []string{"open", "in_progress", "completed", "cancelled"}) {
+		t.Errorf("status: %+v", status)
+	}
+}
+
+func TestDocumentReferencesResolve(t *testing.T) {
+	doc := Build()
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refs := regexp.MustCompile(`"\$ref":"#/components/(schemas|responses)/([^"]+)"`).FindAllStringSubmatch(string(data), -1)
+	if len(refs) == 0 {
+		t.Fatal("o documento não usa componentes")
+	}
+	for _, ref := range refs {
+		var ok bool
+		switch ref[1] {
+		case "schemas":
+			_, ok = doc.Components.Schemas[ref[2]]
+		case "responses":
+			_, ok = doc.Components.Responses[ref[2]]
+		}
+		if !ok {
+			t.Errorf("referência sem componente: %s/%s", ref[1], ref[2])
+		}
+	}
+
+	ids := map[string]string{}
+	for path, item := range doc.Paths {
+		for method, operation := range item {
+			if previous, ok := ids[operation.OperationID]; ok {
+				t.Errorf("operationId %s repetido em %s e %s %s", operation.OperationID, previous, method, path)
+			}
+			ids[operation.OperationID] = method + " " + path
+		}
+	}
+}
+
+// TestDocsAssetsAreEmbedded garante que a página de documentação só referencia arquivos
+// servidos pela própria API
+func TestDocsAssetsAreEmbedded(t *testing.T) {
+	app := fiber.New()
+	app.Get("/api/v1/docs/:file", Asset)
+
+	assets := regexp.MustCompile(`(?:href|src)="([^"]+)"`).FindAllStringSubmatch(string(docsPage), -1)
+	if len(assets) == 0 {
+		t.Fatal("docs.html não referencia nenhum arquivo")
+	}
+	for _, asset := range assets {
+		if !strings.HasPrefix(asset[1], "/api/v1/docs/") {
+			t.Errorf("docs.html carrega %s de fora da API", asset[1])
+			continue
+		}
+		resp, err := app.Test(httptest.NewRequest("GET", asset[1], nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != fiber.StatusOK || resp.ContentLength == 0 {
+			t.Errorf("%s: status %d, %d bytes", asset[1], resp.StatusCode, resp.ContentLength)
+		}
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/docs/index.html", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("arquivo não embutido: status %d; esperado 404", resp.StatusCode)
+	}
+}
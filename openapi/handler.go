@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// swaggerUI contém os arquivos da Swagger UI usados por docs.html (versão em swagger-ui/README.md),
+// para que a documentação não dependa de um CDN
+//
+//go:embed swagger-ui/swagger-ui-bundle.js swagger-ui/swagger-ui.css
+var swaggerUI embed.FS
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+	specErr  error
+)
+
+// Handler serve o documento OpenAPI, montado uma única vez
+func Handler(c *fiber.Ctx) error {
+	specOnce.Do(func() {
+		specJSON, specErr = json.Marshal(Build())
+	})
+	if specErr != nil {
+		return specErr
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(specJSON)
+}
+
+// Docs serve a interface do Swagger UI apontando para o documento de Handler
+func Docs(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.Send(docsPage)
+}
+
+// Asset serve os arquivos embutidos da Swagger UI carregados pela página de Docs
+func Asset(c *fiber.Ctx) error {
+	data, err := swaggerUI.ReadFile(path.Join("swagger-ui", c.Params("file")))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	c.Type(path.Ext(c.Params("file")))
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	return c.Send(data)
+}
@@ -0,0 +1,344 @@
+package openapi
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/models"
+)
+
+// Os handlers respondem sucesso em três envelopes, mantidos por compatibilidade com os
+// clientes: {"success": true}, {"status": "success"} e {"error": false}.
+
+func success(properties ...property) *Schema {
+	return objectOf(append([]property{prop("success", &Schema{Type: "boolean", Const: true})}, properties...)...)
+}
+
+func statusSuccess(properties ...property) *Schema {
+	return objectOf(append([]property{prop("status", &Schema{Type: "string", Const: "success"})}, properties...)...)
+}
+
+func noError(properties ...property) *Schema {
+	return objectOf(append([]property{prop("error", &Schema{Type: "boolean", Const: false})}, properties...)...)
+}
+
+func data(schema *Schema) property {
+	return prop("data", schema)
+}
+
+func message() property {
+	return prop("message", &Schema{Type: "string", Description: "Mensagem no idioma da requisição"})
+}
+
+func queryParam(name string, schema *Schema, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func enumSchema(values ...string) *Schema {
+	return &Schema{Type: "string", Enum: values}
+}
+
+// companyQuery é o filtro de empresa aceito dos admins; os demais papéis usam a própria empresa
+var companyQuery = queryParam("companyId", &Schema{Type: "string", Format: "uuid"}, "Empresa consultada (apenas admins)")
+
+// auditFilters são os filtros da consulta e da exportação do log de auditoria
+var auditFilters = []Parameter{
+	queryParam("actorId", &Schema{Type: "string", Format: "uuid"}, ""),
+	queryParam("companyId", &Schema{Type: "string", Format: "uuid"}, ""),
+	queryParam("entityId", &Schema{Type: "string", Format: "uuid"}, ""),
+	queryParam("action", stringSchema(), "create, update, delete, archive, restore..."),
+	queryParam("entityType", stringSchema(), "Tabela da entidade (developers, teams...)"),
+	queryParam("from", stringSchema(), "Data (YYYY-MM-DD) ou instante RFC 3339 inicial"),
+	queryParam("to", stringSchema(), "Data (YYYY-MM-DD, inclusive) ou instante RFC 3339 final"),
+}
+
+// operations lista as rotas registradas por routes.SetupRoutes. Uma rota nova precisa ser
+// descrita aqui; o teste de routes falha enquanto ela estiver fora do documento.
+func operations(g *generator) []operation {
+	user := g.of(models.User{})
+	deletedMessage := success(message())
+
+	return []operation{
+		// Autenticação
+		{method: "POST", path: "/api/v1/auth/login", id: "login", tag: "Autenticação", summary: "Autentica o usuário e emite o token de acesso",
+			access: public, body: g.of(models.LoginRequest{}), response: statusSuccess(message(), data(g.of(models.LoginResponse{})))},
+		{method: "GET", path: "/api/v1/auth/profile", id: "getProfile", tag: "Autenticação", summary: "Perfil do usuário autenticado",
+			access: authenticated, response: noError(data(user))},
+		{method: "POST", path: "/api/v1/auth/refresh", id: "refreshToken", tag: "Autenticação", summary: "Renova o token de acesso",
+			access: authenticated, response: statusSuccess(data(objectOf(prop("token", stringSchema()))))},
+		{method: "POST", path: "/api/v1/auth/set-new-password", id: "setNewPassword", tag: "Autenticação", summary: "Define a senha exigida no primeiro acesso",
+			access: authenticated, body: g.of(models.SetNewPasswordRequest{}), response: statusSuccess(data(g.of(models.LoginResponse{})))},
+		{method: "POST", path: "/api/v1/auth/change-password", id: "changePassword", tag: "Autenticação", summary: "Altera a senha do usuário autenticado",
+			access: authenticated, body: g.of(models.ChangePasswordRequest{}), response: statusSuccess(message())},
+
+		// Inicialização
+		{method: "GET", path: "/api/v1/init/check", id: "checkInitialization", tag: "Inicialização", summary: "Indica se o sistema já tem usuários",
+			access: public, response: noError(prop("initialized", booleanSchema()), prop("userCount", integerSchema()))},
+		{method: "POST", path: "/api/v1/init/admin", id: "createAdminUser", tag: "Inicialização", summary: "Cria o primeiro administrador com a chave de instalação",
+			access: public, body: g.of(models.InitRequest{}), status: fiber.StatusCreated,
+			response: noError(message(), data(objectOf(
+				prop("userId", &Schema{Type: "string", Format: "uuid"}),
+				prop("email", stringSchema()),
+				prop("name", stringSchema()),
+				prop("role", stringSchema()),
+			)))},
+
+		// Usuários
+		{method: "POST", path: "/api/v1/auth/create-user", id: "createUser", tag: "Usuários", summary: "Cria um usuário com senha temporária",
+			access: managerOrAdmin, body: g.of(models.CreateUserRequest{}), status: fiber.StatusCreated, response: statusSuccess(data(user))},
+		{method: "GET", path: "/api/v1/auth/users", id: "listUsers", tag: "Usuários", summary: "Lista os usuários (da empresa, para gestores)",
+			access: managerOrAdmin, response: statusSuccess(data(g.of([]models.User{})))},
+		{method: "PUT", path: "/api/v1/auth/users/:id", id: "updateUser", tag: "Usuários", summary: "Atualiza um usuário",
+			access: managerOrAdmin, body: g.of(models.UpdateUserRequest{}), response: statusSuccess(data(user))},
+		{method: "DELETE", path: "/api/v1/auth/users/:id", id: "deleteUser", tag: "Usuários", summary: "Exclui um usuário",
+			access: managerOrAdmin, response: statusSuccess(message(), data(objectOf(prop("deletedUser", objectOf(
+				prop("id", &Schema{Type: "string", Format: "uuid"}),
+				prop("name", stringSchema()),
+				prop("email", stringSchema()),
+				prop("role", stringSchema()),
+			)))))},
+
+		// Empresas
+		{method: "GET", path: "/api/v1/companies/", id: "getAllCompanies", tag: "Empresas", summary: "Lista as empresas",
+			access: managerOrAdmin, response: statusSuccess(data(g.of([]models.Company{})))},
+		{method: "POST", path: "/api/v1/companies/", id: "createCompany", tag: "Empresas", summary: "Cria uma empresa",
+			access: adminOnly, body: g.of(models.CreateCompanyRequest{}), status: fiber.StatusCreated, response: statusSuccess(data(g.of(models.Company{})))},
+		{method: "GET", path: "/api/v1/companies/:id", id: "getCompanyByID", tag: "Empresas", summary: "Detalhes de uma empresa",
+			access: adminOnly, response: statusSuccess(data(g.of(models.Company{})))},
+		{method: "PUT", path: "/api/v1/companies/:id", id: "updateCompany", tag: "Empresas", summary: "Atualiza uma empresa",
+			access: adminOnly, body: g.of(models.UpdateCompanyRequest{}), response: statusSuccess(data(g.of(models.Company{})))},
+		{method: "DELETE", path: "/api/v1/companies/:id", id: "deleteCompany", tag: "Empresas", summary: "Exclui uma empresa sem usuários",
+			access: adminOnly, response: statusSuccess(message())},
+
+		// Configurações da empresa
+		{method: "GET", path: "/api/v1/company-settings/", id: "getCompanySettings", tag: "Configurações", summary: "Escala de notas, idioma, fuso horário, ano fiscal e paleta da empresa",
+			access: authenticated, query: []Parameter{companyQuery}, response: success(data(g.of(models.CompanySettings{})))},
+		{method: "PUT", path: "/api/v1/company-settings/", id: "updateCompanySettings", tag: "Configurações", summary: "Atualiza as configurações da empresa",
+			access: managerOrAdmin, query: []Parameter{companyQuery}, body: g.of(models.UpdateCompanySettingsRequest{}), response: success(data(g.of(models.CompanySettings{})))},
+
+		// Auditoria
+		{method: "GET", path: "/api/v1/audit-logs/", id: "getAuditLogs", tag: "Auditoria", summary: "Consulta o log de auditoria",
+			access: adminOnly, query: append(append([]Parameter{}, auditFilters...),
+				queryParam("limit", integerSchema(), "Padrão 100"),
+				queryParam("offset", integerSchema(), ""),
+			),
+			response: statusSuccess(data(g.of([]models.AuditLog{})), prop("pagination", objectOf(
+				prop("total", integerSchema()),
+				prop("limit", integerSchema()),
+				prop("offset", integerSchema()),
+			)))},
+		{method: "GET", path: "/api/v1/audit-logs/export", id: "exportAuditLogs", tag: "Auditoria", summary: "Exporta o log de auditoria filtrado",
+			access: adminOnly, query: append(append([]Parameter{}, auditFilters...), queryParam("format", enumSchema("csv", "json"), "Padrão csv")),
+			content: map[string]*Schema{
+				"text/csv":                stringSchema(),
+				fiber.MIMEApplicationJSON: g.of([]models.AuditLog{}),
+			}},
+
+		// Times
+		{method: "GET", path: "/api/v1/teams/", id: "getAllTeams", tag: "Times", summary: "Lista os times da empresa",
+			access: authenticated, response: success(data(g.of([]models.Team{})))},
+		{method: "GET", path: "/api/v1/teams/tree", id: "getTeamTree", tag: "Times", summary: "Hierarquia de departamentos, times e squads",
+			access: authenticated, response: success(data(g.of([]models.Team{})))},
+		{method: "GET", path: "/api/v1/teams/:id", id: "getTeamByID", tag: "Times", summary: "Detalhes de um time",
+			access: authenticated, response: success(data(g.of(models.Team{})))},
+		{method: "POST", path: "/api/v1/teams/", id: "createTeam", tag: "Times", summary: "Cria um time",
+			access: managerOrAdmin, body: g.of(models.CreateTeamRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.Team{})))},
+		{method: "PUT", path: "/api/v1/teams/:id", id: "updateTeam", tag: "Times", summary: "Atualiza um time",
+			access: managerOrAdmin, body: g.of(models.UpdateTeamRequest{}), response: success(data(g.of(models.Team{})))},
+		{method: "DELETE", path: "/api/v1/teams/:id", id: "deleteTeam", tag: "Times", summary: "Exclui um time",
+			access: adminOnly, response: deletedMessage},
+		{method: "GET", path: "/api/v1/teams/:teamId/developers", id: "getDevelopersByTeam", tag: "Times", summary: "Desenvolvedores do time",
+			access: authenticated, query: []Parameter{queryParam("includeArchived", booleanSchema(), "")}, response: success(data(g.of([]models.Developer{})))},
+		{method: "GET", path: "/api/v1/teams/:teamId/roster", id: "getTeamRoster", tag: "Times", summary: "Integrantes do time em uma data",
+			access: authenticated, query: []Parameter{queryParam("date", &Schema{Type: "string", Format: "date"}, "Padrão: hoje")},
+			response: success(data(g.of([]models.TeamMembership{})), prop("date", &Schema{Type: "string", Format: "date"}))},
+		{method: "GET", path: "/api/v1/teams/:teamId/tree", id: "getTeamSubtree", tag: "Times", summary: "Subárvore de uma unidade",
+			access: authenticated, response: success(data(g.of(models.Team{})))},
+		{method: "GET", path: "/api/v1/teams/:teamId/stats", id: "getTeamStats", tag: "Times", summary: "Performance agregada da unidade e das descendentes",
+			access: authenticated, query: []Parameter{queryParam("month", &Schema{Type: "string", Pattern: `^\d{4}-\d{2}$`}, "")}, response: success(data(g.of(models.TeamStats{})))},
+		{method: "PUT", path: "/api/v1/teams/:teamId/move", id: "moveTeam", tag: "Times", summary: "Move a unidade para outro pai",
+			access: managerOrAdmin, body: g.of(models.MoveTeamRequest{}), response: success(data(g.of(models.Team{})))},
+		{method: "GET", path: "/api/v1/teams/:teamId/skills", id: "getTeamSkillCoverage", tag: "Times", summary: "Cobertura de competências do time",
+			access: authenticated, query: []Parameter{
+				queryParam("minLevel", integerSchema(), "Padrão 3"),
+				queryParam("includeSubteams", booleanSchema(), ""),
+				queryParam("validatedOnly", booleanSchema(), ""),
+			},
+			response: success(data(objectOf(
+				prop("developerCount", integerSchema()),
+				prop("minLevel", integerSchema()),
+				prop("coverage", g.of([]models.TeamSkillCoverage{})),
+				prop("gaps", g.of([]models.TeamSkillCoverage{})),
+			)))},
+
+		// Desenvolvedores
+		{method: "GET", path: "/api/v1/developers/", id: "getAllDevelopers", tag: "Desenvolvedores", summary: "Lista os desenvolvedores da empresa",
+			access: authenticated, query: []Parameter{queryParam("includeArchived", booleanSchema(), "")}, response: success(data(g.of([]models.Developer{})))},
+		{method: "GET", path: "/api/v1/developers/archived", id: "getArchivedDevelopers", tag: "Desenvolvedores", summary: "Lista os desenvolvedores arquivados",
+			access: authenticated, response: success(data(g.of([]models.Developer{})))},
+		{method: "GET", path: "/api/v1/developers/:id", id: "getDeveloperByID", tag: "Desenvolvedores", summary: "Detalhes de um desenvolvedor",
+			access: authenticated, response: success(data(g.of(models.Developer{})))},
+		{method: "POST", path: "/api/v1/developers/", id: "createDeveloper", tag: "Desenvolvedores", summary: "Cria um desenvolvedor",
+			access: managerOrAdmin, body: g.of(models.CreateDeveloperRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.Developer{})))},
+		{method: "PUT", path: "/api/v1/developers/:id", id: "updateDeveloper", tag: "Desenvolvedores", summary: "Atualiza um desenvolvedor",
+			access: managerOrAdmin, body: g.of(models.UpdateDeveloperRequest{}), response: success(data(g.of(models.Developer{})))},
+		{method: "PUT", path: "/api/v1/developers/:id/archive", id: "archiveDeveloper", tag: "Desenvolvedores", summary: "Arquiva ou restaura um desenvolvedor",
+			access: managerOrAdmin, body: g.of(models.ArchiveDeveloperRequest{}), response: success(message(), data(g.of(models.Developer{})))},
+		{method: "DELETE", path: "/api/v1/developers/:id", id: "deleteDeveloper", tag: "Desenvolvedores", summary: "Exclui um desenvolvedor",
+			access: managerOrAdmin, response: success(message(), data(objectOf(prop("deletedDeveloper", g.of(models.Developer{})))))},
+		{method: "GET", path: "/api/v1/developers/:developerId/team-history", id: "getDeveloperTeamHistory", tag: "Desenvolvedores", summary: "Histórico de times do desenvolvedor",
+			access: authenticated, response: success(data(g.of([]models.TeamMembership{})))},
+
+		// Carreira
+		{method: "GET", path: "/api/v1/career/tracks", id: "getCareerTracks", tag: "Carreira", summary: "Lista as trilhas de carreira com os níveis",
+			access: authenticated, query: []Parameter{companyQuery}, response: success(data(g.of([]models.CareerTrack{})))},
+		{method: "GET", path: "/api/v1/career/tracks/:id", id: "getCareerTrackByID", tag: "Carreira", summary: "Detalhes de uma trilha",
+			access: authenticated, response: success(data(g.of(models.CareerTrack{})))},
+		{method: "POST", path: "/api/v1/career/tracks", id: "createCareerTrack", tag: "Carreira", summary: "Cria uma trilha",
+			access: managerOrAdmin, body: g.of(models.CreateCareerTrackRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.CareerTrack{})))},
+		{method: "PUT", path: "/api/v1/career/tracks/:id", id: "updateCareerTrack", tag: "Carreira", summary: "Atualiza uma trilha",
+			access: managerOrAdmin, body: g.of(models.UpdateCareerTrackRequest{}), response: success(data(g.of(models.CareerTrack{})))},
+		{method: "DELETE", path: "/api/v1/career/tracks/:id", id: "deleteCareerTrack", tag: "Carreira", summary: "Exclui uma trilha",
+			access: managerOrAdmin, response: deletedMessage},
+		{method: "POST", path: "/api/v1/career/tracks/:id/levels", id: "createCareerLevel", tag: "Carreira", summary: "Cria um nível na trilha",
+			access: managerOrAdmin, body: g.of(models.CreateCareerLevelRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.CareerLevel{})))},
+		{method: "PUT", path: "/api/v1/career/levels/:levelId", id: "updateCareerLevel", tag: "Carreira", summary: "Atualiza um nível",
+			access: managerOrAdmin, body: g.of(models.UpdateCareerLevelRequest{}), response: success(data(g.of(models.CareerLevel{})))},
+		{method: "DELETE", path: "/api/v1/career/levels/:levelId", id: "deleteCareerLevel", tag: "Carreira", summary: "Exclui um nível sem desenvolvedores",
+			access: managerOrAdmin, response: deletedMessage},
+		{method: "GET", path: "/api/v1/career/analytics", id: "getCareerAnalytics", tag: "Carreira", summary: "Performance por nível e candidatos a promoção",
+			access: managerOrAdmin, query: []Parameter{
+				companyQuery,
+				queryParam("reports", integerSchema(), "Relatórios recentes considerados por desenvolvedor"),
+				queryParam("minMonths", integerSchema(), "Meses mínimos no nível atual"),
+			},
+			response: success(data(objectOf(
+				prop("levels", g.of([]models.CareerLevelStats{})),
+				prop("promotionCandidates", g.of([]models.PromotionCandidate{})),
+				prop("reportsConsidered", integerSchema()),
+			)))},
+		{method: "GET", path: "/api/v1/developers/:developerId/level-history", id: "getDeveloperLevelHistory", tag: "Carreira", summary: "Histórico de níveis do desenvolvedor",
+			access: authenticated, response: success(data(g.of([]models.DeveloperLevelChange{})))},
+		{method: "POST", path: "/api/v1/developers/:developerId/level", id: "changeDeveloperLevel", tag: "Carreira", summary: "Muda o nível do desenvolvedor",
+			access: managerOrAdmin, body: g.of(models.ChangeDeveloperLevelRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.DeveloperLevelChange{})))},
+
+		// Competências
+		{method: "GET", path: "/api/v1/skills/", id: "getSkills", tag: "Competências", summary: "Catálogo de competências",
+			access: authenticated, query: []Parameter{companyQuery}, response: success(data(g.of([]models.Skill{})))},
+		{method: "GET", path: "/api/v1/skills/search", id: "searchDevelopersBySkill", tag: "Competências", summary: "Busca desenvolvedores por competência",
+			access: authenticated, query: []Parameter{
+				companyQuery,
+				queryParam("skillId", &Schema{Type: "string", Format: "uuid"}, "Competência pelo ID"),
+				queryParam("skill", stringSchema(), "Competência pelo nome, quando skillId não é informado"),
+				queryParam("minLevel", integerSchema(), "Padrão 1"),
+				queryParam("validatedOnly", booleanSchema(), ""),
+			},
+			response: success(data(g.of([]models.DeveloperSkill{})))},
+		{method: "POST", path: "/api/v1/skills/", id: "createSkill", tag: "Competências", summary: "Cria uma competência",
+			access: managerOrAdmin, body: g.of(models.CreateSkillRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.Skill{})))},
+		{method: "PUT", path: "/api/v1/skills/:id", id: "updateSkill", tag: "Competências", summary: "Atualiza uma competência",
+			access: managerOrAdmin, body: g.of(models.UpdateSkillRequest{}), response: success(data(g.of(models.Skill{})))},
+		{method: "DELETE", path: "/api/v1/skills/:id", id: "deleteSkill", tag: "Competências", summary: "Exclui uma competência",
+			access: managerOrAdmin, response: deletedMessage},
+		{method: "GET", path: "/api/v1/developers/:developerId/skills", id: "getDeveloperSkills", tag: "Competências", summary: "Proficiências atuais do desenvolvedor",
+			access: authenticated, response: success(data(g.of([]models.DeveloperSkill{})))},
+		{method: "POST", path: "/api/v1/developers/:developerId/skills", id: "createSkillAssessment", tag: "Competências", summary: "Registra uma autoavaliação ou a validação do gestor",
+			access: authenticated, body: g.of(models.CreateSkillAssessmentRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.DeveloperSkillAssessment{})))},
+		{method: "GET", path: "/api/v1/developers/:developerId/skills/:skillId/history", id: "getSkillAssessmentHistory", tag: "Competências", summary: "Histórico de avaliações de uma competência",
+			access: authenticated, response: success(data(g.of([]models.DeveloperSkillAssessment{})))},
+
+		// Relatórios de performance
+		{method: "GET", path: "/api/v1/performance-reports/", id: "getAllPerformanceReports", tag: "Relatórios", summary: "Lista os relatórios da empresa",
+			access: authenticated, response: success(data(g.of([]models.PerformanceReport{})))},
+		{method: "GET", path: "/api/v1/performance-reports/months", id: "getAvailableMonths", tag: "Relatórios", summary: "Meses com relatórios",
+			access: authenticated, response: success(data(g.of([]string{})))},
+		{method: "GET", path: "/api/v1/performance-reports/stats", id: "getPerformanceStats", tag: "Relatórios", summary: "Estatísticas das notas, no ano fiscal informado",
+			access: authenticated, query: []Parameter{queryParam("fiscalYear", integerSchema(), "Ano fiscal, conforme o mês de início configurado"), companyQuery},
+			response: success(
+				data(g.of(models.PerformanceStats{})),
+				optionalProp("scale", objectOf(
+					prop("min", numberSchema()),
+					prop("max", numberSchema()),
+					prop("step", numberSchema()),
+					prop("decimalPlaces", integerSchema()),
+				)),
+				optionalProp("period", objectOf(prop("from", stringSchema()), prop("to", stringSchema()))),
+				optionalProp("decimalPlaces", integerSchema()),
+				optionalProp("companies", g.of([]models.CompanyPerformanceStats{})),
+			)},
+		{method: "GET", path: "/api/v1/performance-reports/:id", id: "getPerformanceReportByID", tag: "Relatórios", summary: "Relatório com metas e reuniões 1:1 do mês",
+			access: authenticated, response: success(
+				data(g.of(models.PerformanceReport{})),
+				prop("goals", g.of([]models.Goal{})),
+				prop("openGoals", g.of([]models.Goal{})),
+				prop("oneOnOnes", g.of([]models.OneOnOne{})),
+			)},
+		{method: "POST", path: "/api/v1/performance-reports/", id: "createPerformanceReport", tag: "Relatórios", summary: "Cria o relatório mensal, com metas opcionais",
+			access: managerOrAdmin, body: g.of(models.CreatePerformanceReportRequest{}), status: fiber.StatusCreated,
+			response: success(
+				data(g.of(models.PerformanceReport{})),
+				prop("goals", g.of([]models.Goal{})),
+				prop("oneOnOnes", g.of([]models.OneOnOne{})),
+			)},
+		{method: "GET", path: "/api/v1/developers/:developerId/reports", id: "getPerformanceReportsByDeveloper", tag: "Relatórios", summary: "Relatórios do desenvolvedor",
+			access: authenticated, response: success(data(g.of([]models.PerformanceReport{})))},
+		{method: "GET", path: "/api/v1/performance-reports/month/:month", id: "getPerformanceReportsByMonth", tag: "Relatórios", summary: "Relatórios do mês",
+			access: authenticated, response: success(data(g.of([]models.PerformanceReport{})))},
+
+		// Comentários
+		{method: "GET", path: "/api/v1/performance-reports/comments/unread", id: "getUnreadReportComments", tag: "Comentários", summary: "Comentários não lidos por relatório",
+			access: authenticated, response: success(data(g.of([]models.ReportCommentUnread{})))},
+		{method: "GET", path: "/api/v1/performance-reports/:id/comments", id: "getReportComments", tag: "Comentários", summary: "Comentários do relatório, em threads",
+			access: authenticated, response: success(data(g.of([]models.ReportComment{})), prop("unreadCount", integerSchema()))},
+		{method: "POST", path: "/api/v1/performance-reports/:id/comments", id: "createReportComment", tag: "Comentários", summary: "Comenta o relatório",
+			access: authenticated, body: g.of(models.CreateReportCommentRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.ReportComment{})))},
+		{method: "POST", path: "/api/v1/performance-reports/:id/comments/read", id: "markReportCommentsRead", tag: "Comentários", summary: "Marca os comentários do relatório como lidos",
+			access: authenticated, response: success(message())},
+		{method: "PUT", path: "/api/v1/performance-reports/:id/comments/:commentId", id: "updateReportComment", tag: "Comentários", summary: "Edita um comentário (apenas o autor)",
+			access: authenticated, body: g.of(models.UpdateReportCommentRequest{}), response: success(data(g.of(models.ReportComment{})))},
+		{method: "DELETE", path: "/api/v1/performance-reports/:id/comments/:commentId", id: "deleteReportComment", tag: "Comentários", summary: "Exclui um comentário",
+			access: authenticated, response: deletedMessage},
+
+		// Metas
+		{method: "GET", path: "/api/v1/developers/:developerId/goals", id: "getGoalsByDeveloper", tag: "Metas", summary: "Metas do desenvolvedor",
+			access: authenticated, query: []Parameter{queryParam("status", enumSchema("open", "in_progress", "completed", "cancelled"), "")},
+			response: success(data(g.of([]models.Goal{})))},
+		{method: "POST", path: "/api/v1/developers/:developerId/goals", id: "createGoal", tag: "Metas", summary: "Cria uma meta",
+			access: managerOrAdmin, body: g.of(models.CreateGoalRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.Goal{})))},
+		{method: "GET", path: "/api/v1/goals/:id", id: "getGoalByID", tag: "Metas", summary: "Meta com o histórico de progresso",
+			access: authenticated, response: success(data(g.of(models.Goal{})))},
+		{method: "PUT", path: "/api/v1/goals/:id", id: "updateGoal", tag: "Metas", summary: "Atualiza uma meta",
+			access: managerOrAdmin, body: g.of(models.UpdateGoalRequest{}), response: success(data(g.of(models.Goal{})))},
+		{method: "DELETE", path: "/api/v1/goals/:id", id: "deleteGoal", tag: "Metas", summary: "Exclui uma meta",
+			access: managerOrAdmin, response: deletedMessage},
+		{method: "POST", path: "/api/v1/goals/:id/progress", id: "addGoalProgress", tag: "Metas", summary: "Registra o progresso da meta",
+			access: managerOrAdmin, body: g.of(models.CreateGoalProgressRequest{}), status: fiber.StatusCreated,
+			response: success(data(objectOf(prop("goal", g.of(models.Goal{})), prop("update", g.of(models.GoalProgressUpdate{})))))},
+
+		// Reuniões 1:1
+		{method: "GET", path: "/api/v1/developers/:developerId/one-on-ones", id: "getOneOnOnesByDeveloper", tag: "Reuniões 1:1", summary: "Reuniões 1:1 do desenvolvedor",
+			access: authenticated, query: []Parameter{queryParam("month", &Schema{Type: "string", Pattern: `^\d{4}-\d{2}$`}, "")},
+			response: success(data(g.of([]models.OneOnOne{})))},
+		{method: "POST", path: "/api/v1/developers/:developerId/one-on-ones", id: "createOneOnOne", tag: "Reuniões 1:1", summary: "Registra uma reunião 1:1",
+			access: managerOrAdmin, body: g.of(models.CreateOneOnOneRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.OneOnOne{})))},
+		{method: "GET", path: "/api/v1/one-on-ones/:id", id: "getOneOnOneByID", tag: "Reuniões 1:1", summary: "Detalhes de uma reunião 1:1",
+			access: authenticated, response: success(data(g.of(models.OneOnOne{})))},
+		{method: "PUT", path: "/api/v1/one-on-ones/:id", id: "updateOneOnOne", tag: "Reuniões 1:1", summary: "Atualiza uma reunião 1:1",
+			access: managerOrAdmin, body: g.of(models.UpdateOneOnOneRequest{}), response: success(data(g.of(models.OneOnOne{})))},
+		{method: "DELETE", path: "/api/v1/one-on-ones/:id", id: "deleteOneOnOne", tag: "Reuniões 1:1", summary: "Exclui uma reunião 1:1",
+			access: managerOrAdmin, response: deletedMessage},
+		{method: "POST", path: "/api/v1/one-on-ones/:id/action-items", id: "createOneOnOneActionItem", tag: "Reuniões 1:1", summary: "Cria um item de ação",
+			access: managerOrAdmin, body: g.of(models.CreateOneOnOneActionItemRequest{}), status: fiber.StatusCreated, response: success(data(g.of(models.OneOnOneActionItem{})))},
+		{method: "PUT", path: "/api/v1/one-on-ones/:id/action-items/:itemId", id: "updateOneOnOneActionItem", tag: "Reuniões 1:1", summary: "Atualiza ou conclui um item de ação",
+			access: authenticated, body: g.of(models.UpdateOneOnOneActionItemRequest{}), response: success(data(g.of(models.OneOnOneActionItem{})))},
+		{method: "DELETE", path: "/api/v1/one-on-ones/:id/action-items/:itemId", id: "deleteOneOnOneActionItem", tag: "Reuniões 1:1", summary: "Exclui um item de ação",
+			access: managerOrAdmin, response: deletedMessage},
+
+		// Documentação
+		{method: "GET", path: "/api/v1/openapi.json", id: "getOpenAPI", tag: "Documentação", summary: "Este documento OpenAPI",
+			access: public, response: &Schema{Type: "object"}},
+		{method: "GET", path: "/api/v1/docs", id: "getDocs", tag: "Documentação", summary: "Documentação interativa (Swagger UI)",
+			access: public, content: map[string]*Schema{fiber.MIMETextHTML: stringSchema()}},
+		{method: "GET", path: "/api/v1/docs/:file", id: "getDocsAsset", tag: "Documentação", summary: "Arquivos da Swagger UI usados pela documentação interativa",
+			access: public, content: map[string]*Schema{"text/css": stringSchema(), "text/javascript": stringSchema()}},
+	}
+}
@@ -0,0 +1,260 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/models"
+)
+
+// Schema é um JSON Schema no dialeto do OpenAPI 3.1
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+}
+
+var (
+	uuidType   = reflect.TypeOf(uuid.UUID{})
+	timeType   = reflect.TypeOf(time.Time{})
+	dbTimeType = reflect.TypeOf(models.DBTime{})
+)
+
+// generator converte tipos Go em schemas. Structs nomeados viram componentes
+// (#/components/schemas/<Nome>), referenciados pelos demais schemas.
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func newGenerator() *generator {
+	return &generator{schemas: map[string]*Schema{}}
+}
+
+// of retorna o schema do tipo do valor de exemplo (models.Team{}, []models.Goal{})
+func (g *generator) of(example any) *Schema {
+	return g.schema(reflect.TypeOf(example))
+}
+
+// named registra o struct do valor de exemplo com outro nome de componente
+func (g *generator) named(name string, example any) *Schema {
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := g.schemas[name]; !ok {
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.object(reflect.TypeOf(example))
+	}
+	return ref
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch t {
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case timeType, dbTimeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.schema(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return g.named(t.Name(), reflect.Zero(t).Interface())
+	}
+	// interface{}: qualquer valor JSON
+	return &Schema{}
+}
+
+// object descreve os campos do struct com os nomes do JSON e as regras de validação
+func (g *generator) object(t reflect.Type) *Schema {
+	object := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := apierror.JSONFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		property := g.schema(field.Type)
+		if applyRules(property, field.Type, field.Tag.Get("validate")) {
+			object.Required = append(object.Required, name)
+		}
+		object.Properties[name] = property
+	}
+	return object
+}
+
+// applyRules traduz as regras do go-playground/validator em restrições do schema; retorna se
+// o campo é obrigatório. As regras depois de dive valem para os itens.
+func applyRules(schema *Schema, t reflect.Type, tag string) (required bool) {
+	if tag == "" {
+		return false
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			if schema.Items != nil {
+				applyRules(schema.Items, t.Elem(), strings.Join(rules[i+1:], ","))
+			}
+			return required
+		case "required":
+			required = true
+		case "min", "gte":
+			setBound(schema, t, param, false)
+		case "max", "lte":
+			setBound(schema, t, param, true)
+		case "len":
+			setBound(schema, t, param, false)
+			setBound(schema, t, param, true)
+		case "gt":
+			if value, err := strconv.ParseFloat(param, 64); err == nil {
+				schema.ExclusiveMinimum = &value
+			}
+		case "lt":
+			if value, err := strconv.ParseFloat(param, 64); err == nil {
+				schema.ExclusiveMaximum = &value
+			}
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "uuid":
+			schema.Format = "uuid"
+		case "datetime":
+			if param == "2006-01-02" {
+				schema.Format = "date"
+			} else {
+				schema.Description = "Formato " + param
+			}
+		case "bcp47_language_tag":
+			schema.Description = "Idioma BCP 47 (ex.: pt-BR, en, es)"
+		case "timezone":
+			schema.Description = "Fuso horário IANA (ex.: America/Sao_Paulo)"
+		}
+	}
+	return required
+}
+
+// setBound aplica min/max conforme o tipo: tamanho do texto, número de itens ou valor
+func setBound(schema *Schema, t reflect.Type, param string, upper bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		n := int(value)
+		if upper {
+			schema.MaxLength = &n
+		} else {
+			schema.MinLength = &n
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n := int(value)
+		if upper {
+			schema.MaxItems = &n
+		} else {
+			schema.MinItems = &n
+		}
+	default:
+		if upper {
+			schema.Maximum = &value
+		} else {
+			schema.Minimum = &value
+		}
+	}
+}
+
+// nullable aceita também null: ponteiros dos models são opcionais ou anuláveis
+func nullable(schema *Schema) *Schema {
+	switch typ := schema.Type.(type) {
+	case string:
+		schema.Type = []string{typ, "null"}
+		return schema
+	case nil:
+		if schema.Ref == "" {
+			return schema
+		}
+	}
+	return &Schema{AnyOf: []*Schema{schema, {Type: "null"}}}
+}
+
+// objectOf monta um objeto com as propriedades informadas; as de optionalProp não são obrigatórias
+func objectOf(properties ...property) *Schema {
+	object := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, p := range properties {
+		object.Properties[p.name] = p.schema
+		if !p.optional {
+			object.Required = append(object.Required, p.name)
+		}
+	}
+	return object
+}
+
+// property é uma propriedade de um objeto montado por objectOf
+type property struct {
+	name     string
+	schema   *Schema
+	optional bool
+}
+
+func prop(name string, schema *Schema) property {
+	return property{name: name, schema: schema}
+}
+
+func optionalProp(name string, schema *Schema) property {
+	return property{name: name, schema: schema, optional: true}
+}
+
+func stringSchema() *Schema  { return &Schema{Type: "string"} }
+func integerSchema() *Schema { return &Schema{Type: "integer"} }
+func numberSchema() *Schema  { return &Schema{Type: "number"} }
+func booleanSchema() *Schema { return &Schema{Type: "boolean"} }
@@ -0,0 +1,1016 @@
+package repository
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/models"
+)
+
+// MemoryProvider mantém os dados em memória; todos os stores criados a partir dele
+// compartilham o mesmo conteúdo. Usado em testes de handlers sem banco de dados.
+type MemoryProvider struct {
+	store *memoryStore
+}
+
+// NewMemoryProvider retorna um provider em memória vazio
+func NewMemoryProvider() *MemoryProvider {
+	return &MemoryProvider{store: &memoryStore{
+		companies:  map[uuid.UUID]models.Company{},
+		users:      map[uuid.UUID]models.User{},
+		teams:      map[uuid.UUID]models.Team{},
+		developers: map[uuid.UUID]models.Developer{},
+		reports:    map[uuid.UUID]models.PerformanceReport{},
+		settings:   map[uuid.UUID]models.CompanySettings{},
+		goals:      map[uuid.UUID]models.Goal{},
+		oneOnOnes:  map[uuid.UUID]models.OneOnOne{},
+	}}
+}
+
+// Store ignora q: os dados em memória não dependem da conexão da requisição
+func (p *MemoryProvider) Store(q database.Querier) Store {
+	return p.store
+}
+
+type memoryStore struct {
+	mu         sync.RWMutex
+	companies  map[uuid.UUID]models.Company
+	users      map[uuid.UUID]models.User
+	teams      map[uuid.UUID]models.Team
+	developers map[uuid.UUID]models.Developer
+	reports    map[uuid.UUID]models.PerformanceReport
+	settings   map[uuid.UUID]models.CompanySettings
+	goals      map[uuid.UUID]models.Goal
+	oneOnOnes  map[uuid.UUID]models.OneOnOne
+	audit      []models.AuditLog
+}
+
+func (s *memoryStore) Companies() CompanyRepository    { return memoryCompanies{s} }
+func (s *memoryStore) Users() UserRepository           { return memoryUsers{s} }
+func (s *memoryStore) Teams() TeamRepository           { return memoryTeams{s} }
+func (s *memoryStore) Developers() DeveloperRepository { return memoryDevelopers{s} }
+func (s *memoryStore) Reports() ReportRepository       { return memoryReports{s} }
+func (s *memoryStore) Goals() GoalRepository           { return memoryGoals{s} }
+func (s *memoryStore) OneOnOnes() OneOnOneRepository   { return memoryOneOnOnes{s} }
+func (s *memoryStore) Audit() AuditRepository          { return memoryAudit{s} }
+
+// AuditLogs retorna as entradas gravadas no log de auditoria, na ordem de gravação
+func (p *MemoryProvider) AuditLogs() []models.AuditLog {
+	p.store.mu.RLock()
+	defer p.store.mu.RUnlock()
+
+	return append([]models.AuditLog(nil), p.store.audit...)
+}
+
+func sameID(a *uuid.UUID, b uuid.UUID) bool {
+	return a != nil && *a == b
+}
+
+// stamp preenche ID e datas de criação ausentes, como os defaults das tabelas
+func stamp(id *uuid.UUID, createdAt, updatedAt *time.Time) {
+	now := time.Now()
+	if *id == uuid.Nil {
+		*id = uuid.New()
+	}
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
+
+type memoryCompanies struct{ s *memoryStore }
+
+func (r memoryCompanies) List(companyID *uuid.UUID) ([]models.Company, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	companies := []models.Company{}
+	for _, company := range r.s.companies {
+		if companyID == nil || company.ID == *companyID {
+			companies = append(companies, company)
+		}
+	}
+	sort.Slice(companies, func(i, j int) bool { return companies[i].Name < companies[j].Name })
+	return companies, nil
+}
+
+func (r memoryCompanies) FindByID(id uuid.UUID) (*models.Company, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	company, ok := r.s.companies[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &company, nil
+}
+
+func (r memoryCompanies) NameTaken(name string, exceptID *uuid.UUID) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, company := range r.s.companies {
+		if company.Name == name && !sameID(exceptID, company.ID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r memoryCompanies) IsActive(id uuid.UUID) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	company, ok := r.s.companies[id]
+	return ok && company.IsActive, nil
+}
+
+func (r memoryCompanies) Create(company *models.Company) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stamp(&company.ID, &company.CreatedAt, &company.UpdatedAt)
+	r.s.companies[company.ID] = *company
+	return nil
+}
+
+func (r memoryCompanies) Update(company *models.Company) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.companies[company.ID]; !ok {
+		return ErrNotFound
+	}
+	r.s.companies[company.ID] = *company
+	return nil
+}
+
+func (r memoryCompanies) Delete(id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.companies[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.s.companies, id)
+	return nil
+}
+
+func (r memoryCompanies) Settings(id uuid.UUID) (*models.CompanySettings, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	settings, ok := r.s.settings[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &settings, nil
+}
+
+func (r memoryCompanies) SaveSettings(settings *models.CompanySettings) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	if stored, ok := r.s.settings[settings.CompanyID]; ok {
+		settings.CreatedAt = stored.CreatedAt
+	} else {
+		settings.CreatedAt = &now
+	}
+	settings.UpdatedAt = &now
+	r.s.settings[settings.CompanyID] = *settings
+	return nil
+}
+
+type memoryUsers struct{ s *memoryStore }
+
+func (r memoryUsers) List(companyID *uuid.UUID) ([]models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	users := []models.User{}
+	for _, user := range r.s.users {
+		if companyID == nil || sameID(user.CompanyID, *companyID) {
+			user.Password = ""
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].CreatedAt.After(users[j].CreatedAt) })
+	return users, nil
+}
+
+func (r memoryUsers) FindByID(id uuid.UUID) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	user, ok := r.s.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r memoryUsers) FindByEmail(email string) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, user := range r.s.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r memoryUsers) EmailTaken(email string, exceptID *uuid.UUID) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, user := range r.s.users {
+		if user.Email == email && !sameID(exceptID, user.ID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r memoryUsers) Count() (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return len(r.s.users), nil
+}
+
+func (r memoryUsers) CountByCompany(companyID uuid.UUID) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	count := 0
+	for _, user := range r.s.users {
+		if sameID(user.CompanyID, companyID) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r memoryUsers) Create(user *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stamp(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	r.s.users[user.ID] = *user
+	return nil
+}
+
+func (r memoryUsers) Update(user *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[user.ID]; !ok {
+		return ErrNotFound
+	}
+	r.s.users[user.ID] = *user
+	return nil
+}
+
+func (r memoryUsers) Delete(id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.s.users, id)
+	return nil
+}
+
+type memoryTeams struct{ s *memoryStore }
+
+func (r memoryTeams) List(companyID *uuid.UUID) ([]models.Team, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	teams := []models.Team{}
+	for _, team := range r.s.teams {
+		if companyID == nil || sameID(team.CompanyID, *companyID) {
+			teams = append(teams, team)
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].CreatedAt.After(teams[j].CreatedAt) })
+	return teams, nil
+}
+
+func (r memoryTeams) FindByID(id uuid.UUID) (*models.Team, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	team, ok := r.s.teams[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &team, nil
+}
+
+func (r memoryTeams) CompanyOf(id uuid.UUID) (*uuid.UUID, error) {
+	team, err := r.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return team.CompanyID, nil
+}
+
+func (r memoryTeams) Create(team *models.Team) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stamp(&team.ID, &team.CreatedAt, &team.UpdatedAt)
+	r.s.teams[team.ID] = *team
+	return nil
+}
+
+func (r memoryTeams) Update(team *models.Team) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.teams[team.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	stored.Name = team.Name
+	stored.Description = team.Description
+	stored.Color = team.Color
+	stored.Kind = team.Kind
+	stored.UpdatedAt = time.Now()
+	r.s.teams[team.ID] = stored
+	*team = stored
+	return nil
+}
+
+func (r memoryTeams) Delete(id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	deleted, ok := r.s.teams[id]
+	if !ok {
+		return ErrNotFound
+	}
+	for childID, child := range r.s.teams {
+		if sameID(child.ParentID, id) {
+			child.ParentID = deleted.ParentID
+			r.s.teams[childID] = child
+		}
+	}
+	for developerID, developer := range r.s.developers {
+		if sameID(developer.TeamID, id) {
+			developer.TeamID = nil
+			r.s.developers[developerID] = developer
+		}
+	}
+	delete(r.s.teams, id)
+	return nil
+}
+
+type memoryDevelopers struct{ s *memoryStore }
+
+func (r memoryDevelopers) List(filter DeveloperFilter) ([]models.Developer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	developers := []models.Developer{}
+	for _, developer := range r.s.developers {
+		if filter.CompanyID != nil && !sameID(developer.CompanyID, *filter.CompanyID) {
+			continue
+		}
+		if filter.TeamID != nil && !sameID(developer.TeamID, *filter.TeamID) {
+			continue
+		}
+		if filter.Archived == ExcludeArchived && developer.ArchivedAt != nil {
+			continue
+		}
+		if filter.Archived == OnlyArchived && developer.ArchivedAt == nil {
+			continue
+		}
+		developers = append(developers, developer)
+	}
+
+	sort.Slice(developers, func(i, j int) bool {
+		if filter.Archived == OnlyArchived {
+			return developers[i].ArchivedAt.After(*developers[j].ArchivedAt)
+		}
+		return developers[i].CreatedAt.After(developers[j].CreatedAt)
+	})
+	return developers, nil
+}
+
+func (r memoryDevelopers) FindByID(id uuid.UUID) (*models.Developer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	developer, ok := r.s.developers[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &developer, nil
+}
+
+func (r memoryDevelopers) Create(developer *models.Developer) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stamp(&developer.ID, &developer.CreatedAt, &developer.UpdatedAt)
+	developer.UpdatedBy = developer.CreatedBy
+	r.s.developers[developer.ID] = *developer
+	return nil
+}
+
+func (r memoryDevelopers) Update(developer *models.Developer) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.developers[developer.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	stored.Name = developer.Name
+	stored.Role = developer.Role
+	stored.LatestPerformanceScore = developer.LatestPerformanceScore
+	stored.TeamID = developer.TeamID
+	stored.UserID = developer.UserID
+	stored.UpdatedBy = developer.UpdatedBy
+	stored.UpdatedAt = time.Now()
+	r.s.developers[developer.ID] = stored
+	*developer = stored
+	return nil
+}
+
+func (r memoryDevelopers) SetLatestScore(id uuid.UUID, score float64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	developer, ok := r.s.developers[id]
+	if !ok {
+		return ErrNotFound
+	}
+	developer.LatestPerformanceScore = score
+	developer.UpdatedAt = time.Now()
+	r.s.developers[id] = developer
+	return nil
+}
+
+func (r memoryDevelopers) SetArchived(id uuid.UUID, archivedAt *time.Time, updatedBy uuid.UUID) (*models.Developer, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	developer, ok := r.s.developers[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	developer.ArchivedAt = archivedAt
+	developer.UpdatedBy = &updatedBy
+	developer.UpdatedAt = time.Now()
+	r.s.developers[id] = developer
+	return &developer, nil
+}
+
+func (r memoryDevelopers) Delete(id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.developers[id]; !ok {
+		return ErrNotFound
+	}
+	for reportID, report := range r.s.reports {
+		if report.DeveloperID == id {
+			delete(r.s.reports, reportID)
+		}
+	}
+	for goalID, goal := range r.s.goals {
+		if goal.DeveloperID == id {
+			delete(r.s.goals, goalID)
+		}
+	}
+	for meetingID, meeting := range r.s.oneOnOnes {
+		if meeting.DeveloperID == id {
+			delete(r.s.oneOnOnes, meetingID)
+		}
+	}
+	delete(r.s.developers, id)
+	return nil
+}
+
+type memoryReports struct{ s *memoryStore }
+
+// visible indica se o relatório pertence à empresa (nil = qualquer empresa); requer o lock
+func (r memoryReports) visible(report models.PerformanceReport, companyID *uuid.UUID) bool {
+	if companyID == nil {
+		return true
+	}
+	developer, ok := r.s.developers[report.DeveloperID]
+	return ok && sameID(developer.CompanyID, *companyID)
+}
+
+func (r memoryReports) List(filter ReportFilter) ([]models.PerformanceReport, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	reports := []models.PerformanceReport{}
+	for _, report := range r.s.reports {
+		if !r.visible(report, filter.CompanyID) {
+			continue
+		}
+		if filter.DeveloperID != nil && report.DeveloperID != *filter.DeveloperID {
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Month != reports[j].Month {
+			return reports[i].Month > reports[j].Month
+		}
+		return reports[i].CreatedAt.After(reports[j].CreatedAt)
+	})
+	return reports, nil
+}
+
+func (r memoryReports) ListByMonth(month string, companyID *uuid.UUID) ([]models.PerformanceReport, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	reports := []models.PerformanceReport{}
+	for _, report := range r.s.reports {
+		if report.Month == month && r.visible(report, companyID) {
+			reports = append(reports, report)
+		}
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].WeightedAverageScore != reports[j].WeightedAverageScore {
+			return reports[i].WeightedAverageScore > reports[j].WeightedAverageScore
+		}
+		return reports[i].CreatedAt.After(reports[j].CreatedAt)
+	})
+	return reports, nil
+}
+
+func (r memoryReports) FindByID(id uuid.UUID, companyID *uuid.UUID) (*models.PerformanceReport, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	report, ok := r.s.reports[id]
+	if !ok || !r.visible(report, companyID) {
+		return nil, ErrNotFound
+	}
+	return &report, nil
+}
+
+func (r memoryReports) AvailableMonths(companyID *uuid.UUID) ([]string, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	seen := map[string]bool{}
+	months := []string{}
+	for _, report := range r.s.reports {
+		if r.visible(report, companyID) && !seen[report.Month] {
+			seen[report.Month] = true
+			months = append(months, report.Month)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(months)))
+	return months, nil
+}
+
+func (r memoryReports) ExistsForMonth(developerID uuid.UUID, month string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, report := range r.s.reports {
+		if report.DeveloperID == developerID && report.Month == month {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r memoryReports) Stats(companyID *uuid.UUID, from, to string) (models.PerformanceStats, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var stats models.PerformanceStats
+	var total float64
+	for _, report := range r.s.reports {
+		if !r.visible(report, companyID) {
+			continue
+		}
+		if from != "" && (report.Month < from || report.Month > to) {
+			continue
+		}
+		score := report.WeightedAverageScore
+		if stats.TotalReports == 0 || score > stats.HighestScore {
+			stats.HighestScore = score
+		}
+		if stats.TotalReports == 0 || score < stats.LowestScore {
+			stats.LowestScore = score
+		}
+		total += score
+		stats.TotalReports++
+	}
+	if stats.TotalReports > 0 {
+		stats.AverageScore = total / float64(stats.TotalReports)
+	}
+	return stats, nil
+}
+
+func (r memoryReports) Create(report *models.PerformanceReport) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	developer, ok := r.s.developers[report.DeveloperID]
+	if !ok {
+		return ErrNotFound
+	}
+	stamp(&report.ID, &report.CreatedAt, &report.UpdatedAt)
+	report.TeamID = developer.TeamID
+	report.UpdatedBy = report.CreatedBy
+	r.s.reports[report.ID] = *report
+	return nil
+}
+
+func (r memoryReports) CreateWithGoals(report *models.PerformanceReport, goals []models.Goal) error {
+	if err := r.Create(report); err != nil {
+		return err
+	}
+	for i := range goals {
+		goals[i].ReportID = &report.ID
+		if err := (memoryGoals{r.s}).Create(&goals[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type memoryGoals struct{ s *memoryStore }
+
+// sortGoalsByDueDate ordena pelo prazo (metas sem prazo por último) e depois pela criação
+func sortGoalsByDueDate(goals []models.Goal) {
+	sort.Slice(goals, func(i, j int) bool {
+		a, b := goals[i].DueDate, goals[j].DueDate
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return goals[i].CreatedAt.Before(goals[j].CreatedAt)
+	})
+}
+
+func (r memoryGoals) ListByReport(reportID uuid.UUID) ([]models.Goal, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	goals := []models.Goal{}
+	for _, goal := range r.s.goals {
+		if sameID(goal.ReportID, reportID) {
+			goal.ProgressUpdates = nil
+			goals = append(goals, goal)
+		}
+	}
+	sort.Slice(goals, func(i, j int) bool { return goals[i].CreatedAt.Before(goals[j].CreatedAt) })
+	return goals, nil
+}
+
+func (r memoryGoals) ListByDeveloper(developerID uuid.UUID, statuses []string) ([]models.Goal, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	goals := []models.Goal{}
+	for _, goal := range r.s.goals {
+		if goal.DeveloperID != developerID {
+			continue
+		}
+		if len(statuses) > 0 && !slices.Contains(statuses, goal.Status) {
+			continue
+		}
+		goals = append(goals, goal)
+	}
+	sort.Slice(goals, func(i, j int) bool {
+		a, b := goals[i].DueDate, goals[j].DueDate
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return goals[i].CreatedAt.After(goals[j].CreatedAt)
+	})
+	return goals, nil
+}
+
+func (r memoryGoals) FindByID(id uuid.UUID) (*models.Goal, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	goal, ok := r.s.goals[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	goal.ProgressUpdates = nil
+	return &goal, nil
+}
+
+func (r memoryGoals) ListOpenAtReport(report models.PerformanceReport) ([]models.Goal, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	goals := []models.Goal{}
+	for _, goal := range r.s.goals {
+		if goal.DeveloperID != report.DeveloperID || sameID(goal.ReportID, report.ID) {
+			continue
+		}
+		if !goal.CreatedAt.Before(report.CreatedAt) {
+			continue
+		}
+		if goal.CompletedAt != nil && goal.CompletedAt.Before(report.CreatedAt) {
+			continue
+		}
+		goals = append(goals, goal)
+	}
+	sortGoalsByDueDate(goals)
+	return goals, nil
+}
+
+func (r memoryGoals) LoadProgressUpdates(goals []models.Goal) error {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for i := range goals {
+		goals[i].ProgressUpdates = r.s.goals[goals[i].ID].ProgressUpdates
+	}
+	return nil
+}
+
+func (r memoryGoals) Create(goal *models.Goal) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.developers[goal.DeveloperID]; !ok {
+		return ErrNotFound
+	}
+	stamp(&goal.ID, &goal.CreatedAt, &goal.UpdatedAt)
+	if goal.Categories == nil {
+		goal.Categories = models.Array[string]{}
+	}
+	if goal.Status == "" {
+		goal.Status = "open"
+	}
+	r.s.goals[goal.ID] = *goal
+	return nil
+}
+
+func (r memoryGoals) Update(goal *models.Goal) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.goals[goal.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	stored.Title = goal.Title
+	stored.Description = goal.Description
+	stored.OwnerID = goal.OwnerID
+	stored.Categories = goal.Categories
+	if stored.Categories == nil {
+		stored.Categories = models.Array[string]{}
+	}
+	stored.DueDate = goal.DueDate
+	stored.UpdatedAt = time.Now()
+	r.s.goals[goal.ID] = stored
+	*goal = stored
+	goal.ProgressUpdates = nil
+	return nil
+}
+
+func (r memoryGoals) AddProgress(goal *models.Goal, update *models.GoalProgressUpdate) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.goals[goal.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	update.ID = uuid.New()
+	update.GoalID = goal.ID
+	update.CreatedAt = time.Now()
+
+	stored.Progress = update.Progress
+	stored.Status = update.Status
+	if update.Status == "completed" || update.Status == "cancelled" {
+		if stored.CompletedAt == nil {
+			stored.CompletedAt = &update.CreatedAt
+		}
+	} else {
+		stored.CompletedAt = nil
+	}
+	stored.UpdatedAt = update.CreatedAt
+	stored.ProgressUpdates = append(append([]models.GoalProgressUpdate{}, stored.ProgressUpdates...), *update)
+	r.s.goals[goal.ID] = stored
+	*goal = stored
+	goal.ProgressUpdates = nil
+	return nil
+}
+
+func (r memoryGoals) Delete(id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.goals[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.s.goals, id)
+	return nil
+}
+
+type memoryOneOnOnes struct{ s *memoryStore }
+
+func (r memoryOneOnOnes) List(filter OneOnOneFilter) ([]models.OneOnOne, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	meetings := []models.OneOnOne{}
+	for _, meeting := range r.s.oneOnOnes {
+		if filter.ID != nil && meeting.ID != *filter.ID {
+			continue
+		}
+		if filter.CompanyID != nil && !sameID(meeting.CompanyID, *filter.CompanyID) {
+			continue
+		}
+		if filter.DeveloperID != nil && meeting.DeveloperID != *filter.DeveloperID {
+			continue
+		}
+		if filter.Month != "" && meeting.MeetingDate.Format("2006-01") != filter.Month {
+			continue
+		}
+		if filter.SharedWith != nil {
+			developer := r.s.developers[meeting.DeveloperID]
+			if meeting.Visibility != "shared" || !sameID(developer.UserID, *filter.SharedWith) {
+				continue
+			}
+		}
+		meeting.ActionItems = append([]models.OneOnOneActionItem{}, meeting.ActionItems...)
+		meetings = append(meetings, meeting)
+	}
+
+	sort.Slice(meetings, func(i, j int) bool {
+		if filter.Month != "" {
+			return meetings[i].MeetingDate.Before(meetings[j].MeetingDate)
+		}
+		return meetings[i].MeetingDate.After(meetings[j].MeetingDate)
+	})
+	return meetings, nil
+}
+
+func (r memoryOneOnOnes) Create(meeting *models.OneOnOne) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.developers[meeting.DeveloperID]; !ok {
+		return ErrNotFound
+	}
+	stamp(&meeting.ID, &meeting.CreatedAt, &meeting.UpdatedAt)
+	if meeting.Agenda == nil {
+		meeting.Agenda = models.Array[string]{}
+	}
+	items := meeting.ActionItems
+	meeting.ActionItems = []models.OneOnOneActionItem{}
+	for _, item := range items {
+		item.OneOnOneID = meeting.ID
+		stamp(&item.ID, &item.CreatedAt, &item.UpdatedAt)
+		meeting.ActionItems = append(meeting.ActionItems, item)
+	}
+	r.s.oneOnOnes[meeting.ID] = *meeting
+	return nil
+}
+
+func (r memoryOneOnOnes) Update(meeting *models.OneOnOne) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.oneOnOnes[meeting.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	stored.MeetingDate = meeting.MeetingDate
+	stored.Agenda = meeting.Agenda
+	if stored.Agenda == nil {
+		stored.Agenda = models.Array[string]{}
+	}
+	stored.Notes = meeting.Notes
+	stored.Visibility = meeting.Visibility
+	stored.UpdatedAt = time.Now()
+	r.s.oneOnOnes[meeting.ID] = stored
+
+	items := meeting.ActionItems
+	*meeting = stored
+	meeting.ActionItems = items
+	return nil
+}
+
+func (r memoryOneOnOnes) Delete(id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.oneOnOnes[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.s.oneOnOnes, id)
+	return nil
+}
+
+func (r memoryOneOnOnes) CreateActionItem(item *models.OneOnOneActionItem) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	meeting, ok := r.s.oneOnOnes[item.OneOnOneID]
+	if !ok {
+		return ErrNotFound
+	}
+	stamp(&item.ID, &item.CreatedAt, &item.UpdatedAt)
+	meeting.ActionItems = append(append([]models.OneOnOneActionItem{}, meeting.ActionItems...), *item)
+	r.s.oneOnOnes[meeting.ID] = meeting
+	return nil
+}
+
+func (r memoryOneOnOnes) UpdateActionItem(item *models.OneOnOneActionItem) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	meeting, ok := r.s.oneOnOnes[item.OneOnOneID]
+	if !ok {
+		return ErrNotFound
+	}
+	items := append([]models.OneOnOneActionItem{}, meeting.ActionItems...)
+	for i := range items {
+		if items[i].ID != item.ID {
+			continue
+		}
+		items[i].Description = item.Description
+		items[i].OwnerID = item.OwnerID
+		items[i].DueDate = item.DueDate
+		items[i].CompletedAt = item.CompletedAt
+		items[i].CompletedBy = item.CompletedBy
+		items[i].UpdatedAt = time.Now()
+		meeting.ActionItems = items
+		r.s.oneOnOnes[meeting.ID] = meeting
+		*item = items[i]
+		return nil
+	}
+	return ErrNotFound
+}
+
+func (r memoryOneOnOnes) DeleteActionItem(meetingID, itemID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	meeting, ok := r.s.oneOnOnes[meetingID]
+	if !ok {
+		return ErrNotFound
+	}
+	items := []models.OneOnOneActionItem{}
+	for _, item := range meeting.ActionItems {
+		if item.ID != itemID {
+			items = append(items, item)
+		}
+	}
+	if len(items) == len(meeting.ActionItems) {
+		return ErrNotFound
+	}
+	meeting.ActionItems = items
+	r.s.oneOnOnes[meetingID] = meeting
+	return nil
+}
+
+type memoryAudit struct{ s *memoryStore }
+
+// Snapshot retorna ErrNotFound: os dados em memória não guardam o formato das linhas das tabelas
+func (r memoryAudit) Snapshot(table string, id uuid.UUID) (models.JSONB, error) {
+	return nil, ErrNotFound
+}
+
+func (r memoryAudit) Record(entry *models.AuditLog) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	entry.ID = uuid.New()
+	entry.CreatedAt = time.Now()
+	r.s.audit = append(r.s.audit, *entry)
+	return nil
+}
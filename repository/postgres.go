@@ -0,0 +1,886 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/models"
+)
+
+const (
+	companyColumns   = "id, name, COALESCE(description, '') AS description, is_active, created_at, updated_at"
+	userColumns      = "id, email, name, role, company_id, needs_password_change, is_active, created_at, updated_at"
+	teamColumns      = "id, name, COALESCE(description, '') AS description, COALESCE(color, '') AS color, company_id, parent_id, kind, created_at, updated_at"
+	developerColumns = "id, name, role, latest_performance_score, team_id, company_id, user_id, level_id, archived_at, created_by, updated_by, created_at, updated_at"
+	goalColumns      = "id, developer_id, company_id, report_id, owner_id, title, COALESCE(description, '') AS description, categories, due_date, status, progress, completed_at, created_at, updated_at"
+	progressColumns  = "id, goal_id, author_id, progress, status, COALESCE(note, '') AS note, created_at"
+	oneOnOneColumns  = "o.id, o.developer_id, o.company_id, o.author_id, o.meeting_date, o.agenda, COALESCE(o.notes, '') AS notes, o.visibility, o.created_at, o.updated_at"
+	// oneOnOneReturning são as colunas de oneOnOneColumns sem o alias, que o SQLite não aceita no RETURNING
+	oneOnOneReturning      = "id, developer_id, company_id, author_id, meeting_date, agenda, COALESCE(notes, '') AS notes, visibility, created_at, updated_at"
+	actionItemColumns      = "id, one_on_one_id, description, owner_id, due_date, completed_at, completed_by, created_at, updated_at"
+	companySettingsColumns = `company_id, score_min, score_max, score_step, decimal_places, locale, timezone,
+		fiscal_year_start_month, team_color_palette, updated_by, created_at, updated_at`
+	reportColumns = "pr.id, pr.developer_id, pr.month, pr.question_scores, pr.category_scores, pr.weighted_average_score, COALESCE(pr.highlights, '') AS highlights, COALESCE(pr.points_to_develop, '') AS points_to_develop, pr.team_id, pr.created_by, pr.updated_by, pr.created_at, pr.updated_at"
+)
+
+// PostgresProvider cria stores PostgreSQL sobre a conexão ou transação recebida
+type PostgresProvider struct{}
+
+// Store retorna um Store PostgreSQL que executa as consultas em q
+func (PostgresProvider) Store(q database.Querier) Store {
+	return NewPostgresStore(q)
+}
+
+type postgresStore struct {
+	q database.Querier
+}
+
+// NewPostgresStore retorna um Store que executa as consultas em q (conexão ou transação)
+func NewPostgresStore(q database.Querier) Store {
+	return &postgresStore{q: q}
+}
+
+func (s *postgresStore) Companies() CompanyRepository    { return postgresCompanies{s.q} }
+func (s *postgresStore) Users() UserRepository           { return postgresUsers{s.q} }
+func (s *postgresStore) Teams() TeamRepository           { return postgresTeams{s.q} }
+func (s *postgresStore) Developers() DeveloperRepository { return postgresDevelopers{s.q} }
+func (s *postgresStore) Reports() ReportRepository       { return postgresReports{s.q} }
+func (s *postgresStore) Goals() GoalRepository           { return postgresGoals{s.q} }
+func (s *postgresStore) OneOnOnes() OneOnOneRepository   { return postgresOneOnOnes{s.q} }
+func (s *postgresStore) Audit() AuditRepository          { return postgresAudit{s.q} }
+
+type postgresCompanies struct{ q database.Querier }
+
+func (r postgresCompanies) List(companyID *uuid.UUID) ([]models.Company, error) {
+	companies := []models.Company{}
+	if companyID != nil {
+		err := r.q.Select(&companies, "SELECT "+companyColumns+" FROM companies WHERE id = $1 ORDER BY name ASC", *companyID)
+		return companies, err
+	}
+	err := r.q.Select(&companies, "SELECT "+companyColumns+" FROM companies ORDER BY name ASC")
+	return companies, err
+}
+
+func (r postgresCompanies) FindByID(id uuid.UUID) (*models.Company, error) {
+	var company models.Company
+	if err := r.q.Get(&company, "SELECT "+companyColumns+" FROM companies WHERE id = $1", id); err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r postgresCompanies) NameTaken(name string, exceptID *uuid.UUID) (bool, error) {
+	var taken bool
+	if exceptID != nil {
+		err := r.q.Get(&taken, "SELECT EXISTS(SELECT 1 FROM companies WHERE name = $1 AND id != $2)", name, *exceptID)
+		return taken, err
+	}
+	err := r.q.Get(&taken, "SELECT EXISTS(SELECT 1 FROM companies WHERE name = $1)", name)
+	return taken, err
+}
+
+func (r postgresCompanies) IsActive(id uuid.UUID) (bool, error) {
+	var active bool
+	err := r.q.Get(&active, "SELECT EXISTS(SELECT 1 FROM companies WHERE id = $1 AND is_active = true)", id)
+	return active, err
+}
+
+func (r postgresCompanies) Create(company *models.Company) error {
+	_, err := r.q.Exec(`
+		INSERT INTO companies (id, name, description, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, company.ID, company.Name, company.Description, company.IsActive, company.CreatedAt, company.UpdatedAt)
+	return err
+}
+
+func (r postgresCompanies) Update(company *models.Company) error {
+	return expectRow(r.q.Exec(`
+		UPDATE companies SET name = $1, description = $2, is_active = $3, updated_at = $4
+		WHERE id = $5
+	`, company.Name, company.Description, company.IsActive, company.UpdatedAt, company.ID))
+}
+
+func (r postgresCompanies) Delete(id uuid.UUID) error {
+	return expectRow(r.q.Exec("DELETE FROM companies WHERE id = $1", id))
+}
+
+func (r postgresCompanies) Settings(id uuid.UUID) (*models.CompanySettings, error) {
+	var settings models.CompanySettings
+	if err := r.q.Get(&settings, "SELECT "+companySettingsColumns+" FROM company_settings WHERE company_id = $1", id); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r postgresCompanies) SaveSettings(settings *models.CompanySettings) error {
+	return r.q.Get(settings, `
+		INSERT INTO company_settings (company_id, score_min, score_max, score_step, decimal_places, locale, timezone,
+		                              fiscal_year_start_month, team_color_palette, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (company_id) DO UPDATE
+		SET score_min = EXCLUDED.score_min,
+		    score_max = EXCLUDED.score_max,
+		    score_step = EXCLUDED.score_step,
+		    decimal_places = EXCLUDED.decimal_places,
+		    locale = EXCLUDED.locale,
+		    timezone = EXCLUDED.timezone,
+		    fiscal_year_start_month = EXCLUDED.fiscal_year_start_month,
+		    team_color_palette = EXCLUDED.team_color_palette,
+		    updated_by = EXCLUDED.updated_by
+		RETURNING `+companySettingsColumns,
+		settings.CompanyID, settings.ScoreMin, settings.ScoreMax, settings.ScoreStep, settings.DecimalPlaces,
+		settings.Locale, settings.Timezone, settings.FiscalYearStartMonth, settings.TeamColorPalette, settings.UpdatedBy,
+	)
+}
+
+type postgresUsers struct{ q database.Querier }
+
+func (r postgresUsers) List(companyID *uuid.UUID) ([]models.User, error) {
+	users := []models.User{}
+	if companyID != nil {
+		err := r.q.Select(&users, "SELECT "+userColumns+" FROM users WHERE company_id = $1 ORDER BY created_at DESC", *companyID)
+		return users, err
+	}
+	err := r.q.Select(&users, "SELECT "+userColumns+" FROM users ORDER BY created_at DESC")
+	return users, err
+}
+
+func (r postgresUsers) FindByID(id uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := r.q.Get(&user, "SELECT "+userColumns+", password FROM users WHERE id = $1", id); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r postgresUsers) FindByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.q.Get(&user, "SELECT "+userColumns+", password FROM users WHERE email = $1", email); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r postgresUsers) EmailTaken(email string, exceptID *uuid.UUID) (bool, error) {
+	var taken bool
+	if exceptID != nil {
+		err := r.q.Get(&taken, "SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND id != $2)", email, *exceptID)
+		return taken, err
+	}
+	err := r.q.Get(&taken, "SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)", email)
+	return taken, err
+}
+
+func (r postgresUsers) Count() (int, error) {
+	var count int
+	err := r.q.Get(&count, "SELECT COUNT(*) FROM users")
+	return count, err
+}
+
+func (r postgresUsers) CountByCompany(companyID uuid.UUID) (int, error) {
+	var count int
+	err := r.q.Get(&count, "SELECT COUNT(*) FROM users WHERE company_id = $1", companyID)
+	return count, err
+}
+
+func (r postgresUsers) Create(user *models.User) error {
+	_, err := r.q.Exec(`
+		INSERT INTO users (id, email, password, name, role, company_id, needs_password_change, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, user.ID, user.Email, user.Password, user.Name, user.Role, user.CompanyID, user.NeedsPasswordChange, user.IsActive, user.CreatedAt, user.UpdatedAt)
+	return err
+}
+
+func (r postgresUsers) Update(user *models.User) error {
+	return expectRow(r.q.Exec(`
+		UPDATE users
+		SET email = $1, password = $2, name = $3, role = $4, company_id = $5,
+		    needs_password_change = $6, is_active = $7, updated_at = $8
+		WHERE id = $9
+	`, user.Email, user.Password, user.Name, user.Role, user.CompanyID, user.NeedsPasswordChange, user.IsActive, user.UpdatedAt, user.ID))
+}
+
+func (r postgresUsers) Delete(id uuid.UUID) error {
+	return expectRow(r.q.Exec("DELETE FROM users WHERE id = $1", id))
+}
+
+type postgresTeams struct{ q database.Querier }
+
+func (r postgresTeams) List(companyID *uuid.UUID) ([]models.Team, error) {
+	teams := []models.Team{}
+	if companyID != nil {
+		err := r.q.Select(&teams, "SELECT "+teamColumns+" FROM teams WHERE company_id = $1 ORDER BY created_at DESC", *companyID)
+		return teams, err
+	}
+	err := r.q.Select(&teams, "SELECT "+teamColumns+" FROM teams ORDER BY created_at DESC")
+	return teams, err
+}
+
+func (r postgresTeams) FindByID(id uuid.UUID) (*models.Team, error) {
+	var team models.Team
+	if err := r.q.Get(&team, "SELECT "+teamColumns+" FROM teams WHERE id = $1", id); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r postgresTeams) CompanyOf(id uuid.UUID) (*uuid.UUID, error) {
+	var companyID *uuid.UUID
+	err := r.q.Get(&companyID, "SELECT company_id FROM teams WHERE id = $1", id)
+	return companyID, err
+}
+
+func (r postgresTeams) Create(team *models.Team) error {
+	return r.q.Get(team, `
+		INSERT INTO teams (name, description, color, company_id, parent_id, kind)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+teamColumns, team.Name, team.Description, team.Color, team.CompanyID, team.ParentID, team.Kind)
+}
+
+func (r postgresTeams) Update(team *models.Team) error {
+	return r.q.Get(team, `
+		UPDATE teams SET name = $1, description = $2, color = $3, kind = $4
+		WHERE id = $5
+		RETURNING `+teamColumns, team.Name, team.Description, team.Color, team.Kind, team.ID)
+}
+
+func (r postgresTeams) Delete(id uuid.UUID) error {
+	tx, err := database.Begin(r.q)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE teams SET parent_id = (SELECT parent_id FROM teams WHERE id = $1) WHERE parent_id = $1", id); err != nil {
+		return fmt.Errorf("realocar subunidades: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE developers SET team_id = NULL WHERE team_id = $1", id); err != nil {
+		return fmt.Errorf("desvincular desenvolvedores: %w", err)
+	}
+	if err := expectRow(tx.Exec("DELETE FROM teams WHERE id = $1", id)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type postgresDevelopers struct{ q database.Querier }
+
+func (r postgresDevelopers) List(filter DeveloperFilter) ([]models.Developer, error) {
+	query := "SELECT " + developerColumns + " FROM developers WHERE 1 = 1"
+	var args []interface{}
+
+	if filter.CompanyID != nil {
+		args = append(args, *filter.CompanyID)
+		query += fmt.Sprintf(" AND company_id = $%d", len(args))
+	}
+	if filter.TeamID != nil {
+		args = append(args, *filter.TeamID)
+		query += fmt.Sprintf(" AND team_id = $%d", len(args))
+	}
+
+	switch filter.Archived {
+	case ExcludeArchived:
+		query += " AND archived_at IS NULL ORDER BY created_at DESC"
+	case OnlyArchived:
+		query += " AND archived_at IS NOT NULL ORDER BY archived_at DESC"
+	default:
+		query += " ORDER BY created_at DESC"
+	}
+
+	developers := []models.Developer{}
+	err := r.q.Select(&developers, query, args...)
+	return developers, err
+}
+
+func (r postgresDevelopers) FindByID(id uuid.UUID) (*models.Developer, error) {
+	var developer models.Developer
+	if err := r.q.Get(&developer, "SELECT "+developerColumns+" FROM developers WHERE id = $1", id); err != nil {
+		return nil, err
+	}
+	return &developer, nil
+}
+
+func (r postgresDevelopers) Create(developer *models.Developer) error {
+	return r.q.Get(developer, `
+		INSERT INTO developers (name, role, team_id, company_id, user_id, created_by, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		RETURNING `+developerColumns,
+		developer.Name, developer.Role, developer.TeamID, developer.CompanyID, developer.UserID, developer.CreatedBy)
+}
+
+func (r postgresDevelopers) Update(developer *models.Developer) error {
+	return r.q.Get(developer, `
+		UPDATE developers
+		SET name = $1, role = $2, latest_performance_score = $3, team_id = $4, user_id = $5, updated_by = $6
+		WHERE id = $7
+		RETURNING `+developerColumns,
+		developer.Name, developer.Role, developer.LatestPerformanceScore, developer.TeamID, developer.UserID, developer.UpdatedBy, developer.ID)
+}
+
+func (r postgresDevelopers) SetLatestScore(id uuid.UUID, score float64) error {
+	return expectRow(r.q.Exec("UPDATE developers SET latest_performance_score = $1 WHERE id = $2", score, id))
+}
+
+func (r postgresDevelopers) SetArchived(id uuid.UUID, archivedAt *time.Time, updatedBy uuid.UUID) (*models.Developer, error) {
+	var developer models.Developer
+	err := r.q.Get(&developer, `
+		UPDATE developers SET archived_at = $1, updated_by = $2
+		WHERE id = $3
+		RETURNING `+developerColumns, archivedAt, updatedBy, id)
+	if err != nil {
+		return nil, err
+	}
+	return &developer, nil
+}
+
+func (r postgresDevelopers) Delete(id uuid.UUID) error {
+	tx, err := database.Begin(r.q)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM performance_reports WHERE developer_id = $1", id); err != nil {
+		return fmt.Errorf("excluir relatórios de performance: %w", err)
+	}
+	if err := expectRow(tx.Exec("DELETE FROM developers WHERE id = $1", id)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type postgresReports struct{ q database.Querier }
+
+func (r postgresReports) List(filter ReportFilter) ([]models.PerformanceReport, error) {
+	query := "SELECT " + reportColumns + " FROM performance_reports pr JOIN developers d ON pr.developer_id = d.id WHERE 1 = 1"
+	var args []interface{}
+
+	if filter.CompanyID != nil {
+		args = append(args, *filter.CompanyID)
+		query += fmt.Sprintf(" AND d.company_id = $%d", len(args))
+	}
+	if filter.DeveloperID != nil {
+		args = append(args, *filter.DeveloperID)
+		query += fmt.Sprintf(" AND pr.developer_id = $%d", len(args))
+	}
+	query += " ORDER BY pr.month DESC, pr.created_at DESC"
+
+	reports := []models.PerformanceReport{}
+	err := r.q.Select(&reports, query, args...)
+	return reports, err
+}
+
+func (r postgresReports) ListByMonth(month string, companyID *uuid.UUID) ([]models.PerformanceReport, error) {
+	query := "SELECT " + reportColumns + " FROM performance_reports pr JOIN developers d ON pr.developer_id = d.id WHERE pr.month = $1"
+	args := []interface{}{month}
+
+	if companyID != nil {
+		query += " AND d.company_id = $2"
+		args = append(args, *companyID)
+	}
+	query += " ORDER BY pr.weighted_average_score DESC, pr.created_at DESC"
+
+	reports := []models.PerformanceReport{}
+	err := r.q.Select(&reports, query, args...)
+	return reports, err
+}
+
+func (r postgresReports) FindByID(id uuid.UUID, companyID *uuid.UUID) (*models.PerformanceReport, error) {
+	query := "SELECT " + reportColumns + " FROM performance_reports pr JOIN developers d ON pr.developer_id = d.id WHERE pr.id = $1"
+	args := []interface{}{id}
+
+	if companyID != nil {
+		query += " AND d.company_id = $2"
+		args = append(args, *companyID)
+	}
+
+	var report models.PerformanceReport
+	if err := r.q.Get(&report, query, args...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r postgresReports) AvailableMonths(companyID *uuid.UUID) ([]string, error) {
+	months := []string{}
+	if companyID != nil {
+		err := r.q.Select(&months, `
+			SELECT DISTINCT pr.month
+			FROM performance_reports pr
+			JOIN developers d ON pr.developer_id = d.id
+			WHERE d.company_id = $1
+			ORDER BY pr.month DESC
+		`, *companyID)
+		return months, err
+	}
+	err := r.q.Select(&months, "SELECT DISTINCT month FROM performance_reports ORDER BY month DESC")
+	return months, err
+}
+
+func (r postgresReports) ExistsForMonth(developerID uuid.UUID, month string) (bool, error) {
+	var exists bool
+	err := r.q.Get(&exists, "SELECT EXISTS(SELECT 1 FROM performance_reports WHERE developer_id = $1 AND month = $2)", developerID, month)
+	return exists, err
+}
+
+func (r postgresReports) Stats(companyID *uuid.UUID, from, to string) (models.PerformanceStats, error) {
+	query := `
+		SELECT COUNT(*),
+		       COALESCE(AVG(pr.weighted_average_score), 0),
+		       COALESCE(MAX(pr.weighted_average_score), 0),
+		       COALESCE(MIN(pr.weighted_average_score), 0)
+		FROM performance_reports pr
+		JOIN developers d ON pr.developer_id = d.id
+		WHERE 1 = 1`
+	var args []interface{}
+
+	if companyID != nil {
+		args = append(args, *companyID)
+		query += fmt.Sprintf(" AND d.company_id = $%d", len(args))
+	}
+	if from != "" {
+		args = append(args, from, to)
+		query += fmt.Sprintf(" AND pr.month BETWEEN $%d AND $%d", len(args)-1, len(args))
+	}
+
+	var stats models.PerformanceStats
+	err := r.q.QueryRow(query, args...).Scan(&stats.TotalReports, &stats.AverageScore, &stats.HighestScore, &stats.LowestScore)
+	return stats, err
+}
+
+func (r postgresReports) Create(report *models.PerformanceReport) error {
+	return r.q.Get(report, `
+		INSERT INTO performance_reports (developer_id, month, question_scores, category_scores,
+		                                 weighted_average_score, highlights, points_to_develop, team_id, created_by, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT team_id FROM developers WHERE id = $1), $8, $8)
+		RETURNING id, developer_id, month, question_scores, category_scores, weighted_average_score,
+		          highlights, points_to_develop, team_id, created_by, updated_by, created_at, updated_at
+	`, report.DeveloperID, report.Month, report.QuestionScores, report.CategoryScores,
+		report.WeightedAverageScore, report.Highlights, report.PointsToDevelop, report.CreatedBy)
+}
+
+func (r postgresReports) CreateWithGoals(report *models.PerformanceReport, goals []models.Goal) error {
+	tx, err := database.Begin(r.q)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := (postgresReports{tx}).Create(report); err != nil {
+		return err
+	}
+	for i := range goals {
+		goals[i].ReportID = &report.ID
+		if err := (postgresGoals{tx}).Create(&goals[i]); err != nil {
+			return fmt.Errorf("criar meta do relatório: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+type postgresGoals struct{ q database.Querier }
+
+func (r postgresGoals) ListByReport(reportID uuid.UUID) ([]models.Goal, error) {
+	goals := []models.Goal{}
+	err := r.q.Select(&goals, "SELECT "+goalColumns+" FROM goals WHERE report_id = $1 ORDER BY created_at", reportID)
+	return goals, err
+}
+
+func (r postgresGoals) ListByDeveloper(developerID uuid.UUID, statuses []string) ([]models.Goal, error) {
+	query := "SELECT " + goalColumns + " FROM goals WHERE developer_id = ?"
+	args := []interface{}{developerID}
+	if len(statuses) > 0 {
+		query += " AND status IN (?)"
+		args = append(args, statuses)
+	}
+	query += " ORDER BY COALESCE(due_date, '9999-12-31'), created_at DESC"
+
+	query, args, err := sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	goals := []models.Goal{}
+	if err := r.q.Select(&goals, sqlx.Rebind(sqlx.DOLLAR, query), args...); err != nil {
+		return nil, err
+	}
+	return goals, r.LoadProgressUpdates(goals)
+}
+
+func (r postgresGoals) FindByID(id uuid.UUID) (*models.Goal, error) {
+	var goal models.Goal
+	if err := r.q.Get(&goal, "SELECT "+goalColumns+" FROM goals WHERE id = $1", id); err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+func (r postgresGoals) ListOpenAtReport(report models.PerformanceReport) ([]models.Goal, error) {
+	goals := []models.Goal{}
+	err := r.q.Select(&goals, `
+		SELECT `+goalColumns+`
+		FROM goals
+		WHERE developer_id = $1
+		  AND (report_id IS NULL OR report_id <> $2)
+		  AND created_at < $3
+		  AND (completed_at IS NULL OR completed_at >= $3)
+		ORDER BY COALESCE(due_date, '9999-12-31'), created_at
+	`, report.DeveloperID, report.ID, report.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return goals, r.LoadProgressUpdates(goals)
+}
+
+func (r postgresGoals) LoadProgressUpdates(goals []models.Goal) error {
+	if len(goals) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(goals))
+	index := make(map[uuid.UUID]int, len(goals))
+	for i, goal := range goals {
+		ids[i] = goal.ID
+		index[goal.ID] = i
+	}
+
+	// IN com a lista expandida no lugar de ANY, que só existe no PostgreSQL
+	query, args, err := sqlx.In("SELECT "+progressColumns+" FROM goal_progress_updates WHERE goal_id IN (?) ORDER BY created_at ASC", ids)
+	if err != nil {
+		return err
+	}
+
+	updates := []models.GoalProgressUpdate{}
+	if err := r.q.Select(&updates, sqlx.Rebind(sqlx.DOLLAR, query), args...); err != nil {
+		return err
+	}
+	for _, update := range updates {
+		i := index[update.GoalID]
+		goals[i].ProgressUpdates = append(goals[i].ProgressUpdates, update)
+	}
+	return nil
+}
+
+func (r postgresGoals) Create(goal *models.Goal) error {
+	categories := goal.Categories
+	if categories == nil {
+		categories = models.Array[string]{}
+	}
+
+	return r.q.Get(goal, `
+		INSERT INTO goals (developer_id, company_id, report_id, owner_id, title, description, categories, due_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+goalColumns,
+		goal.DeveloperID, goal.CompanyID, goal.ReportID, goal.OwnerID, goal.Title, goal.Description, categories, goal.DueDate)
+}
+
+func (r postgresGoals) Update(goal *models.Goal) error {
+	categories := goal.Categories
+	if categories == nil {
+		categories = models.Array[string]{}
+	}
+
+	return r.q.Get(goal, `
+		UPDATE goals SET title = $1, description = $2, owner_id = $3, categories = $4, due_date = $5
+		WHERE id = $6
+		RETURNING `+goalColumns,
+		goal.Title, goal.Description, goal.OwnerID, categories, goal.DueDate, goal.ID)
+}
+
+func (r postgresGoals) AddProgress(goal *models.Goal, update *models.GoalProgressUpdate) error {
+	tx, err := database.Begin(r.q)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.Get(update, `
+		INSERT INTO goal_progress_updates (goal_id, author_id, progress, status, note)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+progressColumns,
+		goal.ID, update.AuthorID, update.Progress, update.Status, update.Note)
+	if err != nil {
+		return fmt.Errorf("criar atualização de progresso: %w", err)
+	}
+
+	err = tx.Get(goal, `
+		UPDATE goals
+		SET progress = $1,
+		    status = $2,
+		    completed_at = CASE WHEN $2 IN ('completed', 'cancelled') THEN COALESCE(completed_at, CURRENT_TIMESTAMP) ELSE NULL END
+		WHERE id = $3
+		RETURNING `+goalColumns,
+		update.Progress, update.Status, goal.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r postgresGoals) Delete(id uuid.UUID) error {
+	return expectRow(r.q.Exec("DELETE FROM goals WHERE id = $1", id))
+}
+
+type postgresOneOnOnes struct{ q database.Querier }
+
+func (r postgresOneOnOnes) List(filter OneOnOneFilter) ([]models.OneOnOne, error) {
+	query := "SELECT " + oneOnOneColumns + " FROM one_on_ones o WHERE 1 = 1"
+	var args []interface{}
+
+	if filter.ID != nil {
+		args = append(args, *filter.ID)
+		query += fmt.Sprintf(" AND o.id = $%d", len(args))
+	}
+	if filter.CompanyID != nil {
+		args = append(args, *filter.CompanyID)
+		query += fmt.Sprintf(" AND o.company_id = $%d", len(args))
+	}
+	if filter.DeveloperID != nil {
+		args = append(args, *filter.DeveloperID)
+		query += fmt.Sprintf(" AND o.developer_id = $%d", len(args))
+	}
+	if filter.Month != "" {
+		args = append(args, filter.Month)
+		query += fmt.Sprintf(" AND %s = $%d", monthOf("o.meeting_date"), len(args))
+	}
+	if filter.SharedWith != nil {
+		args = append(args, *filter.SharedWith)
+		query += fmt.Sprintf(` AND o.visibility = 'shared'
+			AND EXISTS(SELECT 1 FROM developers d WHERE d.id = o.developer_id AND d.user_id = $%d)`, len(args))
+	}
+
+	if filter.Month != "" {
+		query += " ORDER BY o.meeting_date ASC"
+	} else {
+		query += " ORDER BY o.meeting_date DESC"
+	}
+
+	meetings := []models.OneOnOne{}
+	if err := r.q.Select(&meetings, query, args...); err != nil {
+		return nil, err
+	}
+	return meetings, r.loadActionItems(meetings)
+}
+
+// loadActionItems preenche os itens de ação das reuniões
+func (r postgresOneOnOnes) loadActionItems(meetings []models.OneOnOne) error {
+	if len(meetings) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(meetings))
+	index := make(map[uuid.UUID]int, len(meetings))
+	for i := range meetings {
+		meetings[i].ActionItems = []models.OneOnOneActionItem{}
+		ids[i] = meetings[i].ID
+		index[meetings[i].ID] = i
+	}
+
+	query, args, err := sqlx.In("SELECT "+actionItemColumns+" FROM one_on_one_action_items WHERE one_on_one_id IN (?) ORDER BY created_at ASC", ids)
+	if err != nil {
+		return err
+	}
+
+	items := []models.OneOnOneActionItem{}
+	if err := r.q.Select(&items, sqlx.Rebind(sqlx.DOLLAR, query), args...); err != nil {
+		return err
+	}
+	for _, item := range items {
+		i := index[item.OneOnOneID]
+		meetings[i].ActionItems = append(meetings[i].ActionItems, item)
+	}
+	return nil
+}
+
+func (r postgresOneOnOnes) Create(meeting *models.OneOnOne) error {
+	tx, err := database.Begin(r.q)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	agenda := meeting.Agenda
+	if agenda == nil {
+		agenda = models.Array[string]{}
+	}
+
+	items := meeting.ActionItems
+	err = tx.Get(meeting, `
+		INSERT INTO one_on_ones (developer_id, company_id, author_id, meeting_date, agenda, notes, visibility)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+oneOnOneReturning,
+		meeting.DeveloperID, meeting.CompanyID, meeting.AuthorID, meeting.MeetingDate.Format("2006-01-02"),
+		agenda, meeting.Notes, meeting.Visibility)
+	if err != nil {
+		return err
+	}
+
+	meeting.ActionItems = []models.OneOnOneActionItem{}
+	for _, item := range items {
+		item.OneOnOneID = meeting.ID
+		if err := (postgresOneOnOnes{tx}).CreateActionItem(&item); err != nil {
+			return fmt.Errorf("criar item de ação: %w", err)
+		}
+		meeting.ActionItems = append(meeting.ActionItems, item)
+	}
+
+	return tx.Commit()
+}
+
+func (r postgresOneOnOnes) Update(meeting *models.OneOnOne) error {
+	agenda := meeting.Agenda
+	if agenda == nil {
+		agenda = models.Array[string]{}
+	}
+
+	return r.q.Get(meeting, `
+		UPDATE one_on_ones SET meeting_date = $1, agenda = $2, notes = $3, visibility = $4
+		WHERE id = $5
+		RETURNING `+oneOnOneReturning,
+		meeting.MeetingDate.Format("2006-01-02"), agenda, meeting.Notes, meeting.Visibility, meeting.ID)
+}
+
+func (r postgresOneOnOnes) Delete(id uuid.UUID) error {
+	return expectRow(r.q.Exec("DELETE FROM one_on_ones WHERE id = $1", id))
+}
+
+func (r postgresOneOnOnes) CreateActionItem(item *models.OneOnOneActionItem) error {
+	return r.q.Get(item, `
+		INSERT INTO one_on_one_action_items (one_on_one_id, description, owner_id, due_date)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+actionItemColumns,
+		item.OneOnOneID, item.Description, item.OwnerID, item.DueDate)
+}
+
+func (r postgresOneOnOnes) UpdateActionItem(item *models.OneOnOneActionItem) error {
+	return r.q.Get(item, `
+		UPDATE one_on_one_action_items
+		SET description = $1, owner_id = $2, due_date = $3, completed_at = $4, completed_by = $5
+		WHERE id = $6
+		RETURNING `+actionItemColumns,
+		item.Description, item.OwnerID, item.DueDate, item.CompletedAt, item.CompletedBy, item.ID)
+}
+
+func (r postgresOneOnOnes) DeleteActionItem(meetingID, itemID uuid.UUID) error {
+	return expectRow(r.q.Exec("DELETE FROM one_on_one_action_items WHERE id = $1 AND one_on_one_id = $2", itemID, meetingID))
+}
+
+type postgresAudit struct{ q database.Querier }
+
+func (r postgresAudit) Snapshot(table string, id uuid.UUID) (models.JSONB, error) {
+	var raw []byte
+	var err error
+	if database.IsSQLite() {
+		raw, err = rowJSON(r.q, table, id)
+	} else {
+		err = r.q.QueryRow("SELECT row_to_json(t) FROM "+table+" t WHERE id = $1", id).Scan(&raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot models.JSONB
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("decodificar snapshot de %s: %w", table, err)
+	}
+	return snapshot, nil
+}
+
+// rowJSON serializa um registro em Go, para bancos sem row_to_json (SQLite)
+func rowJSON(q database.Querier, table string, id uuid.UUID) ([]byte, error) {
+	rows, err := q.Queryx("SELECT * FROM "+table+" WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	row := make(map[string]interface{})
+	if err := rows.MapScan(row); err != nil {
+		return nil, err
+	}
+	for column, value := range row {
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		// Colunas JSON voltam como texto; mantê-las como objeto deixa o snapshot igual ao do PostgreSQL
+		if text, ok := value.(string); ok && strings.HasPrefix(text, "{") && json.Valid([]byte(text)) {
+			value = json.RawMessage(text)
+		}
+		row[column] = value
+	}
+	return json.Marshal(row)
+}
+
+func (r postgresAudit) Record(entry *models.AuditLog) error {
+	// A entrada participa da transação da requisição; o savepoint impede que uma falha
+	// na auditoria aborte a operação já realizada
+	tx, err := database.Begin(r.q)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var before, after interface{}
+	if entry.Before != nil {
+		before = entry.Before
+	}
+	if entry.After != nil {
+		after = entry.After
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO audit_logs (actor_id, actor_email, actor_role, company_id, action, entity_type, entity_id,
+		                        before_data, after_data, ip_address, user_agent, method, path)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`,
+		entry.ActorID, entry.ActorEmail, entry.ActorRole, entry.CompanyID, entry.Action, entry.EntityType, entry.EntityID,
+		before, after, entry.IPAddress, entry.UserAgent, entry.Method, entry.Path,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// monthOf retorna a expressão SQL com o mês (YYYY-MM) de uma coluna de data
+func monthOf(column string) string {
+	if database.IsSQLite() {
+		return "strftime('%Y-%m', " + column + ")"
+	}
+	return "to_char(" + column + ", 'YYYY-MM')"
+}
+
+// expectRow converte um UPDATE/DELETE que não afetou linhas em ErrNotFound
+func expectRow(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
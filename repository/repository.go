@@ -0,0 +1,202 @@
+// Package repository isola o acesso a dados das entidades principais (empresas, usuários,
+// times, desenvolvedores, relatórios, metas, reuniões 1:1 e auditoria) atrás de interfaces, com uma implementação
+// PostgreSQL e outra em memória para testes.
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/models"
+)
+
+// ErrNotFound é retornado quando o registro procurado não existe (equivale a sql.ErrNoRows)
+var ErrNotFound = sql.ErrNoRows
+
+// Provider cria um Store ligado à conexão ou transação da requisição
+type Provider interface {
+	Store(q database.Querier) Store
+}
+
+// Store agrupa os repositórios disponíveis para um mesmo contexto de acesso
+type Store interface {
+	Companies() CompanyRepository
+	Users() UserRepository
+	Teams() TeamRepository
+	Developers() DeveloperRepository
+	Reports() ReportRepository
+	Goals() GoalRepository
+	OneOnOnes() OneOnOneRepository
+	Audit() AuditRepository
+}
+
+// CompanyRepository acessa as empresas
+type CompanyRepository interface {
+	// List retorna as empresas ordenadas por nome; companyID restringe a uma única empresa
+	List(companyID *uuid.UUID) ([]models.Company, error)
+	FindByID(id uuid.UUID) (*models.Company, error)
+	// NameTaken indica se outra empresa (diferente de exceptID) já usa o nome
+	NameTaken(name string, exceptID *uuid.UUID) (bool, error)
+	// IsActive indica se a empresa existe e está ativa
+	IsActive(id uuid.UUID) (bool, error)
+	Create(company *models.Company) error
+	Update(company *models.Company) error
+	Delete(id uuid.UUID) error
+	// Settings retorna as configurações da empresa (ErrNotFound se nunca foram gravadas)
+	Settings(id uuid.UUID) (*models.CompanySettings, error)
+	// SaveSettings cria ou substitui as configurações da empresa
+	SaveSettings(settings *models.CompanySettings) error
+}
+
+// UserRepository acessa os usuários
+type UserRepository interface {
+	// List retorna os usuários (sem senha) mais recentes primeiro; companyID restringe à empresa
+	List(companyID *uuid.UUID) ([]models.User, error)
+	FindByID(id uuid.UUID) (*models.User, error)
+	FindByEmail(email string) (*models.User, error)
+	// EmailTaken indica se outro usuário (diferente de exceptID) já usa o email
+	EmailTaken(email string, exceptID *uuid.UUID) (bool, error)
+	Count() (int, error)
+	CountByCompany(companyID uuid.UUID) (int, error)
+	Create(user *models.User) error
+	Update(user *models.User) error
+	Delete(id uuid.UUID) error
+}
+
+// TeamRepository acessa os times
+type TeamRepository interface {
+	// List retorna os times mais recentes primeiro; companyID restringe à empresa
+	List(companyID *uuid.UUID) ([]models.Team, error)
+	FindByID(id uuid.UUID) (*models.Team, error)
+	// CompanyOf retorna a empresa do time
+	CompanyOf(id uuid.UUID) (*uuid.UUID, error)
+	Create(team *models.Team) error
+	// Update grava nome, descrição, cor e tipo do time (a hierarquia muda em MoveTeam)
+	Update(team *models.Team) error
+	// Delete remove o time: as subunidades passam ao pai do time e os desenvolvedores ficam sem time
+	Delete(id uuid.UUID) error
+}
+
+// ArchiveFilter define como desenvolvedores arquivados entram em uma listagem
+type ArchiveFilter int
+
+const (
+	ExcludeArchived ArchiveFilter = iota
+	IncludeArchived
+	OnlyArchived
+)
+
+// DeveloperFilter restringe a listagem de desenvolvedores
+type DeveloperFilter struct {
+	CompanyID *uuid.UUID
+	TeamID    *uuid.UUID
+	Archived  ArchiveFilter
+}
+
+// DeveloperRepository acessa os desenvolvedores
+type DeveloperRepository interface {
+	// List retorna os desenvolvedores mais recentes primeiro (arquivados: mais recentemente arquivados)
+	List(filter DeveloperFilter) ([]models.Developer, error)
+	FindByID(id uuid.UUID) (*models.Developer, error)
+	Create(developer *models.Developer) error
+	// Update grava nome, cargo, nota mais recente, time, usuário vinculado e autor da alteração
+	Update(developer *models.Developer) error
+	// SetLatestScore registra a nota do relatório mais recente do desenvolvedor
+	SetLatestScore(id uuid.UUID, score float64) error
+	// SetArchived arquiva (archivedAt definido) ou restaura (nil) o desenvolvedor
+	SetArchived(id uuid.UUID, archivedAt *time.Time, updatedBy uuid.UUID) (*models.Developer, error)
+	// Delete remove o desenvolvedor junto com seus relatórios de performance
+	Delete(id uuid.UUID) error
+}
+
+// ReportFilter restringe a listagem de relatórios de performance
+type ReportFilter struct {
+	CompanyID   *uuid.UUID
+	DeveloperID *uuid.UUID
+}
+
+// ReportRepository acessa os relatórios de performance. A empresa de um relatório é sempre
+// a do seu desenvolvedor.
+type ReportRepository interface {
+	// List retorna os relatórios do mês mais recente para o mais antigo
+	List(filter ReportFilter) ([]models.PerformanceReport, error)
+	// ListByMonth retorna os relatórios do mês ordenados pela nota
+	ListByMonth(month string, companyID *uuid.UUID) ([]models.PerformanceReport, error)
+	FindByID(id uuid.UUID, companyID *uuid.UUID) (*models.PerformanceReport, error)
+	// AvailableMonths retorna os meses com relatórios, do mais recente para o mais antigo
+	AvailableMonths(companyID *uuid.UUID) ([]string, error)
+	// ExistsForMonth indica se o desenvolvedor já tem relatório no mês
+	ExistsForMonth(developerID uuid.UUID, month string) (bool, error)
+	// Stats resume as notas dos relatórios; from e to (inclusivos) restringem os meses quando informados
+	Stats(companyID *uuid.UUID, from, to string) (models.PerformanceStats, error)
+	// Create grava o relatório herdando o time atual do desenvolvedor
+	Create(report *models.PerformanceReport) error
+	// CreateWithGoals grava o relatório e as metas definidas junto com ele (ReportID preenchido)
+	// de uma vez: se alguma meta falhar, nada é gravado
+	CreateWithGoals(report *models.PerformanceReport, goals []models.Goal) error
+}
+
+// GoalRepository acessa as metas do plano de desenvolvimento
+type GoalRepository interface {
+	// ListByDeveloper retorna as metas do desenvolvedor com o histórico de progresso, pelo prazo (metas
+	// sem prazo por último) e depois das mais recentes para as mais antigas; statuses, quando
+	// informado, restringe os status
+	ListByDeveloper(developerID uuid.UUID, statuses []string) ([]models.Goal, error)
+	FindByID(id uuid.UUID) (*models.Goal, error)
+	// ListByReport retorna as metas definidas no relatório, das mais antigas para as mais recentes
+	ListByReport(reportID uuid.UUID) ([]models.Goal, error)
+	// ListOpenAtReport retorna as metas do desenvolvedor que estavam em aberto quando o relatório
+	// foi criado, sem as definidas pelo próprio relatório, com o histórico de progresso
+	ListOpenAtReport(report models.PerformanceReport) ([]models.Goal, error)
+	// LoadProgressUpdates preenche o histórico de progresso das metas
+	LoadProgressUpdates(goals []models.Goal) error
+	// Create grava a meta com o status e o progresso iniciais
+	Create(goal *models.Goal) error
+	// Update grava título, descrição, responsável, categorias e prazo (o progresso muda em AddProgress)
+	Update(goal *models.Goal) error
+	// AddProgress grava a atualização de progresso e aplica progresso e status à meta de uma vez;
+	// metas concluídas ou canceladas ficam com a data de encerramento registrada
+	AddProgress(goal *models.Goal, update *models.GoalProgressUpdate) error
+	// Delete remove a meta junto com seu histórico de progresso
+	Delete(id uuid.UUID) error
+}
+
+// OneOnOneFilter restringe a listagem de reuniões 1:1
+type OneOnOneFilter struct {
+	ID          *uuid.UUID
+	CompanyID   *uuid.UUID
+	DeveloperID *uuid.UUID
+	// Month (YYYY-MM) restringe ao mês da reunião e ordena da mais antiga para a mais recente
+	Month string
+	// SharedWith restringe às reuniões compartilhadas do desenvolvedor vinculado ao usuário
+	SharedWith *uuid.UUID
+}
+
+// OneOnOneRepository acessa as reuniões 1:1 e seus itens de ação
+type OneOnOneRepository interface {
+	// List retorna as reuniões com os itens de ação, das mais recentes para as mais antigas
+	List(filter OneOnOneFilter) ([]models.OneOnOne, error)
+	// Create grava a reunião e os itens de ação informados de uma vez
+	Create(meeting *models.OneOnOne) error
+	// Update grava data, pauta, anotações e visibilidade da reunião, mantendo os itens de ação
+	Update(meeting *models.OneOnOne) error
+	// Delete remove a reunião junto com seus itens de ação
+	Delete(id uuid.UUID) error
+	// CreateActionItem grava um item de ação em uma reunião existente
+	CreateActionItem(item *models.OneOnOneActionItem) error
+	// UpdateActionItem grava descrição, responsável, prazo e conclusão do item de ação
+	UpdateActionItem(item *models.OneOnOneActionItem) error
+	// DeleteActionItem remove o item de ação da reunião
+	DeleteActionItem(meetingID, itemID uuid.UUID) error
+}
+
+// AuditRepository grava o log de auditoria
+type AuditRepository interface {
+	// Snapshot retorna o estado atual do registro, com as colunas da tabela como chaves
+	Snapshot(table string, id uuid.UUID) (models.JSONB, error)
+	// Record grava a entrada; uma falha não afeta as demais escritas da transação
+	Record(entry *models.AuditLog) error
+}
@@ -0,0 +1,328 @@
+package repository_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/migrations"
+	"tivix-performance-tracker-backend/models"
+	"tivix-performance-tracker-backend/repository"
+)
+
+// eachStore executa fn com o store em memória e com o store SQL sobre um banco SQLite
+// temporário com as migrações aplicadas, para que as duas implementações se comportem igual
+func eachStore(t *testing.T, fn func(t *testing.T, store repository.Store)) {
+	t.Run("memory", func(t *testing.T) {
+		fn(t, repository.NewMemoryProvider().Store(nil))
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "repository.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		manager := migrations.NewMigrationManager(db.DB)
+		manager.Dialect = db.DriverName()
+		if err := manager.RunMigrations(); err != nil {
+			t.Fatalf("aplicar migrações: %v", err)
+		}
+
+		// As consultas escolhem o dialeto pelo banco conectado
+		previous := database.DB
+		database.DB = db
+		t.Cleanup(func() { database.DB = previous })
+
+		fn(t, repository.PostgresProvider{}.Store(db))
+	})
+}
+
+// fixture cria uma empresa com um usuário e um desenvolvedor vinculado a ele
+func fixture(t *testing.T, store repository.Store) (models.Company, models.User, models.Developer) {
+	t.Helper()
+
+	now := time.Now()
+	company := models.Company{ID: uuid.New(), Name: uuid.NewString(), IsActive: true, CreatedAt: now, UpdatedAt: now}
+	if err := store.Companies().Create(&company); err != nil {
+		t.Fatal(err)
+	}
+	user := models.User{ID: uuid.New(), Email: uuid.NewString() + "@example.com", Password: "x", Name: "Dev",
+		Role: "user", CompanyID: &company.ID, IsActive: true, CreatedAt: now, UpdatedAt: now}
+	if err := store.Users().Create(&user); err != nil {
+		t.Fatal(err)
+	}
+	developer := models.Developer{Name: "Dev", Role: "Engineer", CompanyID: &company.ID, UserID: &user.ID}
+	if err := store.Developers().Create(&developer); err != nil {
+		t.Fatal(err)
+	}
+	return company, user, developer
+}
+
+func TestCreateReportWithGoals(t *testing.T) {
+	eachStore(t, func(t *testing.T, store repository.Store) {
+		company, user, developer := fixture(t, store)
+
+		dueDate := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+		report := models.PerformanceReport{DeveloperID: developer.ID, Month: "2025-01", WeightedAverageScore: 8, CreatedBy: &user.ID}
+		goals := []models.Goal{
+			{DeveloperID: developer.ID, CompanyID: &company.ID, OwnerID: &user.ID, Title: "Mentorar", DueDate: &dueDate},
+			{DeveloperID: developer.ID, CompanyID: &company.ID, Title: "Certificação", Categories: models.Array[string]{"cloud"}},
+		}
+		if err := store.Reports().CreateWithGoals(&report, goals); err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := store.Reports().FindByID(report.ID, &company.ID)
+		if err != nil || found.Month != "2025-01" {
+			t.Fatalf("relatório: %+v %v", found, err)
+		}
+
+		listed, err := store.Goals().ListByReport(report.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(listed) != 2 {
+			t.Fatalf("metas do relatório: %d; esperado 2", len(listed))
+		}
+		created := map[uuid.UUID]models.Goal{}
+		for _, goal := range listed {
+			created[goal.ID] = goal
+			if goal.ReportID == nil || *goal.ReportID != report.ID || goal.Status != "open" {
+				t.Errorf("meta: %+v", goal)
+			}
+		}
+		for _, goal := range goals {
+			if _, ok := created[goal.ID]; !ok {
+				t.Errorf("meta %s não listada", goal.Title)
+			}
+		}
+		if categories := created[goals[1].ID].Categories; len(categories) != 1 || categories[0] != "cloud" {
+			t.Errorf("categorias: %v", categories)
+		}
+
+		// Metas do próprio relatório não entram nas metas em aberto quando ele foi criado
+		open, err := store.Goals().ListOpenAtReport(report)
+		if err != nil || len(open) != 0 {
+			t.Fatalf("metas em aberto: %v %v", open, err)
+		}
+	})
+}
+
+func TestGoalProgressAndDeletion(t *testing.T) {
+	eachStore(t, func(t *testing.T, store repository.Store) {
+		company, user, developer := fixture(t, store)
+
+		dueDate := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+		goals := []models.Goal{
+			{DeveloperID: developer.ID, CompanyID: &company.ID, Title: "Sem prazo"},
+			{DeveloperID: developer.ID, CompanyID: &company.ID, Title: "Com prazo", DueDate: &dueDate},
+		}
+		for i := range goals {
+			if err := store.Goals().Create(&goals[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		goal := goals[1]
+		goal.Title = "Com prazo revisado"
+		goal.Categories = models.Array[string]{"liderança"}
+		if err := store.Goals().Update(&goal); err != nil {
+			t.Fatal(err)
+		}
+		if goal.Title != "Com prazo revisado" || len(goal.Categories) != 1 || goal.Status != "open" {
+			t.Fatalf("meta atualizada: %+v", goal)
+		}
+
+		update := models.GoalProgressUpdate{AuthorID: &user.ID, Progress: 100, Status: "completed", Note: "Feito"}
+		if err := store.Goals().AddProgress(&goal, &update); err != nil {
+			t.Fatal(err)
+		}
+		if goal.Status != "completed" || goal.Progress != 100 || goal.CompletedAt == nil {
+			t.Fatalf("meta concluída: %+v", goal)
+		}
+		if update.ID == uuid.Nil || update.GoalID != goal.ID {
+			t.Fatalf("atualização de progresso: %+v", update)
+		}
+
+		// Reabrir a meta limpa a data de encerramento
+		reopen := models.GoalProgressUpdate{AuthorID: &user.ID, Progress: 50, Status: "in_progress"}
+		if err := store.Goals().AddProgress(&goal, &reopen); err != nil {
+			t.Fatal(err)
+		}
+		if goal.Status != "in_progress" || goal.CompletedAt != nil {
+			t.Fatalf("meta reaberta: %+v", goal)
+		}
+
+		listed, err := store.Goals().ListByDeveloper(developer.ID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(listed) != 2 || listed[0].ID != goal.ID || listed[1].ID != goals[0].ID {
+			t.Fatalf("metas do desenvolvedor: %+v", listed)
+		}
+		if len(listed[0].ProgressUpdates) != 2 {
+			t.Errorf("histórico de progresso: %d; esperado 2", len(listed[0].ProgressUpdates))
+		}
+
+		open, err := store.Goals().ListByDeveloper(developer.ID, []string{"open"})
+		if err != nil || len(open) != 1 || open[0].ID != goals[0].ID {
+			t.Fatalf("metas em aberto: %+v %v", open, err)
+		}
+
+		if err := store.Goals().Delete(goal.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.Goals().FindByID(goal.ID); err != repository.ErrNotFound {
+			t.Errorf("meta excluída: %v; esperado ErrNotFound", err)
+		}
+		if err := store.Goals().Delete(goal.ID); err != repository.ErrNotFound {
+			t.Errorf("excluir de novo: %v; esperado ErrNotFound", err)
+		}
+	})
+}
+
+func TestUpdateOneOnOneAndActionItems(t *testing.T) {
+	eachStore(t, func(t *testing.T, store repository.Store) {
+		company, user, developer := fixture(t, store)
+
+		meeting := models.OneOnOne{DeveloperID: developer.ID, CompanyID: &company.ID, Visibility: "private",
+			MeetingDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+			ActionItems: []models.OneOnOneActionItem{{Description: "Revisar o PR"}, {Description: "Agendar a retro"}}}
+		if err := store.OneOnOnes().Create(&meeting); err != nil {
+			t.Fatal(err)
+		}
+
+		meeting.Visibility = "shared"
+		meeting.MeetingDate = time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+		meeting.Agenda = models.Array[string]{"Carreira"}
+		if err := store.OneOnOnes().Update(&meeting); err != nil {
+			t.Fatal(err)
+		}
+		if meeting.Visibility != "shared" || meeting.MeetingDate.Format("2006-01-02") != "2025-01-07" || len(meeting.ActionItems) != 2 {
+			t.Fatalf("reunião atualizada: %+v", meeting)
+		}
+
+		completedAt := time.Now().UTC().Truncate(time.Second)
+		item := meeting.ActionItems[0]
+		item.CompletedAt, item.CompletedBy = &completedAt, &user.ID
+		if err := store.OneOnOnes().UpdateActionItem(&item); err != nil {
+			t.Fatal(err)
+		}
+		if item.CompletedAt == nil || item.CompletedBy == nil || *item.CompletedBy != user.ID {
+			t.Fatalf("item concluído: %+v", item)
+		}
+
+		if err := store.OneOnOnes().DeleteActionItem(meeting.ID, meeting.ActionItems[1].ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.OneOnOnes().DeleteActionItem(uuid.New(), item.ID); err != repository.ErrNotFound {
+			t.Errorf("item de outra reunião: %v; esperado ErrNotFound", err)
+		}
+
+		listed, err := store.OneOnOnes().List(repository.OneOnOneFilter{ID: &meeting.ID})
+		if err != nil || len(listed) != 1 {
+			t.Fatalf("reunião: %+v %v", listed, err)
+		}
+		if items := listed[0].ActionItems; len(items) != 1 || items[0].ID != item.ID || items[0].CompletedAt == nil {
+			t.Errorf("itens de ação: %+v", items)
+		}
+
+		if err := store.OneOnOnes().Delete(meeting.ID); err != nil {
+			t.Fatal(err)
+		}
+		if listed, _ := store.OneOnOnes().List(repository.OneOnOneFilter{ID: &meeting.ID}); len(listed) != 0 {
+			t.Errorf("reunião excluída ainda listada: %+v", listed)
+		}
+	})
+}
+
+func TestListOneOnOnes(t *testing.T) {
+	eachStore(t, func(t *testing.T, store repository.Store) {
+		company, user, developer := fixture(t, store)
+
+		meetings := []models.OneOnOne{
+			{MeetingDate: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), Visibility: "private"},
+			{MeetingDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), Visibility: "shared",
+				ActionItems: []models.OneOnOneActionItem{{Description: "Revisar o PR"}}},
+			{MeetingDate: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), Visibility: "shared"},
+		}
+		for i := range meetings {
+			meetings[i].DeveloperID = developer.ID
+			meetings[i].CompanyID = &company.ID
+			if err := store.OneOnOnes().Create(&meetings[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := store.OneOnOnes().CreateActionItem(&models.OneOnOneActionItem{OneOnOneID: meetings[1].ID, Description: "Agendar a retro"}); err != nil {
+			t.Fatal(err)
+		}
+
+		stranger := uuid.New()
+		cases := []struct {
+			name   string
+			filter repository.OneOnOneFilter
+			want   []uuid.UUID
+			items  []int
+		}{
+			{"todas, mais recentes primeiro", repository.OneOnOneFilter{DeveloperID: &developer.ID},
+				[]uuid.UUID{meetings[2].ID, meetings[0].ID, meetings[1].ID}, []int{0, 0, 2}},
+			{"mês, mais antigas primeiro", repository.OneOnOneFilter{DeveloperID: &developer.ID, Month: "2025-01"},
+				[]uuid.UUID{meetings[1].ID, meetings[0].ID}, []int{2, 0}},
+			{"compartilhadas com o desenvolvedor", repository.OneOnOneFilter{DeveloperID: &developer.ID, Month: "2025-01", SharedWith: &user.ID},
+				[]uuid.UUID{meetings[1].ID}, []int{2}},
+			{"outro usuário", repository.OneOnOneFilter{ID: &meetings[1].ID, SharedWith: &stranger}, nil, nil},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				listed, err := store.OneOnOnes().List(tc.filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(listed) != len(tc.want) {
+					t.Fatalf("%d reuniões; esperado %d", len(listed), len(tc.want))
+				}
+				for i, meeting := range listed {
+					if meeting.ID != tc.want[i] || len(meeting.ActionItems) != tc.items[i] {
+						t.Errorf("reunião %d: %s com %d itens; esperado %s com %d", i, meeting.ID, len(meeting.ActionItems), tc.want[i], tc.items[i])
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestCompanySettings(t *testing.T) {
+	eachStore(t, func(t *testing.T, store repository.Store) {
+		company, user, _ := fixture(t, store)
+
+		if _, err := store.Companies().Settings(company.ID); err != repository.ErrNotFound {
+			t.Fatalf("empresa sem configurações: %v; esperado ErrNotFound", err)
+		}
+
+		settings := models.CompanySettings{
+			CompanyID: company.ID, ScoreMin: 1, ScoreMax: 5, ScoreStep: 0.5, DecimalPlaces: 1, Locale: "en",
+			Timezone: "UTC", FiscalYearStartMonth: 4, TeamColorPalette: models.Array[string]{"blue"}, UpdatedBy: &user.ID,
+		}
+		if err := store.Companies().SaveSettings(&settings); err != nil {
+			t.Fatal(err)
+		}
+		settings.DecimalPlaces = 0
+		if err := store.Companies().SaveSettings(&settings); err != nil {
+			t.Fatal(err)
+		}
+
+		saved, err := store.Companies().Settings(company.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saved.ScoreMax != 5 || saved.DecimalPlaces != 0 || saved.FiscalYearStartMonth != 4 || saved.CreatedAt == nil {
+			t.Errorf("configurações: %+v", saved)
+		}
+	})
+}
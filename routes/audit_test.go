@@ -0,0 +1,76 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/models"
+)
+
+func TestAuditLogSnapshotsOmitPassword(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+
+	status, raw := doRequest(t, env, "PUT", "/api/v1/auth/users/"+alpha.UserID.String(), alpha.token("admin"), map[string]interface{}{"name": "Renamed User"})
+	if status != 200 {
+		t.Fatalf("atualizar usuário: %d %s", status, raw)
+	}
+
+	status, raw = doRequest(t, env, "GET", "/api/v1/audit-logs/?entityType=users&entityId="+alpha.UserID.String(), alpha.token("admin"), nil)
+	if status != 200 {
+		t.Fatalf("listar auditoria: %d %s", status, raw)
+	}
+	var body struct {
+		Data []models.AuditLog `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(raw), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Data) != 1 {
+		t.Fatalf("registros de auditoria: %+v; esperado 1", body.Data)
+	}
+
+	entry := body.Data[0]
+	if entry.Action != "update" || entry.ActorID == nil || *entry.ActorID != alpha.AdminID {
+		t.Errorf("registro de auditoria: %+v", entry)
+	}
+	if entry.Before["name"] != "user alpha" || entry.After["name"] != "Renamed User" {
+		t.Errorf("snapshots sem a alteração do nome: antes %v, depois %v", entry.Before, entry.After)
+	}
+	for label, snapshot := range map[string]models.JSONB{"antes": entry.Before, "depois": entry.After} {
+		if _, ok := snapshot["password"]; ok {
+			t.Errorf("snapshot %s expõe o hash da senha: %v", label, snapshot)
+		}
+	}
+}
+
+func TestAuditLogIsAppendOnly(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+
+	// O banco de sistema ignora o RLS, mas não o trigger
+	var id uuid.UUID
+	if err := env.DB.Get(&id, `
+		INSERT INTO audit_logs (actor_id, company_id, action, entity_type, entity_id)
+		VALUES ($1, $2, 'update', 'developers', $3) RETURNING id
+	`, alpha.ManagerID, alpha.CompanyID, alpha.DevID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.DB.Exec("UPDATE audit_logs SET action = 'delete' WHERE id = $1", id); err == nil {
+		t.Error("UPDATE em audit_logs foi aceito")
+	}
+	if _, err := env.DB.Exec("DELETE FROM audit_logs WHERE id = $1", id); err == nil {
+		t.Error("DELETE em audit_logs foi aceito")
+	}
+
+	var action string
+	if err := env.DB.Get(&action, "SELECT action FROM audit_logs WHERE id = $1", id); err != nil {
+		t.Fatalf("registro de auditoria removido: %v", err)
+	}
+	if action != "update" {
+		t.Errorf("ação %q; esperado update", action)
+	}
+}
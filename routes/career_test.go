@@ -0,0 +1,151 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/models"
+)
+
+func TestCareerAnalyticsUsesCompanyPrecision(t *testing.T) {
+	env := setupIntegration(t)
+	alpha, beta := env.Tenants[0], env.Tenants[1]
+
+	for _, tenant := range env.Tenants {
+		if _, err := env.DB.Exec("UPDATE performance_reports SET weighted_average_score = 7.46 WHERE id = $1", tenant.ReportID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := env.DB.Exec("UPDATE company_settings SET decimal_places = 1 WHERE company_id = $1", alpha.CompanyID); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		tenant *tenantFixture
+		want   float64
+	}{
+		{"precisão da empresa", alpha, 7.5},
+		{"precisão padrão", beta, 7.46},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/career/analytics", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.tenant.token("manager"))
+
+			resp, err := env.App.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			var body struct {
+				Data struct {
+					Levels []models.CareerLevelStats `json:"levels"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != 200 || len(body.Data.Levels) != 1 {
+				t.Fatalf("status %d, níveis %+v", resp.StatusCode, body.Data.Levels)
+			}
+			level := body.Data.Levels[0]
+			if level.AverageScore != tc.want || level.MinScore != tc.want || level.MaxScore != tc.want {
+				t.Errorf("notas do nível %+v; esperado %v", level, tc.want)
+			}
+		})
+	}
+}
+
+func TestCareerAnalyticsKeepsSameNamedTracksApart(t *testing.T) {
+	env := setupIntegration(t)
+
+	// As duas empresas têm uma trilha "Engineering"; com o segundo nível, a ordenação só por
+	// nome e rank intercalaria os níveis das duas trilhas
+	for _, tenant := range env.Tenants {
+		if _, err := env.DB.Exec("INSERT INTO career_levels (track_id, company_id, code, name, rank) VALUES ($1, $2, 'L2', 'Pleno', 2)",
+			tenant.TrackID, tenant.CompanyID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	status, raw := doRequest(t, env, "GET", "/api/v1/career/analytics", env.Tenants[0].token("admin"), nil)
+	if status != 200 {
+		t.Fatalf("status %d: %s", status, raw)
+	}
+
+	var body struct {
+		Data struct {
+			Levels []models.CareerLevelStats `json:"levels"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(raw), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	levels := body.Data.Levels
+	if len(levels) != 4 {
+		t.Fatalf("níveis: %+v; esperado 4", levels)
+	}
+	for i := 0; i < len(levels); i += 2 {
+		if levels[i].TrackID != levels[i+1].TrackID || levels[i].Rank != 1 || levels[i+1].Rank != 2 {
+			t.Fatalf("níveis intercalados: %+v", levels)
+		}
+	}
+}
+
+func TestChangeDeveloperLevelRejectsFutureDate(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+
+	var seniorID uuid.UUID
+	if err := env.DB.Get(&seniorID, "INSERT INTO career_levels (track_id, company_id, code, name, rank) VALUES ($1, $2, 'L2', 'Pleno', 2) RETURNING id",
+		alpha.TrackID, alpha.CompanyID); err != nil {
+		t.Fatal(err)
+	}
+
+	target := "/api/v1/developers/" + alpha.DevID.String() + "/level"
+	body := func(date time.Time) map[string]interface{} {
+		return map[string]interface{}{
+			"levelId":       seniorID,
+			"effectiveDate": date.Format("2006-01-02"),
+			"justification": "Assumiu a liderança técnica do time",
+		}
+	}
+
+	// Dois dias à frente continuam no futuro em qualquer fuso horário
+	status, raw := doRequest(t, env, "POST", target, alpha.token("manager"), body(time.Now().AddDate(0, 0, 2)))
+	if status != 400 || !strings.Contains(raw, "LEVEL_CHANGE_IN_FUTURE") {
+		t.Fatalf("data futura: %d %s; esperado 400 LEVEL_CHANGE_IN_FUTURE", status, raw)
+	}
+
+	var levelID uuid.UUID
+	var changes int
+	if err := env.DB.Get(&levelID, "SELECT level_id FROM developers WHERE id = $1", alpha.DevID); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.DB.Get(&changes, "SELECT COUNT(*) FROM developer_level_changes WHERE developer_id = $1", alpha.DevID); err != nil {
+		t.Fatal(err)
+	}
+	if levelID != alpha.LevelID || changes != 0 {
+		t.Fatalf("mudança futura aplicada: nível %s, %d registros no histórico", levelID, changes)
+	}
+
+	status, raw = doRequest(t, env, "POST", target, alpha.token("manager"), body(time.Now().AddDate(0, 0, -2)))
+	if status != 201 {
+		t.Fatalf("data passada: %d %s; esperado 201", status, raw)
+	}
+	if err := env.DB.Get(&levelID, "SELECT level_id FROM developers WHERE id = $1", alpha.DevID); err != nil {
+		t.Fatal(err)
+	}
+	if levelID != seniorID {
+		t.Errorf("nível %s; esperado %s", levelID, seniorID)
+	}
+}
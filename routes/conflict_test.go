@@ -0,0 +1,74 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"tivix-performance-tracker-backend/apierror"
+)
+
+// TestUniqueViolationConflict verifica que registros duplicados respondem 409 com o código do
+// recurso, em qualquer banco (a detecção usa o código de erro do driver)
+func TestUniqueViolationConflict(t *testing.T) {
+	env := setupIntegration(t)
+	tenant := env.Tenants[0]
+	token := tenant.token("manager")
+
+	var rustID string
+	if err := env.DB.Get(&rustID, "INSERT INTO skills (company_id, name) VALUES ($1, 'Rust') RETURNING id", tenant.CompanyID); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   map[string]interface{}
+		code   string
+	}{
+		{"trilha com nome existente", http.MethodPost, "/api/v1/career/tracks",
+			map[string]interface{}{"name": "Engineering"}, "CAREER_TRACK_ALREADY_EXISTS"},
+		{"nível com código existente", http.MethodPost, fmt.Sprintf("/api/v1/career/tracks/%s/levels", tenant.TrackID),
+			map[string]interface{}{"code": "L1", "name": "Junior II", "rank": 2}, "CAREER_LEVEL_ALREADY_EXISTS"},
+		{"competência com nome existente", http.MethodPost, "/api/v1/skills",
+			map[string]interface{}{"name": "go"}, "SKILL_ALREADY_EXISTS"},
+		{"competência renomeada para nome existente", http.MethodPut, "/api/v1/skills/" + rustID,
+			map[string]interface{}{"name": "Go"}, "SKILL_ALREADY_EXISTS"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := doRequest(t, env, tc.method, tc.path, token, tc.body)
+
+			var resp apierror.Response
+			if err := json.Unmarshal([]byte(body), &resp); err != nil {
+				t.Fatalf("resposta inválida: %v\n%s", err, body)
+			}
+			if status != http.StatusConflict || resp.Code != tc.code {
+				t.Errorf("obtido %d %s; esperado 409 %s\n%s", status, resp.Code, tc.code, body)
+			}
+		})
+	}
+}
+
+// TestSkillNamesUniquePerCompany verifica que o nome da competência só conflita dentro da empresa
+func TestSkillNamesUniquePerCompany(t *testing.T) {
+	env := setupIntegration(t)
+	alpha, beta := env.Tenants[0], env.Tenants[1]
+
+	if _, err := env.DB.Exec("INSERT INTO skills (company_id, name) VALUES ($1, 'Rust')", alpha.CompanyID); err != nil {
+		t.Fatal(err)
+	}
+
+	status, body := doRequest(t, env, http.MethodPost, "/api/v1/skills", beta.token("manager"), map[string]interface{}{"name": "Rust"})
+	if status != http.StatusCreated {
+		t.Fatalf("competência com nome de outra empresa: %d %s; esperado 201", status, body)
+	}
+
+	status, body = doRequest(t, env, http.MethodPost, "/api/v1/skills", alpha.token("manager"), map[string]interface{}{"name": "RUST"})
+	if status != http.StatusConflict {
+		t.Errorf("competência repetida na empresa: %d %s; esperado 409", status, body)
+	}
+}
@@ -0,0 +1,136 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/models"
+)
+
+func TestGoalProgressAndStatusTransitions(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+	target := "/api/v1/goals/" + alpha.GoalID.String() + "/progress"
+
+	steps := []struct {
+		name      string
+		body      map[string]interface{}
+		status    string
+		completed bool
+	}{
+		{"progresso parcial", map[string]interface{}{"progress": 50}, "in_progress", false},
+		{"progresso total conclui", map[string]interface{}{"progress": 100, "note": "Entregue"}, "completed", true},
+		{"reabertura", map[string]interface{}{"progress": 80, "status": "in_progress"}, "in_progress", false},
+		{"cancelamento", map[string]interface{}{"progress": 80, "status": "cancelled"}, "cancelled", true},
+	}
+
+	for _, step := range steps {
+		status, raw := doRequest(t, env, "POST", target, alpha.token("manager"), step.body)
+		if status != 201 {
+			t.Fatalf("%s: %d %s", step.name, status, raw)
+		}
+
+		var body struct {
+			Data struct {
+				Goal   models.Goal               `json:"goal"`
+				Update models.GoalProgressUpdate `json:"update"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(raw), &body); err != nil {
+			t.Fatal(err)
+		}
+		goal := body.Data.Goal
+		if goal.Status != step.status || body.Data.Update.Status != step.status {
+			t.Errorf("%s: status da meta %q, da atualização %q; esperado %q", step.name, goal.Status, body.Data.Update.Status, step.status)
+		}
+		if goal.Progress != step.body["progress"] {
+			t.Errorf("%s: progresso %d; esperado %v", step.name, goal.Progress, step.body["progress"])
+		}
+		if (goal.CompletedAt != nil) != step.completed {
+			t.Errorf("%s: completedAt %v", step.name, goal.CompletedAt)
+		}
+	}
+
+	status, raw := doRequest(t, env, "POST", target, alpha.token("manager"), map[string]interface{}{"progress": 150})
+	if status != 400 {
+		t.Errorf("progresso acima de 100: %d %s; esperado 400", status, raw)
+	}
+	status, raw = doRequest(t, env, "POST", target, alpha.token("user"), map[string]interface{}{"progress": 90})
+	if status != 403 {
+		t.Errorf("progresso registrado por usuário comum: %d %s; esperado 403", status, raw)
+	}
+
+	status, raw = doRequest(t, env, "GET", "/api/v1/goals/"+alpha.GoalID.String(), alpha.token("manager"), nil)
+	if status != 200 {
+		t.Fatalf("buscar meta: %d %s", status, raw)
+	}
+	var body struct {
+		Data models.Goal `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(raw), &body); err != nil {
+		t.Fatal(err)
+	}
+	// A atualização semeada e as quatro registradas acima
+	if n := len(body.Data.ProgressUpdates); n != len(steps)+1 {
+		t.Errorf("%d atualizações no histórico; esperado %d", n, len(steps)+1)
+	}
+}
+
+func TestOpenGoalsCarriedIntoNextReport(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := env.DB.Exec(query, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A meta semeada segue em aberto; a segunda foi concluída antes do relatório seguinte
+	// e a terceira é criada junto com ele
+	exec("UPDATE goals SET created_at = '2025-01-10 12:00:00' WHERE id = $1", alpha.GoalID)
+	var doneID, nextID, newGoalID uuid.UUID
+	if err := env.DB.Get(&doneID, `
+		INSERT INTO goals (developer_id, company_id, report_id, title, status, progress, created_at, completed_at)
+		VALUES ($1, $2, $3, 'Finished early', 'completed', 100, '2025-01-10 12:00:00', '2025-01-20 12:00:00') RETURNING id
+	`, alpha.DevID, alpha.CompanyID, alpha.ReportID); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.DB.Get(&nextID, `
+		INSERT INTO performance_reports (developer_id, month, question_scores, category_scores, weighted_average_score, team_id, created_at)
+		VALUES ($1, '2025-02', '{}', '{}', 8, $2, '2025-02-01 12:00:00') RETURNING id
+	`, alpha.DevID, alpha.TeamID); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.DB.Get(&newGoalID, `
+		INSERT INTO goals (developer_id, company_id, report_id, title, created_at)
+		VALUES ($1, $2, $3, 'Next month', '2025-02-01 12:00:00') RETURNING id
+	`, alpha.DevID, alpha.CompanyID, nextID); err != nil {
+		t.Fatal(err)
+	}
+
+	status, raw := doRequest(t, env, "GET", "/api/v1/performance-reports/"+nextID.String(), alpha.token("manager"), nil)
+	if status != 200 {
+		t.Fatalf("buscar relatório: %d %s", status, raw)
+	}
+
+	var body struct {
+		Goals     []models.Goal `json:"goals"`
+		OpenGoals []models.Goal `json:"openGoals"`
+	}
+	if err := json.Unmarshal([]byte(raw), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Goals) != 1 || body.Goals[0].ID != newGoalID {
+		t.Errorf("metas do relatório: %+v; esperado apenas %s", body.Goals, newGoalID)
+	}
+	if len(body.OpenGoals) != 1 || body.OpenGoals[0].ID != alpha.GoalID {
+		t.Fatalf("metas em aberto: %+v; esperado apenas %s", body.OpenGoals, alpha.GoalID)
+	}
+	if len(body.OpenGoals[0].ProgressUpdates) != 1 {
+		t.Errorf("meta em aberto sem o histórico de progresso: %+v", body.OpenGoals[0])
+	}
+}
@@ -0,0 +1,61 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/apierror"
+)
+
+func TestErrorMessageLocale(t *testing.T) {
+	env := setupIntegration(t)
+	alpha, beta := env.Tenants[0], env.Tenants[1]
+
+	if _, err := env.DB.Exec("UPDATE company_settings SET locale = 'es' WHERE company_id = $1", alpha.CompanyID); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name           string
+		tenant         *tenantFixture
+		acceptLanguage string
+		locale         string
+		message        string
+	}{
+		{"idioma padrão", beta, "", "pt-BR", "Time não encontrado ou acesso negado"},
+		{"Accept-Language", beta, "en-US,en;q=0.9", "en", "Team not found or access denied"},
+		{"idioma da empresa", alpha, "", "es", "Equipo no encontrado o acceso denegado"},
+		{"Accept-Language antes da empresa", alpha, "fr-FR, pt-BR;q=0.8", "pt-BR", "Time não encontrado ou acesso negado"},
+		{"idioma sem catálogo", alpha, "fr-FR", "es", "Equipo no encontrado o acceso denegado"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/teams/"+uuid.NewString(), nil)
+			req.Header.Set("Authorization", "Bearer "+tc.tenant.token("manager"))
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+
+			resp, err := env.App.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			var body apierror.Response
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Code != "TEAM_NOT_FOUND" || body.Message != tc.message {
+				t.Errorf("obtido %s %q; esperado TEAM_NOT_FOUND %q", body.Code, body.Message, tc.message)
+			}
+			if got := resp.Header.Get("Content-Language"); got != tc.locale {
+				t.Errorf("Content-Language %q; esperado %q", got, tc.locale)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/models"
+)
+
+func TestPrivateOneOnOnesHiddenFromDeveloper(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+	listTarget := "/api/v1/developers/" + alpha.DevID.String() + "/one-on-ones"
+
+	// Sem visibilidade explícita a reunião é privada
+	status, raw := doRequest(t, env, "POST", listTarget, alpha.token("manager"), map[string]interface{}{
+		"meetingDate": "2025-01-22",
+		"agenda":      []string{"Feedback do líder"},
+		"notes":       "Anotações só da liderança",
+	})
+	if status != 201 {
+		t.Fatalf("criar reunião: %d %s", status, raw)
+	}
+	var created struct {
+		Data models.OneOnOne `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(raw), &created); err != nil {
+		t.Fatal(err)
+	}
+	privateID := created.Data.ID
+	if created.Data.Visibility != "private" {
+		t.Fatalf("visibilidade %q; esperado private", created.Data.Visibility)
+	}
+
+	list := func(role string) []uuid.UUID {
+		t.Helper()
+		status, raw := doRequest(t, env, "GET", listTarget, alpha.token(role), nil)
+		if status != 200 {
+			t.Fatalf("listar como %s: %d %s", role, status, raw)
+		}
+		var body struct {
+			Data []models.OneOnOne `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(raw), &body); err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]uuid.UUID, len(body.Data))
+		for i, meeting := range body.Data {
+			ids[i] = meeting.ID
+		}
+		return ids
+	}
+
+	if ids := list("user"); len(ids) != 1 || ids[0] != alpha.MeetingID {
+		t.Errorf("reuniões visíveis ao desenvolvedor: %v; esperado apenas %s", ids, alpha.MeetingID)
+	}
+	if ids := list("manager"); len(ids) != 2 {
+		t.Errorf("reuniões visíveis ao gestor: %v; esperado 2", ids)
+	}
+
+	status, raw = doRequest(t, env, "GET", "/api/v1/one-on-ones/"+privateID.String(), alpha.token("user"), nil)
+	if status != 404 || !strings.Contains(raw, "MEETING_NOT_FOUND") {
+		t.Errorf("reunião privada por id como desenvolvedor: %d %s; esperado 404 MEETING_NOT_FOUND", status, raw)
+	}
+	status, raw = doRequest(t, env, "GET", "/api/v1/one-on-ones/"+privateID.String(), alpha.token("manager"), nil)
+	if status != 200 {
+		t.Errorf("reunião privada por id como gestor: %d %s", status, raw)
+	}
+}
+
+func TestActionItemCompletion(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+	target := "/api/v1/one-on-ones/" + alpha.MeetingID.String() + "/action-items/" + alpha.ItemID.String()
+
+	update := func(role string, body map[string]interface{}) models.OneOnOneActionItem {
+		t.Helper()
+		status, raw := doRequest(t, env, "PUT", target, alpha.token(role), body)
+		if status != 200 {
+			t.Fatalf("atualizar item como %s com %v: %d %s", role, body, status, raw)
+		}
+		var resp struct {
+			Data models.OneOnOneActionItem `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Data
+	}
+
+	// O responsável pelo item pode concluí-lo, mas não editar os demais campos
+	item := update("user", map[string]interface{}{"completed": true})
+	if item.CompletedAt == nil || item.CompletedBy == nil || *item.CompletedBy != alpha.UserID {
+		t.Fatalf("item concluído pelo responsável: %+v", item)
+	}
+	completedAt := *item.CompletedAt
+
+	status, raw := doRequest(t, env, "PUT", target, alpha.token("user"), map[string]interface{}{"description": "Outra coisa"})
+	if status != 403 || !strings.Contains(raw, "ACTION_ITEM_OWNER_REQUIRED") {
+		t.Errorf("descrição editada pelo responsável: %d %s; esperado 403 ACTION_ITEM_OWNER_REQUIRED", status, raw)
+	}
+
+	// Concluir de novo mantém a primeira conclusão
+	item = update("manager", map[string]interface{}{"completed": true})
+	if item.CompletedBy == nil || *item.CompletedBy != alpha.UserID || item.CompletedAt == nil || !item.CompletedAt.Equal(completedAt) {
+		t.Errorf("nova conclusão alterou o registro: %+v", item)
+	}
+
+	item = update("manager", map[string]interface{}{"completed": false})
+	if item.CompletedAt != nil || item.CompletedBy != nil {
+		t.Errorf("item reaberto continua concluído: %+v", item)
+	}
+
+	// Usuários que não são responsáveis pelo item não podem concluí-lo
+	if _, err := env.DB.Exec("UPDATE one_on_one_action_items SET owner_id = $1 WHERE id = $2", alpha.ManagerID, alpha.ItemID); err != nil {
+		t.Fatal(err)
+	}
+	status, raw = doRequest(t, env, "PUT", target, alpha.token("user"), map[string]interface{}{"completed": true})
+	if status != 403 {
+		t.Errorf("item de outro responsável concluído: %d %s; esperado 403", status, raw)
+	}
+}
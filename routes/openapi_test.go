@@ -0,0 +1,41 @@
+package routes_test
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/openapi"
+	"tivix-performance-tracker-backend/routes"
+)
+
+// TestOpenAPICoversRoutes falha quando uma rota de SetupRoutes não está descrita em
+// openapi/operations.go, ou quando o documento descreve uma rota que não existe mais
+func TestOpenAPICoversRoutes(t *testing.T) {
+	app := fiber.New()
+	routes.SetupRoutes(app)
+
+	documented := map[string]bool{}
+	for _, operation := range openapi.Build().Operations() {
+		documented[operation] = true
+	}
+
+	registered := map[string]bool{}
+	for _, route := range app.GetRoutes(true) {
+		// O Fiber registra HEAD junto com cada GET
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+		operation := route.Method + " " + openapi.Path(route.Path)
+		registered[operation] = true
+		if !documented[operation] {
+			t.Errorf("rota %s não está no documento OpenAPI", operation)
+		}
+	}
+
+	for operation := range documented {
+		if !registered[operation] {
+			t.Errorf("o documento OpenAPI descreve %s, que não está registrada", operation)
+		}
+	}
+}
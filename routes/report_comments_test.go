@@ -0,0 +1,130 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/models"
+)
+
+func TestReportCommentThreadsAndAuthorship(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+	target := "/api/v1/performance-reports/" + alpha.ReportID.String() + "/comments"
+
+	// O desenvolvedor responde ao comentário semeado pelo gestor
+	status, raw := doRequest(t, env, "POST", target, alpha.token("user"), map[string]interface{}{
+		"body":     "Obrigado pelo retorno",
+		"parentId": alpha.CommentID,
+		"mentions": []uuid.UUID{alpha.ManagerID},
+	})
+	if status != 201 {
+		t.Fatalf("responder comentário: %d %s", status, raw)
+	}
+	var created struct {
+		Data models.ReportComment `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(raw), &created); err != nil {
+		t.Fatal(err)
+	}
+	reply := created.Data
+	if reply.ParentID == nil || *reply.ParentID != alpha.CommentID || len(reply.Mentions) != 1 || reply.Mentions[0] != alpha.ManagerID {
+		t.Fatalf("resposta criada: %+v", reply)
+	}
+
+	status, raw = doRequest(t, env, "POST", target, alpha.token("manager"), map[string]interface{}{
+		"body":     "Resposta à resposta",
+		"parentId": reply.ID,
+	})
+	if status != 400 || !strings.Contains(raw, "INVALID_PARENT_COMMENT") {
+		t.Errorf("resposta a uma resposta: %d %s; esperado 400 INVALID_PARENT_COMMENT", status, raw)
+	}
+
+	// Apenas o autor edita o comentário, mesmo que seja admin quem tente
+	commentTarget := target + "/" + alpha.CommentID.String()
+	for _, role := range []string{"user", "admin"} {
+		status, raw = doRequest(t, env, "PUT", commentTarget, alpha.token(role), map[string]interface{}{"body": "Editado"})
+		if status != 403 || !strings.Contains(raw, "COMMENT_AUTHOR_REQUIRED") {
+			t.Errorf("edição como %s: %d %s; esperado 403 COMMENT_AUTHOR_REQUIRED", role, status, raw)
+		}
+	}
+	status, raw = doRequest(t, env, "PUT", commentTarget, alpha.token("manager"), map[string]interface{}{"body": "Ótimo mês"})
+	if status != 200 {
+		t.Fatalf("edição pelo autor: %d %s", status, raw)
+	}
+	var updated struct {
+		Data models.ReportComment `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(raw), &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Data.Body != "Ótimo mês" || updated.Data.EditedAt == nil {
+		t.Errorf("comentário editado: %+v", updated.Data)
+	}
+
+	// A thread devolve a resposta dentro do comentário original
+	status, raw = doRequest(t, env, "GET", target, alpha.token("user"), nil)
+	if status != 200 {
+		t.Fatalf("listar comentários: %d %s", status, raw)
+	}
+	var thread struct {
+		Data []models.ReportComment `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(raw), &thread); err != nil {
+		t.Fatal(err)
+	}
+	if len(thread.Data) != 1 || len(thread.Data[0].Replies) != 1 || thread.Data[0].Replies[0].ID != reply.ID {
+		t.Errorf("thread: %+v", thread.Data)
+	}
+}
+
+func TestUnreadReportComments(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+	target := "/api/v1/performance-reports/" + alpha.ReportID.String() + "/comments"
+
+	status, raw := doRequest(t, env, "POST", target, alpha.token("user"), map[string]interface{}{
+		"body":     "Podemos conversar sobre a meta?",
+		"mentions": []uuid.UUID{alpha.ManagerID},
+	})
+	if status != 201 {
+		t.Fatalf("criar comentário: %d %s", status, raw)
+	}
+
+	unread := func(role string) []models.ReportCommentUnread {
+		t.Helper()
+		status, raw := doRequest(t, env, "GET", "/api/v1/performance-reports/comments/unread", alpha.token(role), nil)
+		if status != 200 {
+			t.Fatalf("não lidos como %s: %d %s", role, status, raw)
+		}
+		var body struct {
+			Data []models.ReportCommentUnread `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(raw), &body); err != nil {
+			t.Fatal(err)
+		}
+		return body.Data
+	}
+
+	// Comentários do próprio usuário não contam; as menções são contadas à parte
+	if got := unread("manager"); len(got) != 1 || got[0].ReportID != alpha.ReportID || got[0].UnreadCount != 1 || got[0].MentionCount != 1 {
+		t.Errorf("não lidos do gestor: %+v; esperado 1 comentário com 1 menção", got)
+	}
+	if got := unread("user"); len(got) != 1 || got[0].UnreadCount != 1 || got[0].MentionCount != 0 {
+		t.Errorf("não lidos do desenvolvedor: %+v; esperado 1 comentário sem menção", got)
+	}
+
+	status, raw = doRequest(t, env, "POST", target+"/read", alpha.token("manager"), nil)
+	if status != 200 {
+		t.Fatalf("marcar como lidos: %d %s", status, raw)
+	}
+	if got := unread("manager"); len(got) != 0 {
+		t.Errorf("não lidos após marcar como lidos: %+v", got)
+	}
+	if got := unread("user"); len(got) != 1 {
+		t.Errorf("leitura do gestor alterou os não lidos do desenvolvedor: %+v", got)
+	}
+}
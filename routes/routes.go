@@ -4,29 +4,36 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"tivix-performance-tracker-backend/handlers"
 	"tivix-performance-tracker-backend/middleware"
+	"tivix-performance-tracker-backend/openapi"
 )
 
 func SetupRoutes(app *fiber.App) {
 	// Grupo principal da API
 	api := app.Group("/api/v1")
 
-	// Rotas públicas de autenticação
+	// Rotas públicas de autenticação - em contexto de sistema (o usuário ainda não tem empresa definida)
 	auth := api.Group("/auth")
-	auth.Post("/login", handlers.Login)
+	auth.Post("/login", middleware.SystemScopeMiddleware(), handlers.Login)
 
-	// Rotas de inicialização do sistema
-	init := api.Group("/init")
+	// Documentação da API (OpenAPI 3.1 e Swagger UI) - públicas
+	api.Get("/openapi.json", openapi.Handler)
+	api.Get("/docs", openapi.Docs)
+	api.Get("/docs/:file", openapi.Asset)
+
+	// Rotas de inicialização do sistema - em contexto de sistema
+	init := api.Group("/init", middleware.SystemScopeMiddleware())
 	init.Get("/check", handlers.CheckInitialization)
 	init.Post("/admin", handlers.CreateAdminUser)
 
-	// Rotas protegidas de autenticação - requerem token válido
-	authProtected := api.Group("/auth", middleware.AuthMiddleware())
+	// Rotas protegidas de autenticação - requerem token válido e executam no contexto de RLS
+	// da empresa do usuário (o próprio cadastro é sempre visível)
+	authProtected := api.Group("/auth", middleware.AuthMiddleware(), middleware.TenantScopeMiddleware())
 	authProtected.Get("/profile", handlers.GetProfile)
 	authProtected.Post("/refresh", handlers.RefreshToken)
 	authProtected.Post("/set-new-password", handlers.SetNewPassword)
 	authProtected.Post("/change-password", handlers.ChangePassword)
 
-	// Rotas admin e manager - para gerenciamento de usuários e empresas
+	// Rotas admin e manager - para gerenciamento de usuários, restritas pelo RLS à empresa do gestor
 	adminAndManagerAuth := authProtected.Group("/", middleware.ManagerOrAdminMiddleware())
 	adminAndManagerAuth.Post("/create-user", handlers.CreateUser)
 	adminAndManagerAuth.Get("/users", handlers.ListUsers)
@@ -34,22 +41,35 @@ func SetupRoutes(app *fiber.App) {
 	adminAndManagerAuth.Delete("/users/:id", handlers.DeleteUser)
 	
 	// Rota para listar empresas - gerentes e admins podem acessar
+	// (o contexto de RLS fica na rota, para não se somar ao contexto de sistema das rotas abaixo)
 	companiesListAuth := api.Group("/companies", middleware.AuthMiddleware(), middleware.ManagerOrAdminMiddleware())
-	companiesListAuth.Get("/", handlers.GetAllCompanies)
+	companiesListAuth.Get("/", middleware.TenantScopeMiddleware(), handlers.GetAllCompanies)
 	
-	// Rotas admin apenas - para gerenciamento de empresas (diretamente no API, não no auth)
-	companiesAdminAuth := api.Group("/companies", middleware.AuthMiddleware(), middleware.AdminOnlyMiddleware())
+	// Rotas admin apenas - para gerenciamento de empresas (diretamente no API, não no auth), em contexto de sistema
+	companiesAdminAuth := api.Group("/companies", middleware.AuthMiddleware(), middleware.AdminOnlyMiddleware(), middleware.SystemScopeMiddleware())
 	companiesAdminAuth.Post("/", handlers.CreateCompany)
 	companiesAdminAuth.Get("/:id", handlers.GetCompanyByID)
 	companiesAdminAuth.Put("/:id", handlers.UpdateCompany)
 	companiesAdminAuth.Delete("/:id", handlers.DeleteCompany)
 
+	// Rotas de configurações da empresa - leitura para todos os usuários da empresa, edição por gestores e admins
+	settings := api.Group("/company-settings", middleware.AuthMiddleware(), middleware.CheckPasswordChangeMiddleware(), middleware.CompanyAccessMiddleware(), middleware.TenantScopeMiddleware())
+	settings.Get("/", handlers.GetCompanySettings)
+	settings.Put("/", middleware.ManagerOrAdminMiddleware(), handlers.UpdateCompanySettings)
+
+	// Rotas de log de auditoria - admin apenas, em contexto de sistema
+	audit := api.Group("/audit-logs", middleware.AuthMiddleware(), middleware.AdminOnlyMiddleware(), middleware.SystemScopeMiddleware())
+	audit.Get("/", handlers.GetAuditLogs)
+	audit.Get("/export", handlers.ExportAuditLogs)
+
 	// Middleware para todas as rotas protegidas - verifica se precisa trocar senha e empresa
-	protectedWithPasswordCheck := api.Group("/", middleware.AuthMiddleware(), middleware.CheckPasswordChangeMiddleware(), middleware.CompanyAccessMiddleware())
+	// e executa a requisição em uma transação com o contexto de RLS da empresa
+	protectedWithPasswordCheck := api.Group("/", middleware.AuthMiddleware(), middleware.CheckPasswordChangeMiddleware(), middleware.CompanyAccessMiddleware(), middleware.TenantScopeMiddleware())
 
 	// Rotas de times - protegidas
 	teams := protectedWithPasswordCheck.Group("/teams")
 	teams.Get("/", handlers.GetAllTeams)
+	teams.Get("/tree", handlers.GetTeamTree)
 	teams.Get("/:id", handlers.GetTeamByID)
 	teams.Post("/", middleware.ManagerOrAdminMiddleware(), handlers.CreateTeam)
 	teams.Put("/:id", middleware.ManagerOrAdminMiddleware(), handlers.UpdateTeam)
@@ -68,6 +88,43 @@ func SetupRoutes(app *fiber.App) {
 	// Rotas de desenvolvedores por time - protegidas
 	teams.Get("/:teamId/developers", handlers.GetDevelopersByTeam)
 
+	// Rotas de histórico de times - protegidas
+	teams.Get("/:teamId/roster", handlers.GetTeamRoster)
+
+	// Rotas de hierarquia de times - protegidas
+	teams.Get("/:teamId/tree", handlers.GetTeamSubtree)
+	teams.Get("/:teamId/stats", handlers.GetTeamStats)
+	teams.Put("/:teamId/move", middleware.ManagerOrAdminMiddleware(), handlers.MoveTeam)
+
+	// Rotas de trilha de carreira - protegidas
+	career := protectedWithPasswordCheck.Group("/career")
+	career.Get("/tracks", handlers.GetCareerTracks)
+	career.Get("/tracks/:id", handlers.GetCareerTrackByID)
+	career.Post("/tracks", middleware.ManagerOrAdminMiddleware(), handlers.CreateCareerTrack)
+	career.Put("/tracks/:id", middleware.ManagerOrAdminMiddleware(), handlers.UpdateCareerTrack)
+	career.Delete("/tracks/:id", middleware.ManagerOrAdminMiddleware(), handlers.DeleteCareerTrack)
+	career.Post("/tracks/:id/levels", middleware.ManagerOrAdminMiddleware(), handlers.CreateCareerLevel)
+	career.Put("/levels/:levelId", middleware.ManagerOrAdminMiddleware(), handlers.UpdateCareerLevel)
+	career.Delete("/levels/:levelId", middleware.ManagerOrAdminMiddleware(), handlers.DeleteCareerLevel)
+	career.Get("/analytics", middleware.ManagerOrAdminMiddleware(), handlers.GetCareerAnalytics)
+
+	developers.Get("/:developerId/level-history", handlers.GetDeveloperLevelHistory)
+	developers.Post("/:developerId/level", middleware.ManagerOrAdminMiddleware(), handlers.ChangeDeveloperLevel)
+
+	// Rotas de matriz de competências - protegidas
+	skills := protectedWithPasswordCheck.Group("/skills")
+	skills.Get("/", handlers.GetSkills)
+	skills.Get("/search", handlers.SearchDevelopersBySkill)
+	skills.Post("/", middleware.ManagerOrAdminMiddleware(), handlers.CreateSkill)
+	skills.Put("/:id", middleware.ManagerOrAdminMiddleware(), handlers.UpdateSkill)
+	skills.Delete("/:id", middleware.ManagerOrAdminMiddleware(), handlers.DeleteSkill)
+
+	developers.Get("/:developerId/skills", handlers.GetDeveloperSkills)
+	developers.Post("/:developerId/skills", handlers.CreateSkillAssessment)
+	developers.Get("/:developerId/skills/:skillId/history", handlers.GetSkillAssessmentHistory)
+	teams.Get("/:teamId/skills", handlers.GetTeamSkillCoverage)
+	developers.Get("/:developerId/team-history", handlers.GetDeveloperTeamHistory)
+
 	// Rotas de relatórios de performance - protegidas
 	reports := protectedWithPasswordCheck.Group("/performance-reports")
 	reports.Get("/", handlers.GetAllPerformanceReports)
@@ -81,4 +138,34 @@ func SetupRoutes(app *fiber.App) {
 
 	// Rotas de relatórios por mês - protegidas
 	reports.Get("/month/:month", handlers.GetPerformanceReportsByMonth)
+
+	// Rotas de comentários em relatórios - protegidas (gestores, admins ou o desenvolvedor vinculado)
+	reports.Get("/comments/unread", handlers.GetUnreadReportComments)
+	reports.Get("/:id/comments", handlers.GetReportComments)
+	reports.Post("/:id/comments", handlers.CreateReportComment)
+	reports.Post("/:id/comments/read", handlers.MarkReportCommentsRead)
+	reports.Put("/:id/comments/:commentId", handlers.UpdateReportComment)
+	reports.Delete("/:id/comments/:commentId", handlers.DeleteReportComment)
+
+	// Rotas de metas (planos de desenvolvimento individual) - protegidas
+	developers.Get("/:developerId/goals", handlers.GetGoalsByDeveloper)
+	developers.Post("/:developerId/goals", middleware.ManagerOrAdminMiddleware(), handlers.CreateGoal)
+
+	goals := protectedWithPasswordCheck.Group("/goals")
+	goals.Get("/:id", handlers.GetGoalByID)
+	goals.Put("/:id", middleware.ManagerOrAdminMiddleware(), handlers.UpdateGoal)
+	goals.Delete("/:id", middleware.ManagerOrAdminMiddleware(), handlers.DeleteGoal)
+	goals.Post("/:id/progress", middleware.ManagerOrAdminMiddleware(), handlers.AddGoalProgress)
+
+	// Rotas de reuniões 1:1 - protegidas
+	developers.Get("/:developerId/one-on-ones", handlers.GetOneOnOnesByDeveloper)
+	developers.Post("/:developerId/one-on-ones", middleware.ManagerOrAdminMiddleware(), handlers.CreateOneOnOne)
+
+	oneOnOnes := protectedWithPasswordCheck.Group("/one-on-ones")
+	oneOnOnes.Get("/:id", handlers.GetOneOnOneByID)
+	oneOnOnes.Put("/:id", middleware.ManagerOrAdminMiddleware(), handlers.UpdateOneOnOne)
+	oneOnOnes.Delete("/:id", middleware.ManagerOrAdminMiddleware(), handlers.DeleteOneOnOne)
+	oneOnOnes.Post("/:id/action-items", middleware.ManagerOrAdminMiddleware(), handlers.CreateOneOnOneActionItem)
+	oneOnOnes.Put("/:id/action-items/:itemId", handlers.UpdateOneOnOneActionItem)
+	oneOnOnes.Delete("/:id/action-items/:itemId", middleware.ManagerOrAdminMiddleware(), handlers.DeleteOneOnOneActionItem)
 }
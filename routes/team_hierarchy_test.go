@@ -0,0 +1,136 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/models"
+)
+
+// seedBackendTeam cria o time Backend abaixo de Platform e um desenvolvedor no próprio
+// departamento, cada um com um relatório
+func seedBackendTeam(t *testing.T, env *integrationEnv, tenant *tenantFixture) uuid.UUID {
+	t.Helper()
+
+	var backendID, backendDev, deptDev uuid.UUID
+	mustGet := func(dest interface{}, query string, args ...interface{}) {
+		t.Helper()
+		if err := env.DB.Get(dest, query, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustGet(&backendID, "INSERT INTO teams (name, company_id, kind, parent_id) VALUES ('Backend', $1, 'team', $2) RETURNING id",
+		tenant.CompanyID, tenant.TeamID)
+	mustGet(&backendDev, "INSERT INTO developers (name, role, team_id, company_id) VALUES ('Backend dev', 'Engineer', $1, $2) RETURNING id",
+		backendID, tenant.CompanyID)
+	mustGet(&deptDev, "INSERT INTO developers (name, role, team_id, company_id) VALUES ('Head', 'Director', $1, $2) RETURNING id",
+		tenant.DeptID, tenant.CompanyID)
+
+	for _, report := range []struct {
+		developer, team uuid.UUID
+		month           string
+		score           float64
+	}{
+		{backendDev, backendID, testMonth, 6},
+		{deptDev, tenant.DeptID, "2025-02", 10},
+	} {
+		if _, err := env.DB.Exec(`
+			INSERT INTO performance_reports (developer_id, month, question_scores, category_scores, weighted_average_score, team_id)
+			VALUES ($1, $2, '{}', '{}', $3, $4)
+		`, report.developer, report.month, report.score, report.team); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return backendID
+}
+
+func TestMoveTeamRejectsCycles(t *testing.T) {
+	env := setupIntegration(t)
+	alpha, beta := env.Tenants[0], env.Tenants[1]
+	backendID := seedBackendTeam(t, env, alpha)
+	target := "/api/v1/teams/" + alpha.DeptID.String() + "/move"
+
+	for name, parentID := range map[string]uuid.UUID{
+		"o próprio time": alpha.DeptID,
+		"um filho":       alpha.TeamID,
+		"um neto":        backendID,
+	} {
+		status, raw := doRequest(t, env, "PUT", target, alpha.token("manager"), map[string]interface{}{"parentId": parentID})
+		if status != 400 || !strings.Contains(raw, "TEAM_HIERARCHY_CYCLE") {
+			t.Errorf("mover o departamento para %s: %d %s; esperado 400 TEAM_HIERARCHY_CYCLE", name, status, raw)
+		}
+	}
+
+	status, raw := doRequest(t, env, "PUT", target, alpha.token("manager"), map[string]interface{}{"parentId": beta.DeptID})
+	if status != 400 || !strings.Contains(raw, "PARENT_TEAM_NOT_IN_COMPANY") {
+		t.Errorf("mover para time de outra empresa: %d %s; esperado 400 PARENT_TEAM_NOT_IN_COMPANY", status, raw)
+	}
+
+	// Mover para fora da própria subárvore é aceito, inclusive para a raiz
+	status, raw = doRequest(t, env, "PUT", "/api/v1/teams/"+backendID.String()+"/move", alpha.token("manager"), map[string]interface{}{"parentId": alpha.DeptID})
+	if status != 200 {
+		t.Fatalf("mover Backend para o departamento: %d %s", status, raw)
+	}
+	var parentID *uuid.UUID
+	if err := env.DB.Get(&parentID, "SELECT parent_id FROM teams WHERE id = $1", backendID); err != nil {
+		t.Fatal(err)
+	}
+	if parentID == nil || *parentID != alpha.DeptID {
+		t.Errorf("pai de Backend: %v; esperado %s", parentID, alpha.DeptID)
+	}
+
+	status, raw = doRequest(t, env, "PUT", "/api/v1/teams/"+backendID.String()+"/move", alpha.token("manager"), map[string]interface{}{"parentId": nil})
+	if status != 200 {
+		t.Fatalf("mover Backend para a raiz: %d %s", status, raw)
+	}
+}
+
+func TestTeamStatsRollUpSubtree(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+	seedBackendTeam(t, env, alpha)
+
+	stats := func(query string) models.TeamStats {
+		t.Helper()
+		status, raw := doRequest(t, env, "GET", "/api/v1/teams/"+alpha.DeptID.String()+"/stats"+query, alpha.token("manager"), nil)
+		if status != 200 {
+			t.Fatalf("estatísticas%s: %d %s", query, status, raw)
+		}
+		var body struct {
+			Data models.TeamStats `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(raw), &body); err != nil {
+			t.Fatal(err)
+		}
+		return body.Data
+	}
+
+	// Departamento: nota 10 própria; Platform: 8; Backend (abaixo de Platform): 6
+	dept := stats("")
+	if dept.DeveloperCount != 1 || dept.ReportCount != 1 || dept.AverageScore != 10 {
+		t.Errorf("números próprios do departamento: %+v", dept)
+	}
+	if dept.Rollup != (models.TeamStatsTotal{DeveloperCount: 3, ReportCount: 3, AverageScore: 8}) {
+		t.Errorf("consolidado do departamento: %+v", dept.Rollup)
+	}
+	if len(dept.Children) != 1 || len(dept.Children[0].Children) != 1 {
+		t.Fatalf("árvore de estatísticas: %+v", dept)
+	}
+	platform := dept.Children[0]
+	if platform.AverageScore != 8 || platform.Rollup != (models.TeamStatsTotal{DeveloperCount: 2, ReportCount: 2, AverageScore: 7}) {
+		t.Errorf("estatísticas de Platform: %+v", platform)
+	}
+
+	// O filtro de mês vale para todos os níveis da árvore
+	month := stats("?month=" + testMonth)
+	if month.ReportCount != 0 || month.AverageScore != 0 {
+		t.Errorf("departamento no mês: %+v", month)
+	}
+	if month.Rollup != (models.TeamStatsTotal{DeveloperCount: 3, ReportCount: 2, AverageScore: 7}) {
+		t.Errorf("consolidado do departamento no mês: %+v", month.Rollup)
+	}
+}
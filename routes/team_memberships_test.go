@@ -0,0 +1,94 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"tivix-performance-tracker-backend/models"
+)
+
+func TestTeamRosterAsOfPastDate(t *testing.T) {
+	env := setupIntegration(t)
+	alpha := env.Tenants[0]
+
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := env.DB.Exec(query, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A troca de time passa pelos triggers do histórico; as datas são fixadas depois
+	var mobileID uuid.UUID
+	if err := env.DB.Get(&mobileID, "INSERT INTO teams (name, company_id, kind, parent_id) VALUES ('Mobile', $1, 'team', $2) RETURNING id",
+		alpha.CompanyID, alpha.DeptID); err != nil {
+		t.Fatal(err)
+	}
+	exec("UPDATE developers SET team_id = $1 WHERE id = $2", mobileID, alpha.DevID)
+	exec("UPDATE team_memberships SET joined_at = '2024-01-01 09:00:00', left_at = '2024-06-30 18:00:00' WHERE developer_id = $1 AND team_id = $2",
+		alpha.DevID, alpha.TeamID)
+	exec("UPDATE team_memberships SET joined_at = '2024-06-30 18:00:00' WHERE developer_id = $1 AND team_id = $2", alpha.DevID, mobileID)
+
+	roster := func(teamID uuid.UUID, date string) []uuid.UUID {
+		t.Helper()
+		status, raw := doRequest(t, env, "GET", "/api/v1/teams/"+teamID.String()+"/roster?date="+date, alpha.token("manager"), nil)
+		if status != 200 {
+			t.Fatalf("roster de %s em %s: %d %s", teamID, date, status, raw)
+		}
+		var body struct {
+			Data []models.TeamMembership `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(raw), &body); err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]uuid.UUID, len(body.Data))
+		for i, membership := range body.Data {
+			ids[i] = membership.DeveloperID
+		}
+		return ids
+	}
+
+	cases := []struct {
+		date     string
+		platform int
+		mobile   int
+	}{
+		{"2023-12-31", 0, 0},
+		// A data vale até o fim do dia: quem entrou no próprio dia já aparece
+		{"2024-01-01", 1, 0},
+		{"2024-06-29", 1, 0},
+		// e quem saiu no dia já está no time novo
+		{"2024-06-30", 0, 1},
+		{"2025-01-15", 0, 1},
+	}
+	for _, tc := range cases {
+		if ids := roster(alpha.TeamID, tc.date); len(ids) != tc.platform {
+			t.Errorf("%s: Platform com %v; esperado %d integrante(s)", tc.date, ids, tc.platform)
+		}
+		if ids := roster(mobileID, tc.date); len(ids) != tc.mobile || (tc.mobile == 1 && ids[0] != alpha.DevID) {
+			t.Errorf("%s: Mobile com %v; esperado %d integrante(s)", tc.date, ids, tc.mobile)
+		}
+	}
+
+	status, raw := doRequest(t, env, "GET", "/api/v1/teams/"+alpha.TeamID.String()+"/roster?date=2024-13-01", alpha.token("manager"), nil)
+	if status != 400 {
+		t.Errorf("data inválida: %d %s; esperado 400", status, raw)
+	}
+
+	status, raw = doRequest(t, env, "GET", "/api/v1/developers/"+alpha.DevID.String()+"/team-history", alpha.token("manager"), nil)
+	if status != 200 {
+		t.Fatalf("histórico de times: %d %s", status, raw)
+	}
+	var history struct {
+		Data []models.TeamMembership `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(raw), &history); err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Data) != 2 || history.Data[0].TeamID != alpha.TeamID || history.Data[0].LeftAt == nil ||
+		history.Data[1].TeamID != mobileID || history.Data[1].LeftAt != nil {
+		t.Errorf("histórico de times: %+v", history.Data)
+	}
+}
@@ -0,0 +1,470 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"tivix-performance-tracker-backend/database"
+)
+
+// Rotas públicas ou de inicialização, que não recebem contexto de empresa
+var tenantAgnosticRoutes = map[string]bool{
+	"POST /api/v1/auth/login": true,
+	"GET /api/v1/init/check":  true,
+	"POST /api/v1/init/admin": true,
+	// Arquivos estáticos da Swagger UI
+	"GET /api/v1/docs/:file": true,
+}
+
+// Tabelas de empresa e o filtro que seleciona as linhas de uma empresa ($1)
+var tenantTables = []struct {
+	Table string
+	Where string
+}{
+	{"companies", "id = $1"},
+	{"users", "company_id = $1"},
+	{"teams", "company_id = $1"},
+	{"developers", "company_id = $1"},
+	{"performance_reports", "developer_id IN (SELECT id FROM developers WHERE company_id = $1)"},
+	{"goals", "company_id = $1"},
+	{"goal_progress_updates", "goal_id IN (SELECT id FROM goals WHERE company_id = $1)"},
+	{"one_on_ones", "company_id = $1"},
+	{"one_on_one_action_items", "one_on_one_id IN (SELECT id FROM one_on_ones WHERE company_id = $1)"},
+	{"report_comments", "company_id = $1"},
+	{"report_comment_reads", "report_id IN (SELECT pr.id FROM performance_reports pr INNER JOIN developers d ON d.id = pr.developer_id WHERE d.company_id = $1)"},
+	{"audit_logs", "company_id = $1"},
+	{"team_memberships", "company_id = $1"},
+	{"career_tracks", "company_id = $1"},
+	{"career_levels", "company_id = $1"},
+	{"developer_level_changes", "company_id = $1"},
+	{"skills", "company_id = $1"},
+	{"developer_skill_assessments", "company_id = $1"},
+	{"developer_skills", "company_id = $1"},
+	{"company_settings", "company_id = $1"},
+}
+
+// Referências que nunca podem cruzar empresas; cada consulta deve retornar zero
+var crossTenantReferences = map[string]string{
+	"developer in another company's team": `
+		SELECT COUNT(*) FROM developers d INNER JOIN teams t ON t.id = d.team_id
+		WHERE t.company_id IS DISTINCT FROM d.company_id`,
+	"team under another company's parent": `
+		SELECT COUNT(*) FROM teams t INNER JOIN teams p ON p.id = t.parent_id
+		WHERE p.company_id IS DISTINCT FROM t.company_id`,
+	"developer linked to another company's user": `
+		SELECT COUNT(*) FROM developers d INNER JOIN users u ON u.id = d.user_id
+		WHERE u.role <> 'admin' AND u.company_id IS DISTINCT FROM d.company_id`,
+	"developer at another company's career level": `
+		SELECT COUNT(*) FROM developers d INNER JOIN career_levels l ON l.id = d.level_id
+		WHERE l.company_id IS DISTINCT FROM d.company_id`,
+	"report stamped with another company's team": `
+		SELECT COUNT(*) FROM performance_reports pr
+		INNER JOIN developers d ON d.id = pr.developer_id
+		INNER JOIN teams t ON t.id = pr.team_id
+		WHERE t.company_id IS DISTINCT FROM d.company_id`,
+	"goal for another company's developer": `
+		SELECT COUNT(*) FROM goals g INNER JOIN developers d ON d.id = g.developer_id
+		WHERE d.company_id IS DISTINCT FROM g.company_id`,
+	"goal owned by another company's user": `
+		SELECT COUNT(*) FROM goals g INNER JOIN users u ON u.id = g.owner_id
+		WHERE u.role <> 'admin' AND u.company_id IS DISTINCT FROM g.company_id`,
+	"one-on-one for another company's developer": `
+		SELECT COUNT(*) FROM one_on_ones o INNER JOIN developers d ON d.id = o.developer_id
+		WHERE d.company_id IS DISTINCT FROM o.company_id`,
+	"comment on another company's report": `
+		SELECT COUNT(*) FROM report_comments rc
+		INNER JOIN performance_reports pr ON pr.id = rc.report_id
+		INNER JOIN developers d ON d.id = pr.developer_id
+		WHERE d.company_id IS DISTINCT FROM rc.company_id`,
+	"skill assessment across companies": `
+		SELECT COUNT(*) FROM developer_skill_assessments a
+		INNER JOIN skills s ON s.id = a.skill_id
+		INNER JOIN developers d ON d.id = a.developer_id
+		WHERE s.company_id IS DISTINCT FROM d.company_id`,
+	"level change across companies": `
+		SELECT COUNT(*) FROM developer_level_changes c
+		INNER JOIN developers d ON d.id = c.developer_id
+		WHERE d.company_id IS DISTINCT FROM c.company_id`,
+}
+
+// TestCrossTenantIsolation chama todas as rotas registradas como admin, gestor e usuário de cada
+// empresa, apontando parâmetros, query string e corpo para registros da outra empresa
+func TestCrossTenantIsolation(t *testing.T) {
+	env := setupIntegration(t)
+
+	for _, attacker := range env.Tenants {
+		for _, victim := range env.Tenants {
+			if attacker == victim {
+				continue
+			}
+
+			for _, route := range tenantRoutes(env) {
+				path, err := victimPath(route.Path, victim)
+				if err != nil {
+					t.Fatalf("%s %s: %v (inclua o parâmetro no teste de isolamento)", route.Method, route.Path, err)
+				}
+				target := path + "?" + victimQuery(victim)
+
+				for _, role := range []string{"manager", "user"} {
+					name := fmt.Sprintf("%s-%s/%s %s", attacker.Name, role, route.Method, route.Path)
+					t.Run(name, func(t *testing.T) {
+						before := tenantDigest(t, env.DB, victim)
+
+						status, body := doRequest(t, env, route.Method, target, attacker.token(role), victimBody(victim))
+
+						if status >= 200 && status < 300 {
+							for _, id := range victim.ids() {
+								if strings.Contains(body, id) {
+									t.Errorf("resposta %d expõe registro %s da empresa %s: %s", status, id, victim.Name, body)
+									break
+								}
+							}
+						}
+
+						if after := tenantDigest(t, env.DB, victim); after != before {
+							t.Errorf("requisição (status %d) alterou dados da empresa %s: %s", status, victim.Name, body)
+						}
+
+						assertNoCrossTenantReferences(t, env.DB)
+					})
+				}
+
+				// Administradores têm acesso global por definição; as leituras servem de controle
+				// de que os parâmetros apontam para registros existentes
+				if route.Method == http.MethodGet && strings.Contains(route.Path, ":") {
+					t.Run(fmt.Sprintf("%s-admin/%s %s", attacker.Name, route.Method, route.Path), func(t *testing.T) {
+						status, body := doRequest(t, env, route.Method, target, attacker.token("admin"), nil)
+						if status < 200 || status >= 300 {
+							t.Errorf("controle como admin retornou %d: %s", status, body)
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+// TestRowLevelSecurityPolicies verifica o isolamento diretamente no banco, sem filtros nas consultas
+func TestRowLevelSecurityPolicies(t *testing.T) {
+	env := setupIntegration(t)
+	requireRLS(t, env)
+
+	tenant := env.Tenants[0]
+	other := env.Tenants[1]
+
+	tx, err := database.BeginTenant(context.Background(), &tenant.CompanyID, tenant.ManagerID, false, false)
+	if err != nil {
+		t.Fatalf("abrir transação da empresa: %v", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range tenantTables {
+		var foreign int
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", table.Table, table.Where)
+		if err := tx.Get(&foreign, query, other.CompanyID); err != nil {
+			t.Fatalf("%s: %v", table.Table, err)
+		}
+		if foreign != 0 {
+			t.Errorf("%s: %d linhas de outra empresa visíveis sem filtro", table.Table, foreign)
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE developers SET name = 'hijacked' WHERE id = $1", other.DevID); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	var name string
+	if err := env.DB.Get(&name, "SELECT name FROM developers WHERE id = $1", other.DevID); err != nil {
+		t.Fatal(err)
+	}
+	if name == "hijacked" {
+		t.Error("UPDATE sem filtro alterou desenvolvedor de outra empresa")
+	}
+
+	if _, err := tx.Exec("SAVEPOINT foreign_insert"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec("INSERT INTO teams (name, company_id) VALUES ('intruder', $1)", other.CompanyID); err == nil {
+		t.Error("INSERT com company_id de outra empresa foi aceito")
+	}
+	tx.Exec("ROLLBACK TO SAVEPOINT foreign_insert")
+
+	admin, err := database.BeginTenant(context.Background(), &tenant.CompanyID, tenant.AdminID, true, false)
+	if err != nil {
+		t.Fatalf("abrir transação de admin: %v", err)
+	}
+	defer admin.Rollback()
+
+	var visible int
+	if err := admin.Get(&visible, "SELECT COUNT(*) FROM developers WHERE company_id = $1", other.CompanyID); err != nil {
+		t.Fatal(err)
+	}
+	if visible == 0 {
+		t.Error("bypass de admin não permitiu ler outra empresa")
+	}
+}
+
+// requireRLS interrompe o teste quando o banco não aplica as políticas de RLS: no CI (variável
+// CI definida) é uma falha, para que as políticas nunca deixem de ser testadas; localmente, o
+// teste é ignorado
+// TestReadTransactionsDoNotBlockEachOther verifica que transações de leitura (requisições GET)
+// não reservam a escrita: no SQLite, com _txlock=immediate, a segunda esperaria a primeira
+func TestReadTransactionsDoNotBlockEachOther(t *testing.T) {
+	env := setupIntegration(t)
+	tenant := env.Tenants[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var count int
+	for i := 0; i < 2; i++ {
+		tx, err := database.BeginTenant(ctx, &tenant.CompanyID, tenant.ManagerID, false, true)
+		if err != nil {
+			t.Fatalf("abrir transação de leitura %d: %v", i+1, err)
+		}
+		defer tx.Rollback()
+
+		if err := tx.Get(&count, "SELECT COUNT(*) FROM developers WHERE company_id = $1", tenant.CompanyID); err != nil {
+			t.Fatalf("ler na transação %d: %v", i+1, err)
+		}
+	}
+
+	writer, err := database.BeginTenant(ctx, &tenant.CompanyID, tenant.ManagerID, false, false)
+	if err != nil {
+		t.Fatalf("abrir transação de escrita com leituras em andamento: %v", err)
+	}
+	writer.Rollback()
+}
+
+func requireRLS(t *testing.T, env *integrationEnv) {
+	t.Helper()
+
+	if env.RLSEnforced {
+		return
+	}
+	if os.Getenv("CI") != "" {
+		t.Fatalf("CI sem PostgreSQL com RLS aplicado: defina %s (veja routes/harness_test.go)", testDatabaseURLEnv)
+	}
+	t.Skip("banco de teste sem RLS aplicado")
+}
+
+type testRoute struct {
+	Method string
+	Path   string
+}
+
+// tenantRoutes lista as rotas registradas em SetupRoutes que recebem contexto de usuário
+func tenantRoutes(env *integrationEnv) []testRoute {
+	seen := map[string]bool{}
+	var result []testRoute
+	for _, route := range env.App.GetRoutes(true) {
+		switch route.Method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete:
+		default:
+			continue
+		}
+		path := strings.TrimSuffix(route.Path, "/")
+		key := route.Method + " " + path
+		if seen[key] || tenantAgnosticRoutes[key] || !strings.HasPrefix(path, "/api/v1/") {
+			continue
+		}
+		seen[key] = true
+		result = append(result, testRoute{Method: route.Method, Path: path})
+	}
+	return result
+}
+
+// victimPath substitui os parâmetros da rota por registros da empresa alvo
+func victimPath(path string, victim *tenantFixture) (string, error) {
+	byParam := map[string]string{
+		"teamId":      victim.TeamID.String(),
+		"developerId": victim.DevID.String(),
+		"levelId":     victim.LevelID.String(),
+		"skillId":     victim.SkillID.String(),
+		"commentId":   victim.CommentID.String(),
+		"itemId":      victim.ItemID.String(),
+		"month":       testMonth,
+	}
+	// :id depende do recurso que o antecede
+	byResource := map[string]string{
+		"teams":               victim.TeamID.String(),
+		"developers":          victim.DevID.String(),
+		"performance-reports": victim.ReportID.String(),
+		"goals":               victim.GoalID.String(),
+		"one-on-ones":         victim.MeetingID.String(),
+		"tracks":              victim.TrackID.String(),
+		"skills":              victim.SkillID.String(),
+		"companies":           victim.CompanyID.String(),
+		"users":               victim.UserID.String(),
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		param := strings.TrimPrefix(segment, ":")
+		value, ok := byParam[param]
+		if param == "id" && i > 0 {
+			value, ok = byResource[segments[i-1]]
+		}
+		if !ok {
+			return "", fmt.Errorf("parâmetro %s sem registro correspondente", segment)
+		}
+		segments[i] = value
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// victimQuery aponta os filtros de query string para a empresa alvo
+func victimQuery(victim *tenantFixture) string {
+	return fmt.Sprintf(
+		"companyId=%s&developerId=%s&teamId=%s&skillId=%s&month=%s&includeSubteams=true",
+		victim.CompanyID, victim.DevID, victim.TeamID, victim.SkillID, testMonth,
+	)
+}
+
+// victimBody monta um corpo válido para as requisições de escrita, com todas as referências
+// apontando para a empresa alvo
+func victimBody(victim *tenantFixture) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                 "Cross tenant probe",
+		"title":                "Cross tenant probe",
+		"description":          "probe",
+		"body":                 "probe",
+		"note":                 "probe",
+		"notes":                "probe",
+		"justification":        "probe",
+		"role":                 "user",
+		"email":                fmt.Sprintf("probe-%s@example.com", uuid.NewString()[:8]),
+		"password":             "Probe-password-123",
+		"kind":                 "team",
+		"color":                "blue",
+		"code":                 "PX",
+		"rank":                 9,
+		"level":                3,
+		"progress":             50,
+		"status":               "in_progress",
+		"visibility":           "shared",
+		"month":                "2025-02",
+		"meetingDate":          "2025-02-01",
+		"effectiveDate":        "2025-02-01",
+		"weightedAverageScore": 8,
+		"questionScores":       map[string]interface{}{},
+		"categoryScores":       map[string]interface{}{},
+		"agenda":               []string{"probe"},
+		"categories":           []string{"probe"},
+		"expectations":         []string{"probe"},
+		"archive":              true,
+		"completed":            true,
+		"companyId":            victim.CompanyID,
+		"developerId":          victim.DevID,
+		"teamId":               victim.TeamID,
+		"parentId":             victim.TeamID,
+		"userId":               victim.UserID,
+		"ownerId":              victim.ManagerID,
+		"levelId":              victim.LevelID,
+		"skillId":              victim.SkillID,
+		"reportId":             victim.ReportID,
+		"mentions":             []uuid.UUID{victim.ManagerID},
+	}
+}
+
+func doRequest(t *testing.T, env *integrationEnv, method, target, token string, body map[string]interface{}) (int, string) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := env.App.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, target, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+// tenantDigest resume todas as linhas da empresa para detectar qualquer escrita
+func tenantDigest(t *testing.T, db *sqlx.DB, tenant *tenantFixture) string {
+	t.Helper()
+
+	var parts []string
+	for _, table := range tenantTables {
+		rows, err := db.Queryx(fmt.Sprintf("SELECT * FROM %s t WHERE %s", table.Table, table.Where), tenant.CompanyID)
+		if err != nil {
+			t.Fatalf("digest %s: %v", table.Table, err)
+		}
+
+		var encoded []string
+		for rows.Next() {
+			row := make(map[string]interface{})
+			if err := rows.MapScan(row); err != nil {
+				rows.Close()
+				t.Fatalf("digest %s: %v", table.Table, err)
+			}
+			for column, value := range row {
+				if b, ok := value.([]byte); ok {
+					row[column] = string(b)
+				}
+			}
+			line, err := json.Marshal(row)
+			if err != nil {
+				rows.Close()
+				t.Fatalf("digest %s: %v", table.Table, err)
+			}
+			encoded = append(encoded, string(line))
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatalf("digest %s: %v", table.Table, err)
+		}
+		rows.Close()
+
+		sort.Strings(encoded)
+		sum := sha256.Sum256([]byte(strings.Join(encoded, "|")))
+		parts = append(parts, table.Table+"="+hex.EncodeToString(sum[:]))
+	}
+	return strings.Join(parts, ";")
+}
+
+func assertNoCrossTenantReferences(t *testing.T, db *sqlx.DB) {
+	t.Helper()
+
+	for description, query := range crossTenantReferences {
+		var count int
+		if err := db.Get(&count, query); err != nil {
+			t.Fatalf("%s: %v", description, err)
+		}
+		if count != 0 {
+			t.Errorf("%d registro(s) com %s", count, description)
+		}
+	}
+}
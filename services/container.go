@@ -0,0 +1,45 @@
+// Package services reúne as dependências compartilhadas pelos handlers
+package services
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"tivix-performance-tracker-backend/config"
+	"tivix-performance-tracker-backend/repository"
+)
+
+const containerKey = "services"
+
+// Container agrupa as dependências injetadas nos handlers a cada requisição
+type Container struct {
+	Repositories repository.Provider
+	// Config é a configuração carregada na inicialização
+	Config *config.Config
+}
+
+// New cria um container com o provider de repositórios e a configuração informados
+func New(repositories repository.Provider, cfg *config.Config) *Container {
+	return &Container{Repositories: repositories, Config: cfg}
+}
+
+// Default retorna um container com repositórios PostgreSQL e a configuração padrão, sem
+// segredo JWT; a aplicação registra o container criado com a configuração carregada
+func Default() *Container {
+	return New(repository.PostgresProvider{}, config.Defaults())
+}
+
+// Middleware disponibiliza o container para os handlers da requisição
+func (s *Container) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(containerKey, s)
+		return c.Next()
+	}
+}
+
+// From retorna o container da requisição; sem o middleware, usa o container padrão
+func From(c *fiber.Ctx) *Container {
+	if container, ok := c.Locals(containerKey).(*Container); ok {
+		return container
+	}
+	return Default()
+}
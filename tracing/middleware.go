@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"tivix-performance-tracker-backend/apierror"
+	"tivix-performance-tracker-backend/logging"
+)
+
+// headerCarrier adapta os cabeçalhos da requisição do Fiber à propagação do OpenTelemetry
+type headerCarrier struct{ c *fiber.Ctx }
+
+func (h headerCarrier) Get(key string) string { return h.c.Get(key) }
+
+func (h headerCarrier) Set(key, value string) { h.c.Request().Header.Set(key, value) }
+
+func (h headerCarrier) Keys() []string {
+	var keys []string
+	h.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// Middleware abre o span de servidor de cada requisição, continuando o trace recebido em
+// traceparent, e o disponibiliza em c.UserContext() para os spans de JWT e SQL. O trace_id
+// é acrescentado ao logger da requisição; por isso deve vir depois de logging.Middleware.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+
+		ctx, span := Tracer().Start(ctx, c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+				semconv.ClientAddress(c.IP()),
+				semconv.UserAgentOriginal(c.Get(fiber.HeaderUserAgent)),
+			),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+		if spanContext := span.SpanContext(); spanContext.IsValid() {
+			logging.With(c, "trace_id", spanContext.TraceID().String(), "span_id", spanContext.SpanID().String())
+		}
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = apierror.Status(err)
+		}
+
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,92 @@
+// Package tracing configura o OpenTelemetry: exportação dos spans (OTLP ou stdout),
+// propagação W3C Trace Context e o span de cada requisição HTTP
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"tivix-performance-tracker-backend/config"
+)
+
+// Exportadores aceitos em TRACING_EXPORTER
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Tracer é o tracer dos spans criados pela aplicação
+func Tracer() trace.Tracer {
+	return otel.Tracer("tivix-performance-tracker-backend")
+}
+
+// Setup registra o tracer provider e o propagador globais conforme a configuração e
+// retorna a função que envia os spans pendentes e encerra o exportador. Com o exportador
+// none, os spans não são gravados, mas o contexto recebido em traceparent continua sendo
+// propagado.
+func Setup(ctx context.Context, cfg *config.Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, err := newExporter(ctx, cfg, os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.TracingServiceName),
+		semconv.DeploymentEnvironment(cfg.Environment),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("falha ao montar o resource do tracing: %w", err)
+	}
+
+	// No stdout (desenvolvimento) cada span é escrito ao terminar; no OTLP os spans seguem em lotes
+	processor := sdktrace.NewBatchSpanProcessor(exporter)
+	if cfg.TracingExporter == ExporterStdout {
+		processor = sdktrace.NewSimpleSpanProcessor(exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, cfg *config.Config, stdout io.Writer) (sdktrace.SpanExporter, error) {
+	switch cfg.TracingExporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(stdout))
+	case ExporterOTLP:
+		var options []otlptracehttp.Option
+		if cfg.TracingEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(cfg.TracingEndpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, options...)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao criar exportador OTLP: %w", err)
+		}
+		return exporter, nil
+	}
+	return nil, fmt.Errorf("TRACING_EXPORTER %q inválido (use %s, %s ou %s)", cfg.TracingExporter, ExporterNone, ExporterOTLP, ExporterStdout)
+}
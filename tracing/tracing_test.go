@@ -0,0 +1,84 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"tivix-performance-tracker-backend/database"
+	"tivix-performance-tracker-backend/tracing"
+)
+
+func TestSanitizeQuery(t *testing.T) {
+	query := `SELECT id, uuid_generate_v4() FROM users
+		WHERE email = 'ana@tivix.com.br' AND id = $1 AND level > 3 LIMIT 50`
+
+	expected := "SELECT id, uuid_generate_v4() FROM users WHERE email = ? AND id = $1 AND level > ? LIMIT ?"
+	if got := database.SanitizeQuery(query); got != expected {
+		t.Errorf("SanitizeQuery:\n  obtido   %q\n  esperado %q", got, expected)
+	}
+}
+
+func TestRequestAndQuerySpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "tracing.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	app := fiber.New()
+	app.Use(tracing.Middleware())
+	app.Get("/items/:id", func(c *fiber.Ctx) error {
+		var value int
+		if err := database.Trace(c.UserContext(), db).Get(&value, "SELECT 42 WHERE 'x' = $1", "x"); err != nil {
+			return err
+		}
+		return c.JSON(value)
+	})
+
+	req := httptest.NewRequest("GET", "/items/7", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if _, err := app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("esperava os spans da query e da requisição, obteve %d", len(spans))
+	}
+	query, server := spans[0], spans[1]
+
+	if server.Name != "GET /items/:id" || server.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("span da requisição inesperado: %s, trace %s", server.Name, server.SpanContext.TraceID())
+	}
+	if server.Parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("o span da requisição deveria continuar o traceparent recebido, pai %s", server.Parent.SpanID())
+	}
+
+	if query.Name != "SELECT" || query.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Errorf("span da query inesperado: %s, pai %s", query.Name, query.Parent.SpanID())
+	}
+	var text string
+	for _, attr := range query.Attributes {
+		if attr.Key == semconv.DBQueryTextKey {
+			text = attr.Value.AsString()
+		}
+	}
+	if text != "SELECT ? WHERE ? = $1" {
+		t.Errorf("texto da query não sanitizado: %q", text)
+	}
+}
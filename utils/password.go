@@ -2,7 +2,6 @@ package utils
 
 import (
 	"crypto/rand"
-	"errors"
 	"math/big"
 	"regexp"
 	"strings"
@@ -48,13 +47,24 @@ func GenerateTemporaryPassword() (string, error) {
 	return string(password), nil
 }
 
+// PasswordError indica a regra da política de senhas que a senha não atende
+type PasswordError struct {
+	Rule    string
+	Message string
+}
+
+func (e *PasswordError) Error() string { return e.Message }
+
+// PasswordRule identifica a regra violada nos detalhes do erro da API
+func (e *PasswordError) PasswordRule() string { return e.Rule }
+
 func ValidatePassword(password string) error {
 	if len(password) < 12 {
-		return errors.New("senha deve ter pelo menos 12 caracteres")
+		return &PasswordError{Rule: "password_min_length", Message: "senha deve ter pelo menos 12 caracteres"}
 	}
 
 	if len(password) > 128 {
-		return errors.New("senha deve ter no máximo 128 caracteres")
+		return &PasswordError{Rule: "password_max_length", Message: "senha deve ter no máximo 128 caracteres"}
 	}
 
 	hasUpper := regexp.MustCompile(`[A-Z]`).MatchString(password)
@@ -63,23 +73,23 @@ func ValidatePassword(password string) error {
 	hasSymbol := regexp.MustCompile(`[!@#$%&*+\-=?]`).MatchString(password)
 
 	if !hasUpper {
-		return errors.New("senha deve conter pelo menos uma letra maiúscula")
+		return &PasswordError{Rule: "password_uppercase", Message: "senha deve conter pelo menos uma letra maiúscula"}
 	}
 
 	if !hasLower {
-		return errors.New("senha deve conter pelo menos uma letra minúscula")
+		return &PasswordError{Rule: "password_lowercase", Message: "senha deve conter pelo menos uma letra minúscula"}
 	}
 
 	if !hasNumber {
-		return errors.New("senha deve conter pelo menos um número")
+		return &PasswordError{Rule: "password_number", Message: "senha deve conter pelo menos um número"}
 	}
 
 	if !hasSymbol {
-		return errors.New("senha deve conter pelo menos um símbolo especial (!@#$%&*+-=?)")
+		return &PasswordError{Rule: "password_symbol", Message: "senha deve conter pelo menos um símbolo especial (!@#$%&*+-=?)"}
 	}
 
 	if regexp.MustCompile(`(.)\1{2,}`).MatchString(password) {
-		return errors.New("senha não pode conter mais de 2 caracteres consecutivos iguais")
+		return &PasswordError{Rule: "password_repeated", Message: "senha não pode conter mais de 2 caracteres consecutivos iguais"}
 	}
 
 	sequences := []string{
@@ -89,7 +99,7 @@ func ValidatePassword(password string) error {
 
 	for _, seq := range sequences {
 		if strings.Contains(strings.ToLower(password), seq) {
-			return errors.New("senha não pode conter sequências comuns ou palavras óbvias")
+			return &PasswordError{Rule: "password_common", Message: "senha não pode conter sequências comuns ou palavras óbvias"}
 		}
 	}
 